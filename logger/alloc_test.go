@@ -0,0 +1,75 @@
+//go:build !binary_log
+
+package logger_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// binary_log 빌드의 zerolog 는 CBOR 출력을 JSON 으로 다시 인코딩하므로 할당 테스트는 기본 빌드에서만 실행한다.
+
+func TestAllocs(t *testing.T) {
+	err := errors.New("card declined")
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		loggerType := loggerType
+		t.Run(string(loggerType)+" 로거의 비활성 레벨 LogAttrs 호출이 할당하지 않는지 테스트", func(t *testing.T) {
+			// given
+			log := logger.NewWrapper(loggerType, options.WithOutput(io.Discard), options.WithLevel(types.Info))
+			log.RegisterCommonField("service", "payment")
+
+			// when
+			allocs := testing.AllocsPerRun(100, func() {
+				logger.LogAttrs(log, types.Debug, "paid", options.String("order_id", "A-1"), options.Err(err))
+			})
+
+			// then
+			assert.Zero(t, allocs)
+		})
+
+		t.Run(string(loggerType)+" 로거의 비활성 레벨 Log 호출이 옵션을 할당하지 않는지 테스트", func(t *testing.T) {
+			// given
+			log := logger.NewWrapper(loggerType, options.WithOutput(io.Discard), options.WithLevel(types.Info))
+			log.RegisterCommonField("service", "payment")
+			msg := "paid"
+
+			// when
+			allocs := testing.AllocsPerRun(100, func() {
+				logger.Log(log, types.Debug, options.WithMessage(msg), options.WithAttrs(options.String("order_id", "A-1")))
+			})
+
+			// then
+			assert.Zero(t, allocs)
+		})
+	}
+
+	t.Run("zerolog 로거의 LogAttrs 가 백엔드 버퍼 외에 할당하지 않는지 테스트", func(t *testing.T) {
+		// given
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(io.Discard))
+		log.RegisterCommonFields(options.Fields{"service": "payment", "version": "1.4.2"})
+		paidAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		// when
+		allocs := testing.AllocsPerRun(100, func() {
+			logger.LogAttrs(log, types.Info, "paid",
+				options.String("order_id", "A-1"),
+				options.Int64("amount", 15000),
+				options.Float64("ratio", 0.25),
+				options.Bool("retry", false),
+				options.Duration(types.ElapsedField, 12*time.Millisecond),
+				options.Time("paid_at", paidAt),
+				options.Err(err),
+			)
+		})
+
+		// then
+		assert.Zero(t, allocs)
+	})
+}
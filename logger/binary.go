@@ -0,0 +1,355 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// DecodeBinary : CBOR 또는 MessagePack 로그 스트림을 한 줄에 하나씩 JSON 로그로 변환하는 함수
+//   - dst(io.Writer): JSON 로그를 기록할 곳
+//   - src(io.Reader): types.CBOR 또는 types.MsgPack 포맷으로 기록한 로그 스트림
+//   - format(types.LogFormat): 스트림의 포맷
+//
+// CBOR 는 zerolog 를 binary_log 빌드 태그로 빌드하여 기록한 스트림도 읽을 수 있으며, 이 경우 시간은 RFC3339 형식으로 변환한다.
+//
+// Example:
+//
+//	f, _ := os.Open("app.cbor")
+//	defer f.Close()
+//	err := logger.DecodeBinary(os.Stdout, f, types.CBOR)
+//	// output: {"level":"info","message":"paid","time":"2024-01-01 00:00:00"}
+func DecodeBinary(dst io.Writer, src io.Reader, format types.LogFormat) error {
+	var next func() (interface{}, error)
+	switch format {
+	case types.CBOR:
+		next = newCBORDecoder(src, time.RFC3339Nano).decode
+	case types.MsgPack:
+		next = newMsgPackDecoder(src, time.RFC3339Nano).decode
+	default:
+		return fmt.Errorf("unsupported binary format %q (cbor, msgpack)", format)
+	}
+
+	out := bufio.NewWriter(dst)
+	for {
+		value, err := next()
+		if errors.Is(err, io.EOF) {
+			return out.Flush()
+		}
+		if err != nil {
+			_ = out.Flush()
+			return err
+		}
+
+		line, err := json.Marshal(value)
+		if err != nil {
+			_ = out.Flush()
+			return err
+		}
+		_, _ = out.Write(line)
+		if err := out.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+}
+
+// binaryWriter : CBOR, MessagePack 의 값 하나를 포맷에 맞게 기록하는 인터페이스
+//
+// 문자열은 strHead 로 길이를 기록한 뒤 내용을 그대로 이어 쓴다.
+type binaryWriter interface {
+	null(buf *bytes.Buffer)
+	boolean(buf *bytes.Buffer, v bool)
+	int(buf *bytes.Buffer, n int64)
+	uint(buf *bytes.Buffer, n uint64)
+	float(buf *bytes.Buffer, f float64)
+	strHead(buf *bytes.Buffer, n int)
+	arrayHead(buf *bytes.Buffer, n int)
+	mapHead(buf *bytes.Buffer, n int)
+}
+
+// encodeBinary : 로그 필드 값을 JSON 을 거치지 않고 CBOR 또는 MessagePack 값 하나로 인코딩하는 함수
+//
+// 값은 JSONFormatter 가 기록하는 JSON 과 같은 형태로 표현한다.
+//   - 맵의 키는 이름순으로 기록
+//   - 숫자는 JSON 으로 기록했을 때 정수이면 정수, 아니면 float64
+//   - time.Time 은 RFC3339Nano 문자열
+//   - 그 밖의 값은 JSON 으로 인코딩한 뒤 transcodeJSON 으로 변환
+func encodeBinary(buf *bytes.Buffer, w binaryWriter, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		w.null(buf)
+	case bool:
+		w.boolean(buf, v)
+	case string:
+		w.strHead(buf, len(v))
+		buf.WriteString(v)
+	case stdjson.Number:
+		if !writeBinaryNumber(buf, w, string(v)) {
+			encodeBinary(buf, w, string(v))
+		}
+	case int:
+		w.int(buf, int64(v))
+	case int8:
+		w.int(buf, int64(v))
+	case int16:
+		w.int(buf, int64(v))
+	case int32:
+		w.int(buf, int64(v))
+	case int64:
+		w.int(buf, v)
+	case uint:
+		w.uint(buf, uint64(v))
+	case uint8:
+		w.uint(buf, uint64(v))
+	case uint16:
+		w.uint(buf, uint64(v))
+	case uint32:
+		w.uint(buf, uint64(v))
+	case uint64:
+		w.uint(buf, v)
+	case float32:
+		// JSON 과 같이 float32 의 가장 짧은 표현을 기준으로 변환
+		writeBinaryNumber(buf, w, strconv.FormatFloat(float64(v), 'g', -1, 32))
+	case float64:
+		// JSON 은 정수인 실수를 소수점 없이 기록하므로 정수로 변환
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			w.int(buf, int64(v))
+		} else {
+			w.float(buf, v)
+		}
+	case time.Time:
+		encodeBinary(buf, w, v.Format(time.RFC3339Nano))
+	case []string:
+		w.arrayHead(buf, len(v))
+		for _, item := range v {
+			encodeBinary(buf, w, item)
+		}
+	case []interface{}:
+		w.arrayHead(buf, len(v))
+		for _, item := range v {
+			encodeBinary(buf, w, item)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		w.mapHead(buf, len(v))
+		for _, key := range keys {
+			encodeBinary(buf, w, key)
+			encodeBinary(buf, w, v[key])
+		}
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			encodeBinary(buf, w, err.Error())
+			return
+		}
+		start := buf.Len()
+		if err := transcodeJSON(buf, w, encoded); err != nil {
+			buf.Truncate(start)
+			encodeBinary(buf, w, string(encoded))
+		}
+	}
+}
+
+// writeBinaryNumber : JSON 숫자를 정수로 표현할 수 있으면 정수, 아니면 float64 로 기록하는 함수
+//
+// 숫자가 아니면 기록하지 않고 false 를 반환한다.
+func writeBinaryNumber(buf *bytes.Buffer, w binaryWriter, s string) bool {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		w.int(buf, n)
+	} else if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		w.uint(buf, u)
+	} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+		w.float(buf, f)
+	} else {
+		return false
+	}
+	return true
+}
+
+// transcodeJSON : JSON 값 하나를 맵으로 디코딩하지 않고 CBOR 또는 MessagePack 으로 바로 변환하는 함수
+//
+// 객체의 키는 JSON 의 순서대로 기록하며, 숫자는 writeBinaryNumber 와 같은 규칙으로 변환한다.
+// 올바른 JSON 이 아니면 에러를 반환하며, 이 경우 buf 에는 변환하던 값의 일부가 남을 수 있다.
+func transcodeJSON(buf *bytes.Buffer, w binaryWriter, data []byte) error {
+	t := jsonTranscoder{buf: buf, w: w, data: data}
+	if err := t.value(); err != nil {
+		return err
+	}
+	t.skipSpace()
+	if t.pos != len(t.data) {
+		return t.syntaxError()
+	}
+	return nil
+}
+
+// jsonTranscoder : JSON 을 앞에서부터 한 번 읽으며 바이너리 포맷으로 기록하는 구조체
+//
+// 배열과 맵은 요소를 먼저 기록한 뒤 요소 개수로 만든 길이를 앞에 끼워 넣는다.
+type jsonTranscoder struct {
+	buf  *bytes.Buffer
+	w    binaryWriter
+	data []byte
+	pos  int
+}
+
+func (t *jsonTranscoder) value() error {
+	t.skipSpace()
+	switch t.peek() {
+	case '{':
+		return t.container('}', t.member, t.w.mapHead)
+	case '[':
+		return t.container(']', t.value, t.w.arrayHead)
+	case '"':
+		return t.str()
+	case 't':
+		return t.literal("true", func() { t.w.boolean(t.buf, true) })
+	case 'f':
+		return t.literal("false", func() { t.w.boolean(t.buf, false) })
+	case 'n':
+		return t.literal("null", func() { t.w.null(t.buf) })
+	default:
+		return t.number()
+	}
+}
+
+// container : 배열 또는 객체의 요소를 기록한 뒤 요소 개수로 만든 길이를 앞에 끼워 넣는 메서드
+func (t *jsonTranscoder) container(end byte, element func() error, head func(buf *bytes.Buffer, n int)) error {
+	t.pos++
+	start := t.buf.Len()
+	n := 0
+	t.skipSpace()
+	if t.peek() == end {
+		t.pos++
+	} else {
+		for {
+			if err := element(); err != nil {
+				return err
+			}
+			n++
+			t.skipSpace()
+			if t.peek() == ',' {
+				t.pos++
+				continue
+			}
+			if t.peek() != end {
+				return t.syntaxError()
+			}
+			t.pos++
+			break
+		}
+	}
+
+	last := t.buf.Len()
+	head(t.buf, n)
+	b := t.buf.Bytes()
+	var encoded [9]byte
+	size := copy(encoded[:], b[last:])
+	copy(b[start+size:], b[start:last])
+	copy(b[start:], encoded[:size])
+	return nil
+}
+
+// member : 객체의 키와 값 하나를 기록하는 메서드
+func (t *jsonTranscoder) member() error {
+	t.skipSpace()
+	if t.peek() != '"' {
+		return t.syntaxError()
+	}
+	if err := t.str(); err != nil {
+		return err
+	}
+	t.skipSpace()
+	if t.peek() != ':' {
+		return t.syntaxError()
+	}
+	t.pos++
+	return t.value()
+}
+
+// str : 문자열을 기록하는 메서드, 이스케이프가 없으면 JSON 의 바이트를 그대로 복사한다.
+func (t *jsonTranscoder) str() error {
+	start := t.pos
+	escaped := false
+	for t.pos++; t.pos < len(t.data); t.pos++ {
+		switch c := t.data[t.pos]; {
+		case c == '\\':
+			escaped = true
+			t.pos++
+		case c < 0x20:
+			return t.syntaxError()
+		case c == '"':
+			t.pos++
+			if !escaped {
+				raw := t.data[start+1 : t.pos-1]
+				t.w.strHead(t.buf, len(raw))
+				t.buf.Write(raw)
+				return nil
+			}
+			var s string
+			if err := json.Unmarshal(t.data[start:t.pos], &s); err != nil {
+				return err
+			}
+			t.w.strHead(t.buf, len(s))
+			t.buf.WriteString(s)
+			return nil
+		}
+	}
+	return t.syntaxError()
+}
+
+func (t *jsonTranscoder) number() error {
+	start := t.pos
+	for ; t.pos < len(t.data); t.pos++ {
+		if c := t.data[t.pos]; (c < '0' || c > '9') && c != '-' && c != '+' && c != '.' && c != 'e' && c != 'E' {
+			break
+		}
+	}
+	if start == t.pos || !writeBinaryNumber(t.buf, t.w, string(t.data[start:t.pos])) {
+		return t.syntaxError()
+	}
+	return nil
+}
+
+func (t *jsonTranscoder) literal(lit string, write func()) error {
+	if len(t.data)-t.pos < len(lit) || string(t.data[t.pos:t.pos+len(lit)]) != lit {
+		return t.syntaxError()
+	}
+	t.pos += len(lit)
+	write()
+	return nil
+}
+
+func (t *jsonTranscoder) peek() byte {
+	if t.pos < len(t.data) {
+		return t.data[t.pos]
+	}
+	return 0
+}
+
+func (t *jsonTranscoder) skipSpace() {
+	for t.pos < len(t.data) {
+		switch t.data[t.pos] {
+		case ' ', '\t', '\n', '\r':
+			t.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (t *jsonTranscoder) syntaxError() error {
+	return fmt.Errorf("invalid JSON at offset %d", t.pos)
+}
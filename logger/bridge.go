@@ -0,0 +1,179 @@
+package logger
+
+import (
+	"io"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
+	zlog "github.com/rs/zerolog/log"
+	"github.com/sirupsen/logrus"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// stdLevelPattern : 표준 log 패키지 출력에서 "[WARN] ..." 또는 "warn: ..." 형태의 레벨 표기를 찾는 정규식
+var stdLevelPattern = regexp.MustCompile(`(?i)^\s*(?:\[(trace|debug|info|warn|warning|error|fatal|panic)\]|(trace|debug|info|warn|warning|error|fatal|panic):)\s*`)
+
+// bridge : 다른 로거의 출력을 Logger 로 전달하는 구조체
+//
+// Logger 는 호출마다 필드가 누적되므로, 줄마다 복사한 로거로 기록한다.
+type bridge struct {
+	mu     sync.Mutex
+	logger Logger
+}
+
+// forward : 레벨, 메시지, 필드를 Logger 로 전달하는 메서드
+func (b *bridge) forward(level types.LogLevel, message string, fields map[string]interface{}) {
+	opts := []options.EntryOption{options.WithMessage(message)}
+	if len(fields) > 0 {
+		opts = append(opts, options.WithFields(options.Fields(fields)))
+	}
+
+	b.mu.Lock()
+	clone := b.logger.Clone()
+	b.mu.Unlock()
+	Log(clone, level, opts...)
+}
+
+// bridgeLevel : 다른 로거의 레벨 표기를 LogLevel 로 변환하는 함수
+//
+// panic 레벨은 원래 로거가 로그 후 패닉을 발생시키므로, 프로세스가 종료되지 않도록 error 로 기록한다.
+func bridgeLevel(text string) types.LogLevel {
+	if strings.EqualFold(text, "panic") {
+		return types.Error
+	}
+	level, _ := types.ParseLevel(text)
+	return level
+}
+
+// RedirectStdLog : 표준 log 패키지의 출력을 Logger 로 전달하는 함수
+//   - l(Logger): 로그를 전달받을 로거
+//
+// "[WARN] ..." 또는 "warn: ..." 처럼 레벨이 표기된 줄은 해당 레벨로, 그 외는 info 로 기록한다.
+// 반환된 restore 함수를 호출하면 원래 출력, 플래그, 접두사로 되돌린다.
+//
+// Example:
+//
+//	restore := logger.RedirectStdLog(log)
+//	defer restore()
+//	log.Printf("[WARN] retrying %s", url) // {"level":"warn","message":"retrying ..."}
+func RedirectStdLog(l Logger) (restore func()) {
+	output, flags, prefix := log.Writer(), log.Flags(), log.Prefix()
+
+	b := &bridge{logger: l}
+	log.SetOutput(writerFunc(func(p []byte) (int, error) {
+		message := strings.TrimRight(string(p), "\n")
+		level := types.Info
+		if match := stdLevelPattern.FindStringSubmatch(message); match != nil {
+			level = bridgeLevel(match[1] + match[2])
+			message = message[len(match[0]):]
+		}
+		b.forward(level, message, nil)
+		return len(p), nil
+	}))
+	log.SetFlags(0)
+	log.SetPrefix("")
+
+	return func() {
+		log.SetOutput(output)
+		log.SetFlags(flags)
+		log.SetPrefix(prefix)
+	}
+}
+
+// RedirectLogrus : logrus 표준 로거(logrus.StandardLogger())의 출력을 Logger 로 전달하는 함수
+//   - l(Logger): 로그를 전달받을 로거
+//
+// logrus 의 레벨과 필드가 그대로 전달되며(trace 는 debug 로), 레벨 필터링은 l 의 설정을 따른다.
+// 반환된 restore 함수를 호출하면 원래 출력, 레벨, 후크로 되돌린다.
+//
+// Example:
+//
+//	restore := logger.RedirectLogrus(log)
+//	defer restore()
+//	logrus.WithField("user", "kim").Warn("login failed") // {"level":"warn","user":"kim","message":"login failed"}
+func RedirectLogrus(l Logger) (restore func()) {
+	std := logrus.StandardLogger()
+	output, level := std.Out, std.GetLevel()
+
+	hooks := std.ReplaceHooks(logrus.LevelHooks{})
+	std.AddHook(&logrusBridge{bridge: &bridge{logger: l}})
+	std.SetOutput(io.Discard)
+	std.SetLevel(logrus.TraceLevel)
+
+	return func() {
+		std.ReplaceHooks(hooks)
+		std.SetOutput(output)
+		std.SetLevel(level)
+	}
+}
+
+// logrusBridge : logrus 엔트리를 Logger 로 전달하는 logrus.Hook
+type logrusBridge struct {
+	*bridge
+}
+
+func (h *logrusBridge) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *logrusBridge) Fire(entry *logrus.Entry) error {
+	fields := make(map[string]interface{}, len(entry.Data))
+	for k, v := range entry.Data {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		fields[k] = v
+	}
+	h.forward(bridgeLevel(entry.Level.String()), entry.Message, fields)
+	return nil
+}
+
+// RedirectZerolog : zerolog 전역 로거(github.com/rs/zerolog/log.Logger)의 출력을 Logger 로 전달하는 함수
+//   - l(Logger): 로그를 전달받을 로거
+//
+// zerolog 의 레벨과 필드가 그대로 전달된다 (전역 레벨 zerolog.SetGlobalLevel 은 계속 적용된다).
+// 반환된 restore 함수를 호출하면 원래 전역 로거로 되돌린다.
+//
+// Example:
+//
+//	restore := logger.RedirectZerolog(log)
+//	defer restore()
+//	zlog.Info().Str("job", "sync").Msg("done") // {"level":"info","job":"sync","message":"done"}
+func RedirectZerolog(l Logger) (restore func()) {
+	previous := zlog.Logger
+
+	b := &bridge{logger: l}
+	zlog.Logger = zerolog.New(writerFunc(func(p []byte) (int, error) {
+		fields, err := decodeZerologLine(p)
+		if err != nil {
+			b.forward(types.Info, strings.TrimRight(string(p), "\n"), nil)
+			return len(p), nil
+		}
+
+		level, _ := fields[zerolog.LevelFieldName].(string)
+		message, _ := fields[zerolog.MessageFieldName].(string)
+		delete(fields, zerolog.LevelFieldName)
+		delete(fields, zerolog.MessageFieldName)
+		delete(fields, zerolog.TimestampFieldName)
+		b.forward(bridgeLevel(level), message, fields)
+		return len(p), nil
+	}))
+
+	return func() {
+		zlog.Logger = previous
+	}
+}
+
+// writerFunc : 함수를 io.Writer 로 사용하기 위한 어댑터
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,189 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// bufferedEntry : 버퍼에 보관된 로그 호출
+//
+// 출력할 때 원래 시각과 호출 위치로 기록되도록 보관한 시점의 값을 함께 저장한다.
+type bufferedEntry struct {
+	level  types.LogLevel
+	opts   []options.EntryOption
+	time   time.Time
+	caller string
+}
+
+// bufferState : 하나의 스코프(요청)에서 공유되는 버퍼 상태
+type bufferState struct {
+	config    options.Buffering
+	mu        sync.Mutex
+	entries   []bufferedEntry
+	triggered bool
+	closed    bool
+	discarded uint64
+}
+
+// BufferedLogger : Trigger 레벨 이상의 로그가 기록될 때만 앞선 로그를 함께 출력하는 요청 단위 로거
+//
+// Trigger 레벨 미만의 로그는 메모리에 보관되며, 같은 스코프에서 Trigger 레벨 이상의 로그가
+// 기록되면 보관된 로그를 순서대로 출력한 뒤 이후 로그는 바로 기록한다.
+// Trigger 없이 스코프가 끝나면(Close) 보관된 로그는 버려진다.
+// 보관된 로그는 Trigger 로그의 시각이 아닌 원래 호출한 시각과 위치로 출력된다.
+//
+// 보관된 debug 로그가 출력될 수 있도록, 기반 로거는 debug 레벨로 생성해야 한다.
+//
+// Example:
+//
+//	// 기반 로거는 debug 레벨로 생성
+//	base := logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Debug))
+//
+//	// request handler 레벨에서 버퍼링 로거를 컨텍스트에 등록
+//	buf := logger.NewBufferedLogger(base, types.ZeroLog, options.Buffering{Trigger: types.Error})
+//	defer buf.Close() // 에러가 없었다면 보관된 로그를 버림 (버린 수: buf.Dropped())
+//	ctx = buf.WithContext(ctx)
+//
+//	// ---------doSomething 함수 내부---------
+//	log := logger.FromContext(ctx, types.ZeroLog)
+//	log.Debug(options.WithMessage("query")) // 보관
+//	log.Error(options.WithMessage("failed")) // 보관된 query 로그와 함께 출력
+type BufferedLogger struct {
+	Logger
+	loggerType types.LoggerType
+	state      *bufferState
+}
+
+// NewBufferedLogger : 요청 단위 버퍼링 로거 생성자
+//   - base(Logger): 기반 로거, 공통 필드가 원래 로거에 남지 않도록 복사하여 사용
+//   - loggerType(types.LoggerType): 기반 로거 타입 (컨텍스트 키)
+//   - buffering(options.Buffering): 버퍼링 설정
+func NewBufferedLogger(base Logger, loggerType types.LoggerType, buffering options.Buffering) *BufferedLogger {
+	if buffering.Trigger == "" {
+		buffering.Trigger = types.Error
+	}
+	if buffering.MaxEntries <= 0 {
+		buffering.MaxEntries = 100
+	}
+	return &BufferedLogger{
+		Logger:     base.Clone(),
+		loggerType: loggerType,
+		state:      &bufferState{config: buffering},
+	}
+}
+
+// WithContext : 컨텍스트에 로거를 등록하는 메서드
+func (l *BufferedLogger) WithContext(ctx context.Context) context.Context {
+	key, _ := contextKey(l.loggerType)
+	return context.WithValue(ctx, key, l)
+}
+
+// Clone : 같은 버퍼를 공유하는 새 로거를 반환하는 메서드
+func (l *BufferedLogger) Clone() Logger {
+	return &BufferedLogger{Logger: l.Logger.Clone(), loggerType: l.loggerType, state: l.state}
+}
+
+// Debug : 디버그 로그를 출력하는 메서드
+func (l *BufferedLogger) Debug(opts ...options.EntryOption) {
+	l.log(types.Debug, opts)
+}
+
+// Info : 정보 로그를 출력하는 메서드
+func (l *BufferedLogger) Info(opts ...options.EntryOption) {
+	l.log(types.Info, opts)
+}
+
+// Warn : 경고 로그를 출력하는 메서드
+func (l *BufferedLogger) Warn(opts ...options.EntryOption) {
+	l.log(types.Warn, opts)
+}
+
+// Error : 에러 로그를 출력하는 메서드
+func (l *BufferedLogger) Error(opts ...options.EntryOption) {
+	l.log(types.Error, opts)
+}
+
+// Fatal : 치명적인 에러 로그를 출력하는 메서드
+func (l *BufferedLogger) Fatal(opts ...options.EntryOption) {
+	l.log(types.Fatal, opts)
+}
+
+// Flush : Trigger 와 관계없이 보관된 로그를 출력하고, 이후 로그는 바로 기록하는 메서드
+func (l *BufferedLogger) Flush() {
+	l.state.mu.Lock()
+	entries := l.trigger()
+	l.state.mu.Unlock()
+
+	l.replay(entries)
+}
+
+// Close : 스코프를 끝내고 보관된 로그를 버리는 메서드 (io.Closer, 항상 nil 을 반환)
+//
+// 버려진 엔트리 수는 Dropped 로 확인한다.
+// Close 이후의 로그는 보관할 스코프가 없으므로 레벨과 관계없이 바로 기록한다.
+// (예: 요청이 끝난 뒤 실행되는 고루틴의 로그가 버려지지 않도록)
+// 기반 로거는 다른 요청과 공유하므로 닫지 않는다.
+func (l *BufferedLogger) Close() error {
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+
+	if !l.state.closed {
+		l.state.closed = true
+		l.state.discarded += uint64(len(l.state.entries))
+		l.state.entries = nil
+	}
+	return nil
+}
+
+// Dropped : MaxEntries 를 넘었거나 Trigger 없이 Close 되어 버려진 엔트리 수를 반환하는 메서드
+func (l *BufferedLogger) Dropped() uint64 {
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+	return l.state.discarded
+}
+
+func (l *BufferedLogger) log(level types.LogLevel, opts []options.EntryOption) {
+	config := l.state.config
+
+	l.state.mu.Lock()
+	switch {
+	case l.state.triggered || l.state.closed:
+		l.state.mu.Unlock()
+	case level.Severity() >= config.Trigger.Severity():
+		entries := l.trigger()
+		l.state.mu.Unlock()
+		l.replay(entries)
+	case config.PassThrough != "" && level.Severity() >= config.PassThrough.Severity():
+		l.state.mu.Unlock()
+	default:
+		if len(l.state.entries) >= config.MaxEntries {
+			l.state.entries = l.state.entries[1:]
+			l.state.discarded++
+		}
+		l.state.entries = append(l.state.entries, bufferedEntry{level: level, opts: opts, time: time.Now(), caller: caller()})
+		l.state.mu.Unlock()
+		return
+	}
+
+	Log(l.Logger, level, opts...)
+}
+
+// trigger : 버퍼링을 끝내고 보관된 로그를 꺼내는 메서드 (state.mu 를 잡은 상태에서 호출)
+func (l *BufferedLogger) trigger() []bufferedEntry {
+	l.state.triggered = true
+	entries := l.state.entries
+	l.state.entries = nil
+	return entries
+}
+
+// replay : 보관된 로그를 순서대로 출력하는 메서드
+func (l *BufferedLogger) replay(entries []bufferedEntry) {
+	for _, entry := range entries {
+		opts := append(entry.opts[:len(entry.opts):len(entry.opts)], options.WithTime(entry.time), options.WithCallerAt(entry.caller))
+		Log(l.Logger, entry.level, opts...)
+	}
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// packagePrefix : 호출 위치를 찾을 때 건너뛸 logger 패키지의 함수 이름 접두사
+var packagePrefix = reflect.TypeOf(zerologLogger{}).PkgPath() + "."
+
+// caller : logger 패키지 밖에서 로그 메서드를 호출한 위치(file:line)를 반환하는 함수
+//
+// 백엔드나 데코레이터를 몇 단계 거치더라도 실제 호출한 위치를 가리킨다.
+func caller() string {
+	var pcs [16]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// CBOR(RFC 8949) major type
+const (
+	cborUint   byte = 0
+	cborNegInt byte = 1
+	cborBytes  byte = 2
+	cborText   byte = 3
+	cborArray  byte = 4
+	cborMap    byte = 5
+	cborTag    byte = 6
+	cborSimple byte = 7
+)
+
+const (
+	cborFalse   byte = 0xf4
+	cborTrue    byte = 0xf5
+	cborNull    byte = 0xf6
+	cborFloat64 byte = 0xfb
+	cborBreak   byte = 0xff
+
+	// cborTagEpoch : 에포크 기준 시간 태그 (zerolog binary_log 빌드의 시간 필드)
+	cborTagEpoch uint64 = 1
+	// cborTagEmbeddedJSON : JSON 을 담은 바이트 문자열 태그 (zerolog binary_log 빌드의 RawJSON)
+	cborTagEmbeddedJSON uint64 = 262
+
+	// cborMaxLength : 디코딩할 문자열, 배열, 맵의 최대 길이 (잘못된 입력으로 큰 메모리를 할당하지 않도록)
+	cborMaxLength = 64 << 20
+)
+
+var errCBORBreak = errors.New("cbor: unexpected break")
+
+// cborWriter : CBOR 데이터 아이템을 기록하는 binaryWriter
+//
+// 배열과 맵은 정해진 길이로, 숫자는 정수로 표현할 수 있으면 가장 짧은 정수, 아니면 float64 로 기록한다.
+type cborWriter struct{}
+
+func (cborWriter) null(buf *bytes.Buffer) {
+	buf.WriteByte(cborNull)
+}
+
+func (cborWriter) boolean(buf *bytes.Buffer, v bool) {
+	if v {
+		buf.WriteByte(cborTrue)
+	} else {
+		buf.WriteByte(cborFalse)
+	}
+}
+
+func (cborWriter) int(buf *bytes.Buffer, n int64) {
+	if n >= 0 {
+		writeCBORHead(buf, cborUint, uint64(n))
+		return
+	}
+	writeCBORHead(buf, cborNegInt, uint64(-1-n))
+}
+
+func (cborWriter) uint(buf *bytes.Buffer, n uint64) {
+	writeCBORHead(buf, cborUint, n)
+}
+
+func (cborWriter) float(buf *bytes.Buffer, f float64) {
+	var b [9]byte
+	b[0] = cborFloat64
+	binary.BigEndian.PutUint64(b[1:], math.Float64bits(f))
+	buf.Write(b[:])
+}
+
+func (cborWriter) strHead(buf *bytes.Buffer, n int) {
+	writeCBORHead(buf, cborText, uint64(n))
+}
+
+func (cborWriter) arrayHead(buf *bytes.Buffer, n int) {
+	writeCBORHead(buf, cborArray, uint64(n))
+}
+
+func (cborWriter) mapHead(buf *bytes.Buffer, n int) {
+	writeCBORHead(buf, cborMap, uint64(n))
+}
+
+// writeCBORHead : major type 과 인자(길이 또는 값)를 가장 짧은 형태로 기록하는 함수
+func writeCBORHead(buf *bytes.Buffer, major byte, n uint64) {
+	var b [9]byte
+	b[0] = major << 5
+	switch {
+	case n < 24:
+		b[0] |= byte(n)
+		buf.WriteByte(b[0])
+	case n <= math.MaxUint8:
+		b[0] |= 24
+		b[1] = byte(n)
+		buf.Write(b[:2])
+	case n <= math.MaxUint16:
+		b[0] |= 25
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		buf.Write(b[:3])
+	case n <= math.MaxUint32:
+		b[0] |= 26
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		buf.Write(b[:5])
+	default:
+		b[0] |= 27
+		binary.BigEndian.PutUint64(b[1:], n)
+		buf.Write(b[:])
+	}
+}
+
+// cborDecoder : CBOR 데이터 아이템을 JSON 으로 표현할 수 있는 값으로 디코딩하는 구조체 (숫자는 json.Number)
+//
+// 정해진 길이와 정해지지 않은 길이(indefinite length)의 문자열, 배열, 맵을 모두 읽으며,
+// 에포크 시간 태그는 시간 포맷의 문자열로, 임베디드 JSON 태그는 JSON 값 그대로 변환한다.
+type cborDecoder struct {
+	r          cborReader
+	timeFormat string
+}
+
+type cborReader interface {
+	io.Reader
+	io.ByteScanner
+}
+
+// newCBORDecoder : cborDecoder 생성자
+//   - r(io.Reader): CBOR 스트림
+//   - timeFormat(string): 에포크 시간 태그를 변환할 시간 포맷
+func newCBORDecoder(r io.Reader, timeFormat string) *cborDecoder {
+	reader, ok := r.(cborReader)
+	if !ok {
+		reader = bufio.NewReader(r)
+	}
+	return &cborDecoder{r: reader, timeFormat: timeFormat}
+}
+
+// decode : 다음 데이터 아이템을 디코딩하는 메서드, 스트림이 끝나면 io.EOF 를 반환한다.
+func (d *cborDecoder) decode() (interface{}, error) {
+	if _, err := d.r.ReadByte(); err != nil {
+		return nil, err
+	}
+	_ = d.r.UnreadByte()
+	value, err := d.item()
+	if errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return value, err
+}
+
+func (d *cborDecoder) item() (interface{}, error) {
+	initial, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	major, info := initial>>5, initial&0x1f
+	if initial == cborBreak {
+		return nil, errCBORBreak
+	}
+	if major == cborSimple {
+		return d.simple(info)
+	}
+
+	indefinite := info == 31
+	var n uint64
+	if !indefinite {
+		if n, err = d.argument(info); err != nil {
+			return nil, err
+		}
+	}
+
+	switch major {
+	case cborUint:
+		return stdjson.Number(strconv.FormatUint(n, 10)), nil
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			return cborFloat(-1 - float64(n)), nil
+		}
+		return stdjson.Number(strconv.FormatInt(-1-int64(n), 10)), nil
+	case cborBytes, cborText:
+		data, err := d.str(major, n, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		if major == cborText {
+			return string(data), nil
+		}
+		return data, nil
+	case cborArray:
+		return d.array(n, indefinite)
+	case cborMap:
+		return d.dict(n, indefinite)
+	case cborTag:
+		if indefinite {
+			return nil, fmt.Errorf("cbor: invalid tag")
+		}
+		return d.tag(n)
+	}
+	return nil, fmt.Errorf("cbor: invalid initial byte 0x%02x", initial)
+}
+
+// argument : 추가 정보(additional information)에 따라 인자를 읽는 메서드
+func (d *cborDecoder) argument(info byte) (uint64, error) {
+	if info < 24 {
+		return uint64(info), nil
+	}
+	var size int
+	switch info {
+	case 24:
+		size = 1
+	case 25:
+		size = 2
+	case 26:
+		size = 4
+	case 27:
+		size = 8
+	default:
+		return 0, fmt.Errorf("cbor: invalid additional information %d", info)
+	}
+	var b [8]byte
+	if _, err := io.ReadFull(d.r, b[8-size:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+func (d *cborDecoder) length(n uint64) (int, error) {
+	if n > cborMaxLength {
+		return 0, fmt.Errorf("cbor: length %d exceeds limit", n)
+	}
+	return int(n), nil
+}
+
+func (d *cborDecoder) str(major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		size, err := d.length(n)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, size)
+		_, err = io.ReadFull(d.r, data)
+		return data, err
+	}
+
+	var data []byte
+	for {
+		chunk, err := d.item()
+		if errors.Is(err, errCBORBreak) {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch c := chunk.(type) {
+		case string:
+			if major != cborText {
+				return nil, fmt.Errorf("cbor: invalid chunk in indefinite byte string")
+			}
+			data = append(data, c...)
+		case []byte:
+			if major != cborBytes {
+				return nil, fmt.Errorf("cbor: invalid chunk in indefinite text string")
+			}
+			data = append(data, c...)
+		default:
+			return nil, fmt.Errorf("cbor: invalid chunk in indefinite string")
+		}
+		if len(data) > cborMaxLength {
+			return nil, fmt.Errorf("cbor: length exceeds limit")
+		}
+	}
+}
+
+func (d *cborDecoder) array(n uint64, indefinite bool) ([]interface{}, error) {
+	size, err := d.length(n)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]interface{}, 0, min(size, 1024))
+	for i := 0; indefinite || i < size; i++ {
+		item, err := d.item()
+		if indefinite && errors.Is(err, errCBORBreak) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// dict : 맵을 읽는 메서드, 문자열이 아닌 키는 문자열로 바꾼다.
+func (d *cborDecoder) dict(n uint64, indefinite bool) (map[string]interface{}, error) {
+	size, err := d.length(n)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{}, min(size, 1024))
+	for i := 0; indefinite || i < size; i++ {
+		key, err := d.item()
+		if indefinite && errors.Is(err, errCBORBreak) {
+			return fields, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.item()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			name = fmt.Sprint(key)
+		}
+		fields[name] = value
+	}
+	return fields, nil
+}
+
+func (d *cborDecoder) tag(number uint64) (interface{}, error) {
+	value, err := d.item()
+	if err != nil {
+		return nil, err
+	}
+	switch number {
+	case cborTagEpoch:
+		if epoch, ok := value.(stdjson.Number); ok {
+			if sec, err := epoch.Int64(); err == nil {
+				return time.Unix(sec, 0).Format(d.timeFormat), nil
+			}
+			if f, err := epoch.Float64(); err == nil {
+				sec, frac := math.Modf(f)
+				return time.Unix(int64(sec), int64(math.Round(frac*1e9))).Format(d.timeFormat), nil
+			}
+		}
+	case cborTagEmbeddedJSON:
+		if data, ok := value.([]byte); ok {
+			var embedded interface{}
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.UseNumber()
+			if err := decoder.Decode(&embedded); err == nil {
+				return embedded, nil
+			}
+		}
+	}
+	return value, nil
+}
+
+func (d *cborDecoder) simple(info byte) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		bits, err := d.argument(info)
+		return cborFloat(halfFloat(uint16(bits))), err
+	case 26:
+		bits, err := d.argument(info)
+		return cborFloat(float64(math.Float32frombits(uint32(bits)))), err
+	case 27:
+		bits, err := d.argument(info)
+		return cborFloat(math.Float64frombits(bits)), err
+	}
+	return nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+}
+
+// cborFloat : 실수를 JSON 로그와 같은 json.Number 로 변환하는 함수, NaN 과 무한대는 문자열로 변환한다.
+func cborFloat(f float64) interface{} {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return stdjson.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// decodeCBORLine : 로그 하나를 담은 CBOR 맵을 필드로 변환하는 함수를 반환하는 함수
+//   - timeFormat(string): 에포크 시간 태그를 변환할 시간 포맷
+func decodeCBORLine(timeFormat string) func(line []byte) (map[string]interface{}, error) {
+	return func(line []byte) (map[string]interface{}, error) {
+		value, err := newCBORDecoder(bytes.NewReader(line), timeFormat).decode()
+		if err != nil {
+			return nil, err
+		}
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cbor: log entry is not a map")
+		}
+		return fields, nil
+	}
+}
+
+// halfFloat : IEEE 754 반정밀도 부동소수점을 float64 로 변환하는 함수
+func halfFloat(bits uint16) float64 {
+	exp := int(bits>>10) & 0x1f
+	mant := float64(bits & 0x3ff)
+	var value float64
+	switch exp {
+	case 0:
+		value = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			value = math.Inf(1)
+		} else {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mant+1024, exp-25)
+	}
+	if bits&0x8000 != 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,617 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/masking"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/sink"
+	"github.com/wjddn3711/structured-logger/logger/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Config : 파일(YAML/JSON)과 환경 변수로 읽어들이는 로거 설정
+//   - Backend(types.LoggerType): 로거 백엔드 (default: zerolog)
+//   - Level(types.LogLevel): 로그 레벨 (default: info)
+//   - Format(types.LogFormat): 출력 포맷 (default: json)
+//   - TimeFormat(string): 시간 포맷 (default: "2006-01-02 15:04:05")
+//   - Caller(bool): 호출 위치 기록 여부
+//   - Outputs([]OutputConfig): 출력 목록, 여러 개면 모두에 기록 (default: stdout)
+//   - Sampling(*SamplingConfig): 샘플링 설정
+//   - Masking(masking.Rules): 필드 이름별 마스킹 규칙
+//   - Schema(types.Schema): 출력 필드 스키마 (ecs, gcp)
+//   - SchemaMapping(map[string]string): 스키마 기본 매핑에 추가하거나 덮어쓸 매핑
+//   - GCPProject(string): gcp 스키마의 trace 리소스 이름에 사용할 프로젝트 ID
+//
+// Example:
+//
+//	# logging.yaml
+//	backend: zerolog
+//	level: debug
+//	outputs:
+//	  - type: stdout
+//	  - type: tcp
+//	    address: 127.0.0.1:5170
+//	sampling:
+//	  interval: 1s
+//	  levels:
+//	    info: {first: 100, thereafter: 100}
+//	masking:
+//	  phone: mobile
+type Config struct {
+	Backend    types.LoggerType `json:"backend" yaml:"backend"`
+	Level      types.LogLevel   `json:"level" yaml:"level"`
+	Format     types.LogFormat  `json:"format" yaml:"format"`
+	TimeFormat string           `json:"time_format" yaml:"time_format"`
+	Caller     bool             `json:"caller" yaml:"caller"`
+	Outputs    []OutputConfig   `json:"outputs" yaml:"outputs"`
+	Sampling   *SamplingConfig  `json:"sampling" yaml:"sampling"`
+	Masking    masking.Rules    `json:"masking" yaml:"masking"`
+
+	Schema        types.Schema      `json:"schema" yaml:"schema"`
+	SchemaMapping map[string]string `json:"schema_mapping" yaml:"schema_mapping"`
+	GCPProject    string            `json:"gcp_project" yaml:"gcp_project"`
+}
+
+// OutputConfig : 출력 설정
+//   - Type(string): stdout, stderr, file, tcp, udp, http, forward, journald, gelf (UDP), gelf_tcp, otlp (OTLP/HTTP), otlp_grpc
+//   - Path(string): file 출력의 파일 경로 (추가 모드로 열림)
+//   - Address(string): tcp, udp, forward, gelf, gelf_tcp, otlp_grpc 출력의 주소 (host:port)
+//   - URL(string): http, otlp 출력의 URL
+//   - HTTPFormat(sink.HTTPFormat): http 출력의 본문 포맷 (json, loki, elasticsearch)
+//   - Tag(string): forward 출력의 태그
+//   - Insecure(bool): otlp_grpc 출력에서 TLS 를 사용하지 않을지 여부 (otlp 출력은 URL 의 스킴을 따름)
+//   - ServiceName(string): otlp, otlp_grpc 출력의 service.name 리소스 속성
+type OutputConfig struct {
+	Type       string          `json:"type" yaml:"type"`
+	Path       string          `json:"path,omitempty" yaml:"path,omitempty"`
+	Address    string          `json:"address,omitempty" yaml:"address,omitempty"`
+	URL        string          `json:"url,omitempty" yaml:"url,omitempty"`
+	HTTPFormat sink.HTTPFormat `json:"http_format,omitempty" yaml:"http_format,omitempty"`
+	Tag        string          `json:"tag,omitempty" yaml:"tag,omitempty"`
+
+	Insecure    bool   `json:"insecure,omitempty" yaml:"insecure,omitempty"`
+	ServiceName string `json:"service_name,omitempty" yaml:"service_name,omitempty"`
+}
+
+// SamplingConfig : 샘플링 설정 (options.Sampling 참고)
+type SamplingConfig struct {
+	Interval  Duration                                `json:"interval" yaml:"interval"`
+	KeyFields []string                                `json:"key_fields" yaml:"key_fields"`
+	Levels    map[types.LogLevel]SamplingPolicyConfig `json:"levels" yaml:"levels"`
+}
+
+// SamplingPolicyConfig : 레벨별 샘플링 정책 (options.SamplingPolicy 참고)
+type SamplingPolicyConfig struct {
+	First      int     `json:"first" yaml:"first"`
+	Thereafter int     `json:"thereafter" yaml:"thereafter"`
+	Rate       float64 `json:"rate" yaml:"rate"`
+}
+
+// Duration : "1s", "500ms" 형태의 문자열로 읽어들이는 time.Duration
+type Duration time.Duration
+
+// UnmarshalJSON : 문자열("1s") 또는 나노초 숫자를 읽어들이는 메서드
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var text string
+		if err := json.Unmarshal(b, &text); err != nil {
+			return err
+		}
+		return d.parse(text)
+	}
+	var n int64
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("invalid duration %s", b)
+	}
+	*d = Duration(n)
+	return nil
+}
+
+// UnmarshalYAML : 문자열("1s")을 읽어들이는 메서드
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	return d.parse(node.Value)
+}
+
+// MarshalJSON : 문자열("1s")로 변환하는 메서드
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// MarshalYAML : 문자열("1s")로 변환하는 메서드
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return time.Duration(d).String(), nil
+}
+
+func (d *Duration) parse(text string) error {
+	duration, err := time.ParseDuration(text)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", text)
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// ConfigError : 설정 검증 에러
+//   - Path(string): 문제가 있는 설정 경로 (예: outputs[1].address, env:LOG_LEVEL)
+//   - Message(string): 에러 내용
+type ConfigError struct {
+	Path    string
+	Message string
+}
+
+func (e ConfigError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ConfigErrors : 설정 검증 에러 목록
+type ConfigErrors []ConfigError
+
+func (e ConfigErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "logger: invalid config: " + strings.Join(messages, "; ")
+}
+
+func (e *ConfigErrors) add(path, format string, args ...interface{}) {
+	*e = append(*e, ConfigError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e ConfigErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// LoadConfig : 설정 파일을 읽고 환경 변수를 적용한 뒤 검증한 설정을 반환하는 함수
+//   - path(string): 설정 파일 경로 (.yaml, .yml, .json), 빈 문자열이면 기본값과 환경 변수만 사용
+//
+// 설정 파일에 정의되지 않은 항목이 있으면 에러를 반환한다.
+//
+// Example:
+//
+//	cfg, err := logger.LoadConfig("config/logging.yaml")
+//	if err != nil {
+//		panic(err)
+//	}
+//	log, closer, err := logger.NewFromConfig(cfg)
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("logger: read config: %w", err)
+		}
+		if cfg, err = ParseConfig(data, filepath.Ext(path)); err != nil {
+			return Config{}, fmt.Errorf("logger: parse config %s: %w", path, err)
+		}
+	}
+	if err := cfg.ApplyEnv(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// ParseConfig : YAML 또는 JSON 설정을 읽어들이는 함수
+//   - data([]byte): 설정 내용
+//   - format(string): "yaml", "yml", "json" (앞의 "." 은 무시)
+func ParseConfig(data []byte, format string) (Config, error) {
+	var cfg Config
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "yaml", "yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil && err != io.EOF {
+			return Config{}, err
+		}
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&cfg); err != nil {
+			return Config{}, err
+		}
+	default:
+		return Config{}, fmt.Errorf("unsupported config format %q", format)
+	}
+	return cfg, nil
+}
+
+// ApplyEnv : 환경 변수로 설정을 덮어쓰는 메서드
+//   - LOG_BACKEND: 로거 백엔드 (zerolog, logrus)
+//   - LOG_LEVEL: 로그 레벨
+//   - LOG_FORMAT: 출력 포맷
+//   - LOG_TIME_FORMAT: 시간 포맷
+//   - LOG_CALLER: 호출 위치 기록 여부 (true, false)
+//   - LOG_OUTPUT: 쉼표로 구분한 출력 목록 (stdout, stderr, file:<path>, tcp:<address>, udp:<address>, gelf:<address>, http:<url>, otlp:<url>, otlp_grpc:<address>)
+//
+// Example:
+//
+//	LOG_LEVEL=debug LOG_OUTPUT=stdout,file:/var/log/app.log ./app
+func (c *Config) ApplyEnv() error {
+	var errs ConfigErrors
+	if v, ok := os.LookupEnv("LOG_BACKEND"); ok {
+		switch backend := types.LoggerType(v); backend {
+		case types.ZeroLog, types.Logrus:
+			c.Backend = backend
+		default:
+			errs.add("env:LOG_BACKEND", "unsupported backend %q (zerolog, logrus)", v)
+		}
+	}
+	if v, ok := os.LookupEnv("LOG_LEVEL"); ok {
+		if _, err := types.ParseLevel(v); err != nil {
+			errs.add("env:LOG_LEVEL", "unknown log level %q", v)
+		} else {
+			c.Level = types.LogLevel(v)
+		}
+	}
+	if v, ok := os.LookupEnv("LOG_FORMAT"); ok {
+		switch format := types.LogFormat(v); format {
+		case types.JSON, types.Text, types.Logfmt, types.CBOR, types.MsgPack:
+			c.Format = format
+		default:
+			errs.add("env:LOG_FORMAT", "unsupported format %q (json, text, logfmt, cbor, msgpack)", v)
+		}
+	}
+	if v, ok := os.LookupEnv("LOG_TIME_FORMAT"); ok {
+		c.TimeFormat = v
+	}
+	if v, ok := os.LookupEnv("LOG_CALLER"); ok {
+		caller, err := strconv.ParseBool(v)
+		if err != nil {
+			errs.add("env:LOG_CALLER", "invalid boolean %q", v)
+		}
+		c.Caller = caller
+	}
+	if v, ok := os.LookupEnv("LOG_OUTPUT"); ok {
+		c.Outputs = nil
+		for i, item := range strings.Split(v, ",") {
+			output, err := parseOutput(strings.TrimSpace(item))
+			if err != nil {
+				errs.add(fmt.Sprintf("env:LOG_OUTPUT[%d]", i), "%v", err)
+				continue
+			}
+			c.Outputs = append(c.Outputs, output)
+		}
+	}
+	return errs.err()
+}
+
+// parseOutput : "type" 또는 "type:target" 형태의 출력 표기를 변환하는 함수
+func parseOutput(text string) (OutputConfig, error) {
+	kind, target, _ := strings.Cut(text, ":")
+	output := OutputConfig{Type: kind}
+	switch kind {
+	case "stdout", "stderr", "journald":
+	case "file":
+		output.Path = target
+	case "tcp", "udp", "forward", "gelf", "gelf_tcp", "otlp_grpc":
+		output.Address = target
+	case "http", "otlp":
+		output.URL = target
+	default:
+		return OutputConfig{}, fmt.Errorf("unknown output %q", text)
+	}
+	return output, nil
+}
+
+// Validate : 설정을 검증하는 메서드
+//
+// 문제가 있는 모든 항목을 경로와 함께 담은 ConfigErrors 를 반환한다.
+func (c Config) Validate() error {
+	var errs ConfigErrors
+
+	switch c.Backend {
+	case "", types.ZeroLog, types.Logrus:
+	default:
+		errs.add("backend", "unsupported backend %q (zerolog, logrus)", c.Backend)
+	}
+	if c.Level != "" {
+		if _, err := types.ParseLevel(string(c.Level)); err != nil {
+			errs.add("level", "unknown log level %q", c.Level)
+		}
+	}
+	switch c.Format {
+	case "", types.JSON, types.Text, types.Logfmt, types.CBOR, types.MsgPack:
+	default:
+		errs.add("format", "unsupported format %q (json, text, logfmt, cbor, msgpack)", c.Format)
+	}
+	switch c.Schema {
+	case "", types.ECS, types.GCP:
+	default:
+		errs.add("schema", "unsupported schema %q (ecs, gcp)", c.Schema)
+	}
+
+	for i, output := range c.Outputs {
+		path := fmt.Sprintf("outputs[%d]", i)
+		switch output.Type {
+		case "stdout", "stderr", "journald":
+		case "file":
+			if output.Path == "" {
+				errs.add(path+".path", "required for file output")
+			}
+		case "tcp", "udp", "forward", "gelf", "gelf_tcp", "otlp_grpc":
+			if output.Address == "" {
+				errs.add(path+".address", "required for %s output", output.Type)
+			}
+		case "http":
+			if output.URL == "" {
+				errs.add(path+".url", "required for http output")
+			}
+			switch output.HTTPFormat {
+			case "", sink.HTTPJSON, sink.HTTPLoki, sink.HTTPElasticsearch:
+			default:
+				errs.add(path+".http_format", "unsupported http format %q (json, loki, elasticsearch)", output.HTTPFormat)
+			}
+		case "otlp":
+			if output.URL == "" {
+				errs.add(path+".url", "required for otlp output")
+			}
+		case "":
+			errs.add(path+".type", "required")
+		default:
+			errs.add(path+".type", "unknown output type %q", output.Type)
+		}
+	}
+
+	if c.Sampling != nil {
+		if c.Sampling.Interval < 0 {
+			errs.add("sampling.interval", "must not be negative")
+		}
+		for _, level := range sortedLevels(c.Sampling.Levels) {
+			policy := c.Sampling.Levels[level]
+			path := "sampling.levels." + string(level)
+			if _, err := types.ParseLevel(string(level)); err != nil {
+				errs.add(path, "unknown log level %q", level)
+			}
+			if policy.First < 0 {
+				errs.add(path+".first", "must not be negative")
+			}
+			if policy.Thereafter < 0 {
+				errs.add(path+".thereafter", "must not be negative")
+			}
+			if policy.Rate < 0 || policy.Rate > 1 {
+				errs.add(path+".rate", "must be between 0 and 1")
+			}
+		}
+	}
+
+	fields := make([]string, 0, len(c.Masking))
+	for field := range c.Masking {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if kind := c.Masking[field]; !kind.Valid() {
+			errs.add("masking."+field, "unknown masking kind %q", kind)
+		}
+	}
+
+	return errs.err()
+}
+
+// NewFromConfig : 설정으로 로거를 생성하는 함수
+//
+// 설정을 검증한 뒤 출력(싱크)을 열고 NewWrapper 로 로거를 생성한다.
+// 함께 반환하는 io.Closer 는 로거의 남은 요약 로그를 기록(Close)한 뒤 연 출력(파일, 네트워크 싱크 등)을 모두 닫으며,
+// 버퍼에 남은 로그를 내보내도록 종료 시 호출해야 한다.
+//
+// Example:
+//
+//	cfg, err := logger.LoadConfig(os.Getenv("LOG_CONFIG"))
+//	if err != nil {
+//		panic(err)
+//	}
+//	log, closer, err := logger.NewFromConfig(cfg)
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer closer.Close()
+func NewFromConfig(cfg Config) (Logger, io.Closer, error) {
+	logger, closers, err := cfg.build()
+	if err != nil {
+		return nil, nil, err
+	}
+	return logger, outputClosers(closers), nil
+}
+
+// build : 설정을 검증하고 로거를 생성하는 메서드
+//
+// 생성된 로거와 함께 설정 변경 시 닫아야 하는 목록을 반환한다.
+// 로거의 남은 요약 로그가 출력되도록 로거(Close)를 출력(싱크)보다 먼저 닫는다.
+func (c Config) build() (Logger, []io.Closer, error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, err
+	}
+	settingOpts, closers, err := c.options()
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := newWrapper(c.backend(), settingOpts...)
+	return logger, append([]io.Closer{loggerCloser{logger}}, closers...), nil
+}
+
+// loggerCloser : Close 로 로거를 닫는 io.Closer
+type loggerCloser struct {
+	logger Logger
+}
+
+// Close : 로거가 보관 중인 요약 로그를 기록하는 메서드
+func (c loggerCloser) Close() error {
+	return Close(c.logger)
+}
+
+// backend : 기본값(zerolog)을 적용한 로거 백엔드를 반환하는 메서드
+func (c Config) backend() types.LoggerType {
+	if c.Backend == "" {
+		return types.ZeroLog
+	}
+	return c.Backend
+}
+
+// options : 설정을 로그 설정 옵션으로 변환하는 메서드 (검증된 설정에서 호출)
+func (c Config) options() ([]options.LogSettingOption, []io.Closer, error) {
+	output, closers, err := c.openOutputs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	level, _ := types.ParseLevel(string(c.Level))
+	settingOpts := []options.LogSettingOption{
+		options.WithLevel(level),
+		options.WithOutput(output),
+		options.WithCaller(c.Caller),
+	}
+	if c.Format != "" {
+		settingOpts = append(settingOpts, options.WithFormat(c.Format))
+	}
+	if c.TimeFormat != "" {
+		settingOpts = append(settingOpts, options.WithTimeFormat(c.TimeFormat))
+	}
+	if len(c.Masking) > 0 {
+		settingOpts = append(settingOpts, options.WithMasking(c.Masking))
+	}
+	if c.Schema != "" {
+		settingOpts = append(settingOpts, options.WithSchema(c.Schema, c.SchemaMapping))
+	}
+	if c.GCPProject != "" {
+		settingOpts = append(settingOpts, options.WithGCPProject(c.GCPProject))
+	}
+	if c.Sampling != nil {
+		sampling := options.Sampling{
+			Interval:  time.Duration(c.Sampling.Interval),
+			KeyFields: c.Sampling.KeyFields,
+			Levels:    map[types.LogLevel]options.SamplingPolicy{},
+		}
+		for text, policy := range c.Sampling.Levels {
+			level, _ := types.ParseLevel(string(text))
+			sampling.Levels[level] = options.SamplingPolicy(policy)
+		}
+		settingOpts = append(settingOpts, options.WithSampling(sampling))
+	}
+	return settingOpts, closers, nil
+}
+
+// openOutputs : 출력 설정으로 싱크를 열고 하나의 io.Writer 로 합치는 메서드
+//
+// 표준 출력, 표준 에러를 제외하고 닫아야 하는 싱크 목록을 함께 반환한다.
+func (c Config) openOutputs() (io.Writer, []io.Closer, error) {
+	if len(c.Outputs) == 0 {
+		return os.Stdout, nil, nil
+	}
+
+	writers := make([]io.Writer, 0, len(c.Outputs))
+	var closers []io.Closer
+	for i, output := range c.Outputs {
+		w, err := output.open(c.TimeFormat)
+		if err != nil {
+			closeAll(closers)
+			return nil, nil, ConfigErrors{{Path: fmt.Sprintf("outputs[%d]", i), Message: err.Error()}}
+		}
+		writers = append(writers, w)
+		if closer, ok := w.(io.Closer); ok && w != os.Stdout && w != os.Stderr {
+			closers = append(closers, closer)
+		}
+	}
+	if len(writers) == 1 {
+		return writers[0], closers, nil
+	}
+	return io.MultiWriter(writers...), closers, nil
+}
+
+// closeAll : 싱크들을 닫는 함수
+func closeAll(closers []io.Closer) {
+	for _, closer := range closers {
+		_ = closer.Close()
+	}
+}
+
+// outputClosers : NewFromConfig 가 연 싱크들을 한 번에 닫는 io.Closer
+type outputClosers []io.Closer
+
+// Close : 모든 싱크를 닫고 발생한 에러들을 합쳐 반환하는 메서드
+func (c outputClosers) Close() error {
+	var errs []error
+	for _, closer := range c {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// open : 출력 설정에 해당하는 싱크를 여는 메서드
+//   - timeFormat(string): 싱크가 로그 라인의 time 필드를 읽을 시간 포맷 (로거의 시간 포맷)
+func (o OutputConfig) open(timeFormat string) (io.Writer, error) {
+	switch o.Type {
+	case "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	case "file":
+		return os.OpenFile(o.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	case "tcp", "udp":
+		return sink.NewNetwork(o.Type, o.Address)
+	case "forward":
+		opts := []sink.Option{sink.WithTimeFormat(timeFormat)}
+		if o.Tag != "" {
+			opts = append(opts, sink.WithTag(o.Tag, ""))
+		}
+		return sink.NewForward("tcp", o.Address, opts...)
+	case "http":
+		opts := []sink.Option{sink.WithTimeFormat(timeFormat)}
+		if o.HTTPFormat != "" {
+			opts = append(opts, sink.WithHTTPFormat(o.HTTPFormat))
+		}
+		return sink.NewHTTP(o.URL, opts...)
+	case "journald":
+		return sink.NewJournald()
+	case "gelf":
+		return sink.NewGELF("udp", o.Address, sink.WithTimeFormat(timeFormat))
+	case "gelf_tcp":
+		return sink.NewGELF("tcp", o.Address, sink.WithTimeFormat(timeFormat))
+	case "otlp":
+		return sink.NewOTLP(sink.OTLPHTTP, o.URL, o.otlpOptions(timeFormat)...)
+	case "otlp_grpc":
+		opts := o.otlpOptions(timeFormat)
+		if o.Insecure {
+			opts = append(opts, sink.WithOTLPInsecure())
+		}
+		return sink.NewOTLP(sink.OTLPGRPC, o.Address, opts...)
+	default:
+		return nil, fmt.Errorf("unknown output type %q", o.Type)
+	}
+}
+
+// otlpOptions : otlp, otlp_grpc 출력에 공통으로 적용할 싱크 옵션을 반환하는 메서드
+func (o OutputConfig) otlpOptions(timeFormat string) []sink.Option {
+	opts := []sink.Option{sink.WithTimeFormat(timeFormat)}
+	if o.ServiceName != "" {
+		opts = append(opts, sink.WithOTLPService(o.ServiceName, ""))
+	}
+	return opts
+}
+
+// sortedLevels : 에러 메시지 순서가 일정하도록 레벨을 정렬하는 함수
+func sortedLevels(levels map[types.LogLevel]SamplingPolicyConfig) []types.LogLevel {
+	sorted := make([]types.LogLevel, 0, len(levels))
+	for level := range levels {
+		sorted = append(sorted, level)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// dedupWindow : 같은 로그의 억제 기간 상태
+type dedupWindow struct {
+	level      types.LogLevel
+	opts       []options.EntryOption
+	logger     Logger
+	first      time.Time
+	last       time.Time
+	seen       int
+	suppressed uint64
+	timer      *time.Timer
+}
+
+// deduplicator : 같은 로그를 기간 단위로 억제하는 구조체
+//
+// 같은 로거에서 Clone 된 로거들은 하나의 deduplicator 를 공유한다.
+type deduplicator struct {
+	config  options.Deduplication
+	now     func() time.Time
+	mu      sync.Mutex
+	windows map[string]*dedupWindow
+}
+
+// dedupLogger : 반복되는 로그를 억제한 뒤 백엔드 로거로 전달하는 데코레이터
+type dedupLogger struct {
+	Logger
+	loggerType types.LoggerType
+	dedup      *deduplicator
+}
+
+func newDedupLogger(logger Logger, loggerType types.LoggerType, config options.Deduplication) Logger {
+	return &dedupLogger{
+		Logger:     logger,
+		loggerType: loggerType,
+		dedup:      &deduplicator{config: config, now: time.Now, windows: map[string]*dedupWindow{}},
+	}
+}
+
+// WithContext : 컨텍스트에 로거를 등록하는 메서드
+func (l *dedupLogger) WithContext(ctx context.Context) context.Context {
+	key, _ := contextKey(l.loggerType)
+	return context.WithValue(ctx, key, l)
+}
+
+// Clone : 억제 상태를 공유하는 새 로거를 반환하는 메서드
+func (l *dedupLogger) Clone() Logger {
+	return &dedupLogger{Logger: l.Logger.Clone(), loggerType: l.loggerType, dedup: l.dedup}
+}
+
+// Debug : 디버그 로그를 출력하는 메서드
+func (l *dedupLogger) Debug(opts ...options.EntryOption) {
+	l.log(types.Debug, opts)
+}
+
+// Info : 정보 로그를 출력하는 메서드
+func (l *dedupLogger) Info(opts ...options.EntryOption) {
+	l.log(types.Info, opts)
+}
+
+// Warn : 경고 로그를 출력하는 메서드
+func (l *dedupLogger) Warn(opts ...options.EntryOption) {
+	l.log(types.Warn, opts)
+}
+
+// Error : 에러 로그를 출력하는 메서드
+func (l *dedupLogger) Error(opts ...options.EntryOption) {
+	l.log(types.Error, opts)
+}
+
+// Fatal : 치명적인 에러 로그를 출력하는 메서드
+func (l *dedupLogger) Fatal(opts ...options.EntryOption) {
+	l.log(types.Fatal, opts)
+}
+
+func (l *dedupLogger) log(level types.LogLevel, opts []options.EntryOption) {
+	policy, ok := l.dedup.config.Levels[level]
+	if !ok || policy.Window <= 0 {
+		Log(l.Logger, level, opts...)
+		return
+	}
+	burst := policy.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+
+	key := entryKey(level, options.NewEntry(opts...), l.dedup.config.KeyFields)
+	now := l.dedup.now()
+
+	l.dedup.mu.Lock()
+	window, ok := l.dedup.windows[key]
+	if !ok {
+		// 요약 로그는 타이머 고루틴에서 기록되므로, 현재 상태를 복사한 로거를 보관
+		window = &dedupWindow{level: level, opts: opts, logger: l.Logger.Clone(), first: now}
+		l.dedup.windows[key] = window
+		window.timer = time.AfterFunc(policy.Window, func() { l.dedup.flush(key, window) })
+	}
+	window.seen++
+	window.last = now
+	if window.seen > burst {
+		window.suppressed++
+		l.dedup.mu.Unlock()
+		return
+	}
+	l.dedup.mu.Unlock()
+
+	Log(l.Logger, level, opts...)
+}
+
+// Close : 억제 기간의 타이머를 멈추고, 억제된 로그의 요약 로그를 바로 기록한 뒤 감싼 로거를 닫는 메서드 (logger.Close 참고)
+//
+// Close 이후의 로그도 같은 정책으로 억제된다.
+func (l *dedupLogger) Close() error {
+	l.dedup.close()
+	return Close(l.Logger)
+}
+
+// close : 모든 억제 기간을 끝내고, 억제된 로그가 있으면 처음 발생한 순서대로 요약 로그를 기록하는 메서드
+func (d *deduplicator) close() {
+	d.mu.Lock()
+	windows := make([]*dedupWindow, 0, len(d.windows))
+	for _, window := range d.windows {
+		window.timer.Stop()
+		windows = append(windows, window)
+	}
+	d.windows = map[string]*dedupWindow{}
+	d.mu.Unlock()
+
+	sort.Slice(windows, func(i, j int) bool { return windows[i].first.Before(windows[j].first) })
+	for _, window := range windows {
+		window.report()
+	}
+}
+
+// flush : 억제 기간을 끝내고, 억제된 로그가 있으면 요약 로그를 기록하는 메서드
+//
+// Close 로 이미 끝난 기간이면 (같은 키의 새 기간이 시작되었더라도) 아무것도 하지 않는다.
+func (d *deduplicator) flush(key string, window *dedupWindow) {
+	d.mu.Lock()
+	if d.windows[key] != window {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.windows, key)
+	d.mu.Unlock()
+
+	window.report()
+}
+
+// report : 억제된 로그가 있으면 요약 로그를 기록하는 메서드 (deduplicator 에서 제거된 기간에서 호출)
+func (w *dedupWindow) report() {
+	if w.suppressed == 0 {
+		return
+	}
+	w.logger.ApplyOption(w.opts)
+	Log(w.logger, w.level, options.WithFields(options.Fields{
+		types.RepeatCountField: w.suppressed,
+		types.FirstSeenField:   w.first.Format(time.RFC3339Nano),
+		types.LastSeenField:    w.last.Format(time.RFC3339Nano),
+	}))
+}
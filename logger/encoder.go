@@ -0,0 +1,182 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// lineEncoder : 백엔드가 만든 로그 필드를 스키마에 맞게 바꾼 뒤 JSON, logfmt, CBOR, MessagePack 으로 인코딩하는 구조체
+type lineEncoder struct {
+	format types.LogFormat
+	schema schemaTransformer
+	// binary : CBOR, MessagePack 포맷의 binaryWriter (다른 포맷은 nil)
+	binary binaryWriter
+}
+
+// newLineEncoder : 로그 설정에 맞는 lineEncoder 를 생성하는 함수
+//
+// 백엔드의 JSON 출력을 그대로 사용할 수 있는 경우(JSON 포맷, 스키마 없음)와 텍스트 포맷인 경우 nil 을 반환한다.
+func newLineEncoder(settings options.LogSetting) *lineEncoder {
+	if settings.Format == types.Text {
+		return nil
+	}
+	if (settings.Format == "" || settings.Format == types.JSON) && settings.Schema == "" {
+		return nil
+	}
+	return formatEncoder(settings)
+}
+
+// formatEncoder : 로그 설정의 포맷과 스키마로 인코딩하는 lineEncoder 를 생성하는 함수
+func formatEncoder(settings options.LogSetting) *lineEncoder {
+	encoder := &lineEncoder{
+		format: settings.Format,
+		schema: newSchemaTransformer(settings),
+	}
+	switch settings.Format {
+	case types.CBOR:
+		encoder.binary = cborWriter{}
+	case types.MsgPack:
+		encoder.binary = msgpackWriter{}
+	}
+	return encoder
+}
+
+// direct : 필드를 JSON 값으로 바꾸지 않고 바로 인코딩할 수 있으면 포맷의 binaryWriter 를, 아니면 nil 을 반환하는 메서드
+//
+// 스키마는 JSON 으로 디코딩한 값(json.Number 등)을 기준으로 필드를 바꾸므로, 스키마가 있으면 nil 을 반환한다.
+func (e *lineEncoder) direct() binaryWriter {
+	if e.schema != nil {
+		return nil
+	}
+	return e.binary
+}
+
+// encode : 필드를 한 줄(바이너리 포맷은 데이터 아이템 하나)로 인코딩하는 메서드
+func (e *lineEncoder) encode(buf *bytes.Buffer, fields map[string]interface{}) error {
+	if e.schema != nil {
+		fields = e.schema.apply(fields)
+	}
+	if e.binary != nil {
+		encodeBinary(buf, e.binary, fields)
+		return nil
+	}
+	if e.format == types.Logfmt {
+		encodeLogfmt(buf, fields)
+		return nil
+	}
+
+	line, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	buf.Write(line)
+	buf.WriteByte('\n')
+	return nil
+}
+
+// encodedWriter : 백엔드가 출력한 로그를 하나씩 lineEncoder 로 다시 인코딩하여 기록하는 io.Writer
+//
+// 스키마 없는 바이너리 포맷은 JSON 로그를 맵으로 디코딩하지 않고 transcodeJSON 으로 바로 변환한다.
+type encodedWriter struct {
+	out     io.Writer
+	encoder *lineEncoder
+	decode  func(line []byte) (map[string]interface{}, error)
+	// transcode : JSON 로그를 바로 변환할 binaryWriter (nil 이면 decode 한 필드를 encoder 로 인코딩)
+	transcode binaryWriter
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// newEncodedWriter : 백엔드가 출력한 JSON 로그를 다시 인코딩하는 encodedWriter 생성자
+func newEncodedWriter(out io.Writer, encoder *lineEncoder) *encodedWriter {
+	return &encodedWriter{out: out, encoder: encoder, decode: decodeLogLine, transcode: encoder.direct()}
+}
+
+// Write : 로그 하나를 다시 인코딩하여 기록하는 메서드
+//
+// 디코딩할 수 없는 로그는 그대로 기록한다.
+func (w *encodedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Reset()
+	if w.transcode != nil {
+		if err := transcodeJSON(&w.buf, w.transcode, p); err != nil {
+			return w.out.Write(p)
+		}
+	} else {
+		fields, err := w.decode(p)
+		if err != nil {
+			return w.out.Write(p)
+		}
+		if err := w.encoder.encode(&w.buf, fields); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := w.out.Write(w.buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// encodedFormatter : logrus 엔트리를 lineEncoder 로 인코딩하는 logrus.Formatter
+//
+// 스키마 없는 바이너리 포맷은 엔트리의 필드를 encodeBinary 로 바로 인코딩하며,
+// logfmt 와 스키마를 적용하는 포맷은 JSONFormatter 와 같은 값이 되도록 JSON 을 거쳐 인코딩한다.
+type encodedFormatter struct {
+	timeFormat string
+	encoder    *lineEncoder
+}
+
+// Format : logrus 엔트리를 한 줄로 인코딩하는 메서드
+func (f *encodedFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data := make(logrus.Fields, len(entry.Data)+3)
+	for k, v := range entry.Data {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		data[k] = v
+	}
+	data[types.TimeField] = entry.Time.Format(f.timeFormat)
+	data[types.LevelField] = entry.Level.String()
+	if entry.Message != "" {
+		data[logrus.FieldKeyMsg] = entry.Message
+	}
+
+	var buf bytes.Buffer
+	if w := f.encoder.direct(); w != nil {
+		encodeBinary(&buf, w, map[string]interface{}(data))
+		return buf.Bytes(), nil
+	}
+
+	// JSONFormatter 와 같은 방식으로 값을 표현하도록 JSON 을 거쳐 변환
+	line, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := decodeLogLine(line)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.encoder.encode(&buf, fields); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeLogLine : JSON 로그 한 줄을 맵으로 변환하는 함수 (숫자는 json.Number 로 유지)
+func decodeLogLine(line []byte) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,196 @@
+package logger
+
+import (
+	stdjson "encoding/json"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// Google Cloud Logging 이 인식하는 구조화 로그의 특수 필드
+const (
+	gcpSeverityField       = "severity"
+	gcpTimestampField      = "timestamp"
+	gcpTraceField          = "logging.googleapis.com/trace"
+	gcpSpanIDField         = "logging.googleapis.com/spanId"
+	gcpSourceLocationField = "logging.googleapis.com/sourceLocation"
+	gcpHTTPRequestField    = "httpRequest"
+)
+
+// gcpFields : 라이브러리 필드 이름과 Cloud Logging 필드 이름의 기본 매핑
+//
+// HTTP 필드(gcpHTTPRequestFields), 호출 위치, trace 는 값의 형식까지 바꿔야 하므로 따로 처리한다.
+var gcpFields = map[string]string{
+	types.TimeField:    gcpTimestampField,
+	types.MessageField: "message",
+}
+
+// gcpHTTPRequestFields : 라이브러리 필드 이름과 httpRequest 필드 이름의 매핑
+//
+// HTTP 액세스 로그(isHTTPAccessLog)에만 적용하며, 그 밖의 로그는 같은 이름의 필드를 그대로 기록한다.
+var gcpHTTPRequestFields = map[string]string{
+	types.MethodField:       "requestMethod",
+	types.URIField:          "requestUrl",
+	types.StatusCodeField:   "status",
+	types.BytesWrittenField: "responseSize",
+	types.UserAgentField:    "userAgent",
+	types.RemoteAddrField:   "remoteIp",
+	types.RefererField:      "referer",
+	types.ElapsedField:      "latency",
+}
+
+// gcpSeverities : 라이브러리 레벨과 Cloud Logging severity 의 매핑
+var gcpSeverities = map[string]string{
+	"trace":   "DEBUG",
+	"debug":   "DEBUG",
+	"info":    "INFO",
+	"warn":    "WARNING",
+	"warning": "WARNING",
+	"error":   "ERROR",
+	"fatal":   "CRITICAL",
+	"panic":   "ALERT",
+}
+
+// gcpProfile : 로그 필드를 Google Cloud Logging 구조화 로그 형식으로 바꾸는 구조체
+type gcpProfile struct {
+	project string
+	fields  map[string]string
+}
+
+// newGCPProfile : gcpProfile 생성자
+//   - project(string): trace 리소스 이름에 사용할 프로젝트 ID, 빈 문자열이면 GOOGLE_CLOUD_PROJECT 환경 변수
+//   - overrides(map[string]string): 기본 매핑에 추가하거나 덮어쓸 매핑 (필드 이름 → 최상위 키)
+func newGCPProfile(project string, overrides map[string]string) *gcpProfile {
+	if project == "" {
+		project = os.Getenv("GOOGLE_CLOUD_PROJECT")
+	}
+
+	fields := make(map[string]string, len(gcpFields)+len(overrides))
+	for field, key := range gcpFields {
+		fields[field] = key
+	}
+	for field, key := range overrides {
+		fields[field] = key
+	}
+	return &gcpProfile{project: project, fields: fields}
+}
+
+// apply : 필드를 Cloud Logging 의 특수 필드와 httpRequest 로 바꾼 새 필드를 반환하는 메서드
+//
+// 사용자 매핑이 있는 필드는 특수 필드로 바꾸지 않고 매핑한 키에 그대로 기록한다.
+// httpRequest 는 HTTP 액세스 로그에만 만들며, gRPC 호출 로그나 쿼리 로그의 elapsed 등은 일반 필드로 남긴다.
+func (p *gcpProfile) apply(fields map[string]interface{}) map[string]interface{} {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]interface{}, len(fields))
+	httpRequest := map[string]interface{}{}
+	access := isHTTPAccessLog(fields)
+	for _, key := range keys {
+		value := fields[key]
+		if mapped, ok := p.fields[key]; ok && mapped != "" {
+			out[mapped] = value
+			continue
+		}
+
+		switch key {
+		case types.LevelField:
+			out[gcpSeverityField] = gcpSeverity(value)
+		case types.CallerField:
+			out[gcpSourceLocationField] = gcpSourceLocation(value)
+		case types.TraceIDField:
+			out[gcpTraceField] = p.trace(value)
+		case types.SpanIDField:
+			out[gcpSpanIDField] = value
+		default:
+			if name, ok := gcpHTTPRequestFields[key]; ok && access {
+				httpRequest[name] = gcpHTTPRequestValue(key, value)
+				continue
+			}
+			out[key] = value
+		}
+	}
+	if len(httpRequest) > 0 {
+		out[gcpHTTPRequestField] = httpRequest
+	}
+	return out
+}
+
+// isHTTPAccessLog : HTTP 메서드와 함께 URI 또는 상태 코드가 있는 HTTP 액세스 로그인지 반환하는 함수
+func isHTTPAccessLog(fields map[string]interface{}) bool {
+	if _, ok := fields[types.MethodField]; !ok {
+		return false
+	}
+	_, uri := fields[types.URIField]
+	_, status := fields[types.StatusCodeField]
+	return uri || status
+}
+
+// trace : trace ID 를 "projects/<project>/traces/<trace_id>" 리소스 이름으로 바꾸는 메서드
+//
+// 프로젝트 ID 가 없으면 trace ID 를 그대로 반환한다.
+func (p *gcpProfile) trace(value interface{}) interface{} {
+	id, ok := value.(string)
+	if !ok || id == "" || p.project == "" || strings.HasPrefix(id, "projects/") {
+		return value
+	}
+	return "projects/" + p.project + "/traces/" + id
+}
+
+// gcpSeverity : 레벨을 Cloud Logging severity 로 바꾸는 함수, 알 수 없는 레벨은 DEFAULT
+func gcpSeverity(value interface{}) string {
+	level, _ := value.(string)
+	if severity, ok := gcpSeverities[strings.ToLower(level)]; ok {
+		return severity
+	}
+	return "DEFAULT"
+}
+
+// gcpSourceLocation : "file:line" 형식의 호출 위치를 sourceLocation 으로 바꾸는 함수
+func gcpSourceLocation(value interface{}) interface{} {
+	caller, ok := value.(string)
+	if !ok {
+		return value
+	}
+	location := map[string]interface{}{"file": caller}
+	if i := strings.LastIndexByte(caller, ':'); i > 0 {
+		if _, err := strconv.Atoi(caller[i+1:]); err == nil {
+			// sourceLocation.line 은 int64 이므로 문자열로 기록한다.
+			location["file"], location["line"] = caller[:i], caller[i+1:]
+		}
+	}
+	return location
+}
+
+// gcpHTTPRequestValue : HTTP 필드 값을 httpRequest 의 형식으로 바꾸는 함수
+//   - responseSize: int64 이므로 문자열
+//   - remoteIp: 포트를 제외한 IP
+//   - latency: 처리 시간(ms)을 "<초>s" 형식의 Duration 으로
+func gcpHTTPRequestValue(key string, value interface{}) interface{} {
+	switch key {
+	case types.BytesWrittenField:
+		if n, ok := value.(stdjson.Number); ok {
+			return n.String()
+		}
+	case types.RemoteAddrField:
+		if addr, ok := value.(string); ok {
+			if host, _, err := net.SplitHostPort(addr); err == nil {
+				return host
+			}
+		}
+	case types.ElapsedField:
+		if n, ok := value.(stdjson.Number); ok {
+			if ms, err := n.Float64(); err == nil {
+				return strconv.FormatFloat(ms/1000, 'f', -1, 64) + "s"
+			}
+		}
+	}
+	return value
+}
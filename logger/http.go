@@ -0,0 +1,247 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/masking"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// HTTPMiddleware : 요청 단위 로거를 생성하고 액세스 로그를 기록하는 net/http 미들웨어
+//   - base(Logger): 요청마다 복사하여 사용할 기반 로거
+//   - opts(...HTTPOption): HTTP 로깅 설정 옵션
+//
+// 요청 ID 헤더가 있으면 그대로 사용하고 없으면 생성하여, 공통 필드와 응답 헤더에 등록한다.
+// 헤더 값이 128자를 넘거나 영문자, 숫자, '-', '_', '.', ':' 외의 문자를 포함하면 신뢰하지 않고 새로 생성한다.
+// 요청 단위 로거는 요청 컨텍스트에 등록되며, 핸들러가 끝나면 상태 코드, 응답 크기, 처리 시간 등을
+// 담은 액세스 로그를 기록한다 (5xx: error, 4xx: warn, 그 외: info).
+//
+// Example:
+//
+//	log := logger.NewWrapper(types.ZeroLog)
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+//		// 요청 ID 가 공통 필드로 등록된 로거
+//		log := logger.FromContext(r.Context(), types.ZeroLog)
+//		log.Info(options.WithMessage("handling request"))
+//	})
+//	http.ListenAndServe(":8080", logger.HTTPMiddleware(log)(mux))
+//	// output: {"level":"info","rid":"...","start_time":"...","elapsed":3,"status_code":200,"method":"GET","uri":"/",...,"message":"http request"}
+func HTTPMiddleware(base Logger, opts ...options.HTTPOption) func(http.Handler) http.Handler {
+	setting := options.NewHTTPLogging(opts...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestID := r.Header.Get(setting.RequestIDHeader)
+			if !ValidRequestID(requestID) {
+				requestID = generateRequestID(setting)
+			}
+			w.Header().Set(setting.RequestIDHeader, requestID)
+
+			log := base.Clone()
+			log.RegisterCommonField(setting.RequestIDField, requestID)
+			ctx := log.WithContext(WithRequestID(r.Context(), requestID))
+
+			writer, recorder := wrapResponseWriter(w)
+			next.ServeHTTP(writer, r.WithContext(ctx))
+
+			end := time.Now()
+			fields := options.Fields{}
+			names := setting.Fields
+			addField(fields, names.StartTime, start.Format(time.RFC3339Nano))
+			addField(fields, names.EndTime, end.Format(time.RFC3339Nano))
+			addField(fields, names.Elapsed, end.Sub(start).Milliseconds())
+			addField(fields, names.StatusCode, recorder.status())
+			addField(fields, names.Method, r.Method)
+			addField(fields, names.URI, requestURI(r, setting.QueryMasking))
+			addField(fields, names.Referer, r.Referer())
+			addField(fields, names.UserAgent, r.UserAgent())
+			addField(fields, names.RemoteAddr, r.RemoteAddr)
+			addField(fields, names.BytesWritten, recorder.bytes)
+
+			Log(log, statusLevel(recorder.status()),
+				options.WithMessage(setting.Message),
+				options.WithFields(fields),
+			)
+		})
+	}
+}
+
+// WithRequestID : 컨텍스트에 요청 ID 를 등록하는 함수
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, types.RequestIDKey, requestID)
+}
+
+// RequestIDFromContext : 컨텍스트에 등록된 요청 ID 를 반환하는 함수
+//
+// Example:
+//
+//	// HTTPMiddleware 가 등록한 요청 ID 를 하위 서비스 호출에 전달
+//	req.Header.Set("X-Request-ID", logger.RequestIDFromContext(ctx))
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(types.RequestIDKey).(string)
+	return requestID
+}
+
+// statusLevel : 응답 상태 코드에 해당하는 액세스 로그 레벨을 반환하는 함수
+func statusLevel(status int) types.LogLevel {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return types.Error
+	case status >= http.StatusBadRequest:
+		return types.Warn
+	default:
+		return types.Info
+	}
+}
+
+// requestURI : 쿼리 파라미터를 마스킹한 요청 URI 를 반환하는 함수
+func requestURI(r *http.Request, rules masking.Rules) string {
+	if r.URL.RawQuery == "" {
+		return r.URL.Path
+	}
+	return r.URL.Path + "?" + masking.Query(r.URL.RawQuery, rules)
+}
+
+// addField : 필드 이름이 "-" 가 아닌 경우에만 필드를 추가하는 함수
+func addField(fields options.Fields, name string, value interface{}) {
+	if name != "-" {
+		fields[name] = value
+	}
+}
+
+// maxRequestIDLength : 요청 헤더로 받은 요청 ID 의 최대 길이
+const maxRequestIDLength = 128
+
+// ValidRequestID : 요청 헤더나 메타데이터로 받은 요청 ID 를 그대로 사용할 수 있는지 확인하는 함수
+//
+// 로그와 응답 헤더에 그대로 기록되므로 길이를 128자로 제한하고, UUID, hex, W3C trace ID 등에 쓰이는
+// 영문자, 숫자, '-', '_', '.', ':' 만 허용한다. HTTPMiddleware 와 interceptor 패키지가 같은 기준을 사용한다.
+//
+// Example:
+//
+//	requestID := r.Header.Get("X-Request-ID")
+//	if !logger.ValidRequestID(requestID) {
+//		requestID = newRequestID()
+//	}
+func ValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		switch c := requestID[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// generateRequestID : 설정된 생성 함수 또는 랜덤 16바이트 hex 로 요청 ID 를 생성하는 함수
+func generateRequestID(setting options.HTTPLogging) string {
+	if setting.GenerateRequestID != nil {
+		return setting.GenerateRequestID()
+	}
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// responseRecorder : 응답 상태 코드와 본문 크기를 기록하는 http.ResponseWriter 래퍼
+type responseRecorder struct {
+	http.ResponseWriter
+	code  int
+	bytes int64
+}
+
+// wrapResponseWriter : 원래 ResponseWriter 가 지원하는 http.Flusher, http.Hijacker 를 유지하여 래핑하는 함수
+func wrapResponseWriter(w http.ResponseWriter) (http.ResponseWriter, *responseRecorder) {
+	recorder := &responseRecorder{ResponseWriter: w}
+	_, flusher := w.(http.Flusher)
+	_, hijacker := w.(http.Hijacker)
+	switch {
+	case flusher && hijacker:
+		return flushHijackRecorder{recorder}, recorder
+	case flusher:
+		return flushRecorder{recorder}, recorder
+	case hijacker:
+		return hijackRecorder{recorder}, recorder
+	default:
+		return recorder, recorder
+	}
+}
+
+// WriteHeader : 상태 코드를 기록하는 메서드
+func (r *responseRecorder) WriteHeader(code int) {
+	if r.code == 0 {
+		r.code = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Write : 응답 본문 크기를 기록하는 메서드
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.code == 0 {
+		r.code = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap : http.ResponseController 가 원래 ResponseWriter 를 사용할 수 있도록 반환하는 메서드
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// status : 기록된 상태 코드를 반환하는 메서드 (아무것도 쓰지 않은 경우 200)
+func (r *responseRecorder) status() int {
+	if r.code == 0 {
+		return http.StatusOK
+	}
+	return r.code
+}
+
+func (r *responseRecorder) flush() {
+	if r.code == 0 {
+		r.code = http.StatusOK
+	}
+	r.ResponseWriter.(http.Flusher).Flush()
+}
+
+func (r *responseRecorder) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("logger: response writer does not support hijacking")
+	}
+	if r.code == 0 {
+		r.code = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+type flushRecorder struct{ *responseRecorder }
+
+func (r flushRecorder) Flush() { r.flush() }
+
+type hijackRecorder struct{ *responseRecorder }
+
+func (r hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) { return r.hijack() }
+
+type flushHijackRecorder struct{ *responseRecorder }
+
+func (r flushHijackRecorder) Flush() { r.flush() }
+
+func (r flushHijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) { return r.hijack() }
@@ -0,0 +1,127 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/wjddn3711/structured-logger/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// UnaryClientInterceptor : 요청 ID 를 전달하고 호출 로그를 기록하는 unary 클라이언트 인터셉터
+//   - base(logger.Logger): 호출마다 복사하여 사용할 기반 로거
+//   - opts(...Option): 인터셉터 설정 옵션
+//
+// 컨텍스트에 요청 ID 가 있으면(logger.RequestIDFromContext) 송신 메타데이터로 전달하고, 없으면 생성한다.
+//
+// Example:
+//
+//	conn, err := grpc.Dial(target, grpc.WithChainUnaryInterceptor(interceptor.UnaryClientInterceptor(log)))
+//	// HTTP/gRPC 요청을 처리하는 중이라면 같은 요청 ID 가 하위 서비스로 전달된다.
+//	user, err := pb.NewUserServiceClient(conn).GetUser(r.Context(), req)
+func UnaryClientInterceptor(base logger.Logger, opts ...Option) grpc.UnaryClientInterceptor {
+	s := newSetting(opts, "grpc call")
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, requestID := s.outgoingContext(ctx)
+		c := newCall(base, s, requestID, method, false)
+
+		var p peer.Peer
+		err := invoker(ctx, method, req, reply, cc, append(callOpts, grpc.Peer(&p))...)
+		c.peer = p.Addr
+		c.finish(s, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor : 요청 ID 를 전달하고 호출 로그를 기록하는 stream 클라이언트 인터셉터
+//   - base(logger.Logger): 호출마다 복사하여 사용할 기반 로거
+//   - opts(...Option): 인터셉터 설정 옵션
+//
+// 호출 로그는 스트림이 끝났을 때(RecvMsg 가 io.EOF 또는 에러를 반환) 기록된다.
+func StreamClientInterceptor(base logger.Logger, opts ...Option) grpc.StreamClientInterceptor {
+	s := newSetting(opts, "grpc call")
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, requestID := s.outgoingContext(ctx)
+		c := newCall(base, s, requestID, method, true)
+
+		p := &peer.Peer{}
+		cs, err := streamer(ctx, desc, cc, method, append(callOpts, grpc.Peer(p))...)
+		if err != nil {
+			c.finish(s, err)
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, desc: desc, setting: s, call: c, peer: p}, nil
+	}
+}
+
+// outgoingContext : 컨텍스트의 요청 ID 를 송신 메타데이터에 등록하는 메서드
+//
+// 송신 메타데이터에 이미 요청 ID 가 있으면 그대로 사용한다.
+func (s setting) outgoingContext(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if requestID := firstValue(md.Get(s.requestIDKey)); requestID != "" {
+		return ctx, requestID
+	}
+
+	requestID := logger.RequestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = s.requestID()
+	}
+	return metadata.AppendToOutgoingContext(ctx, s.requestIDKey, requestID), requestID
+}
+
+// clientStream : 메시지 수를 세고 스트림이 끝나면 호출 로그를 기록하는 grpc.ClientStream 래퍼
+type clientStream struct {
+	grpc.ClientStream
+	desc    *grpc.StreamDesc
+	setting setting
+	call    *call
+	peer    *peer.Peer
+	once    sync.Once
+}
+
+// SendMsg : 보낸 메시지 수를 세는 메서드
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.call.sent.Add(1)
+	} else if !errors.Is(err, io.EOF) {
+		// io.EOF 인 경우 실제 상태는 RecvMsg 에서 반환된다.
+		s.finish(err)
+	}
+	return err
+}
+
+// RecvMsg : 받은 메시지 수를 세고, 스트림이 끝나면 호출 로그를 기록하는 메서드
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.call.received.Add(1)
+		if !s.desc.ServerStreams {
+			// 서버 스트리밍이 아니면 응답은 하나뿐이므로 호출이 끝난 것
+			s.finish(nil)
+		}
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
+	default:
+		s.finish(err)
+	}
+	return err
+}
+
+// finish : 호출 로그를 한 번만 기록하는 메서드
+func (s *clientStream) finish(err error) {
+	s.once.Do(func() {
+		s.call.peer = s.peer.Addr
+		s.call.finish(s.setting, err)
+	})
+}
@@ -0,0 +1,105 @@
+// Package interceptor : 요청 단위 로거와 호출 로그를 제공하는 gRPC 서버/클라이언트 인터셉터
+//
+// logger.HTTPMiddleware 와 같은 방식으로 요청 ID 를 메타데이터로 주고받고, 요청 단위 로거를 컨텍스트에 등록한다.
+//
+// Example:
+//
+//	log := logger.NewWrapper(types.ZeroLog)
+//	server := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(interceptor.UnaryServerInterceptor(log)),
+//		grpc.ChainStreamInterceptor(interceptor.StreamServerInterceptor(log)),
+//	)
+//	conn, err := grpc.Dial(target,
+//		grpc.WithChainUnaryInterceptor(interceptor.UnaryClientInterceptor(log)),
+//		grpc.WithChainStreamInterceptor(interceptor.StreamClientInterceptor(log)),
+//	)
+package interceptor
+
+import (
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CodeLevel : gRPC 상태 코드에 해당하는 기본 로그 레벨을 반환하는 함수
+//   - info: OK, Canceled, InvalidArgument, NotFound, AlreadyExists, Unauthenticated (클라이언트 책임의 실패)
+//   - warn: DeadlineExceeded, PermissionDenied, ResourceExhausted, FailedPrecondition, Aborted, OutOfRange
+//   - error: Unknown, Unimplemented, Internal, Unavailable, DataLoss 및 그 외
+func CodeLevel(code codes.Code) types.LogLevel {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.Unauthenticated:
+		return types.Info
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition,
+		codes.Aborted, codes.OutOfRange:
+		return types.Warn
+	default:
+		return types.Error
+	}
+}
+
+// call : 하나의 gRPC 호출에 대한 로그 정보
+type call struct {
+	log      logger.Logger
+	method   string
+	start    time.Time
+	peer     net.Addr
+	stream   bool
+	sent     atomic.Int64
+	received atomic.Int64
+}
+
+// finish : 호출 결과를 레벨에 맞춰 기록하는 메서드
+func (c *call) finish(s setting, err error) {
+	code := status.Code(err)
+	service, method := splitMethod(c.method)
+
+	fields := options.Fields{
+		types.GRPCServiceField: service,
+		types.GRPCMethodField:  method,
+		types.GRPCCodeField:    code.String(),
+		types.ElapsedField:     time.Since(c.start).Milliseconds(),
+	}
+	if c.peer != nil {
+		fields[types.PeerField] = c.peer.String()
+	}
+	if c.stream {
+		fields[types.MsgSentField] = c.sent.Load()
+		fields[types.MsgReceivedField] = c.received.Load()
+	}
+	if err != nil {
+		fields[types.ErrorField] = status.Convert(err).Message()
+	}
+
+	logger.Log(c.log, s.levelFunc(code), options.WithMessage(s.message), options.WithFields(fields))
+}
+
+// newCall : 요청 ID 를 공통 필드로 등록한 로거로 호출 정보를 생성하는 함수
+func newCall(base logger.Logger, s setting, requestID, fullMethod string, stream bool) *call {
+	log := base.Clone()
+	log.RegisterCommonField(s.requestIDField, requestID)
+	return &call{log: log, method: fullMethod, start: time.Now(), stream: stream}
+}
+
+// splitMethod : "/package.Service/Method" 형식의 메서드 이름을 서비스와 메서드로 나누는 함수
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+// firstValue : 메타데이터 값 중 첫 번째 값을 반환하는 함수
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
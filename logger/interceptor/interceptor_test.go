@@ -0,0 +1,293 @@
+package interceptor_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/interceptor"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func TestServerInterceptor(t *testing.T) {
+	t.Run("unary 호출의 요청 ID 가 핸들러 로그와 호출 로그에 기록되는지 테스트", func(t *testing.T) {
+		// given
+		serverLog := &captureWriter{}
+		conn := newEchoServer(t, logger.NewWrapper(types.ZeroLog, options.WithOutput(serverLog)))
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+
+		// when
+		var header metadata.MD
+		reply := &wrapperspb.StringValue{}
+		err := conn.Invoke(ctx, "/test.Echo/Unary", wrapperspb.String("hello"), reply, grpc.Header(&header))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "hello", reply.Value)
+		assert.Equal(t, []string{"req-1"}, header.Get("x-request-id"), "요청 ID 가 응답 헤더로 전달되어야 합니다.")
+
+		lines := serverLog.Lines()
+		require.Len(t, lines, 2)
+		assert.Equal(t, "handling", lines[0][types.MessageField])
+		assert.Equal(t, "req-1", lines[0][types.RequestIDField], "핸들러 로그에 요청 ID 가 기록되어야 합니다.")
+
+		access := lines[1]
+		assert.Equal(t, "grpc request", access[types.MessageField])
+		assert.Equal(t, "info", access[types.LevelField])
+		assert.Equal(t, "req-1", access[types.RequestIDField])
+		assert.Equal(t, "test.Echo", access[types.GRPCServiceField])
+		assert.Equal(t, "Unary", access[types.GRPCMethodField])
+		assert.Equal(t, "OK", access[types.GRPCCodeField])
+		assert.Contains(t, access, types.ElapsedField)
+		assert.Contains(t, access, types.PeerField)
+	})
+
+	t.Run("신뢰할 수 없는 요청 ID 는 새로 생성한 ID 로 바뀌는지 테스트", func(t *testing.T) {
+		for _, incoming := range []string{"<script>alert(1)</script>", strings.Repeat("a", 129)} {
+			// given
+			serverLog := &captureWriter{}
+			conn := newEchoServer(t, logger.NewWrapper(types.ZeroLog, options.WithOutput(serverLog)))
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", incoming)
+
+			// when
+			var header metadata.MD
+			err := conn.Invoke(ctx, "/test.Echo/Unary", wrapperspb.String("hello"), &wrapperspb.StringValue{}, grpc.Header(&header))
+
+			// then
+			require.NoError(t, err)
+			requestID := serverLog.Map()[types.RequestIDField]
+			assert.NotEqual(t, incoming, requestID)
+			assert.Regexp(t, "^[0-9a-f]{32}$", requestID, "생성한 요청 ID 가 기록되어야 합니다.")
+			assert.Equal(t, []string{requestID.(string)}, header.Get("x-request-id"), "생성한 요청 ID 가 응답 헤더로 전달되어야 합니다.")
+		}
+	})
+
+	t.Run("상태 코드에 따라 로그 레벨이 정해지는지 테스트", func(t *testing.T) {
+		// given
+		serverLog := &captureWriter{}
+		conn := newEchoServer(t, logger.NewWrapper(types.Logrus, options.WithOutput(serverLog)))
+
+		// when
+		err := conn.Invoke(context.Background(), "/test.Echo/Unary", wrapperspb.String("internal"), &wrapperspb.StringValue{})
+
+		// then
+		assert.Equal(t, codes.Internal, status.Code(err))
+		access := serverLog.Map()
+		assert.Equal(t, "error", access[types.LevelField])
+		assert.Equal(t, "Internal", access[types.GRPCCodeField])
+		assert.Equal(t, "boom", access[types.ErrorField])
+		assert.NotEmpty(t, access[types.RequestIDField], "요청 ID 가 없으면 생성되어야 합니다.")
+	})
+
+	t.Run("stream 호출의 메시지 수가 기록되는지 테스트", func(t *testing.T) {
+		// given
+		serverLog := &captureWriter{}
+		conn := newEchoServer(t, logger.NewWrapper(types.ZeroLog, options.WithOutput(serverLog)))
+		stream, err := conn.NewStream(context.Background(), &echoDesc.Streams[0], "/test.Echo/Stream")
+		require.NoError(t, err)
+
+		// when
+		for _, msg := range []string{"a", "b", "c"} {
+			require.NoError(t, stream.SendMsg(wrapperspb.String(msg)))
+			require.NoError(t, stream.RecvMsg(&wrapperspb.StringValue{}))
+		}
+		require.NoError(t, stream.CloseSend())
+		assert.ErrorIs(t, stream.RecvMsg(&wrapperspb.StringValue{}), io.EOF)
+
+		// then
+		require.Eventually(t, func() bool { return len(serverLog.Lines()) == 1 }, time.Second, 5*time.Millisecond)
+		access := serverLog.Map()
+		assert.Equal(t, "Stream", access[types.GRPCMethodField])
+		assert.Equal(t, float64(3), access[types.MsgSentField])
+		assert.Equal(t, float64(3), access[types.MsgReceivedField])
+		assert.NotEmpty(t, access[types.RequestIDField])
+	})
+}
+
+func TestClientInterceptor(t *testing.T) {
+	t.Run("컨텍스트의 요청 ID 가 서버로 전달되고 호출 로그가 기록되는지 테스트", func(t *testing.T) {
+		// given
+		serverLog, clientLog := &captureWriter{}, &captureWriter{}
+		conn := newEchoServer(t, logger.NewWrapper(types.ZeroLog, options.WithOutput(serverLog)),
+			grpc.WithChainUnaryInterceptor(interceptor.UnaryClientInterceptor(
+				logger.NewWrapper(types.ZeroLog, options.WithOutput(clientLog)),
+			)),
+		)
+		ctx := logger.WithRequestID(context.Background(), "req-2")
+
+		// when
+		err := conn.Invoke(ctx, "/test.Echo/Unary", wrapperspb.String("not_found"), &wrapperspb.StringValue{})
+
+		// then
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "req-2", serverLog.Map()[types.RequestIDField], "요청 ID 가 메타데이터로 전달되어야 합니다.")
+
+		call := clientLog.Map()
+		assert.Equal(t, "grpc call", call[types.MessageField])
+		assert.Equal(t, "info", call[types.LevelField], "NotFound 는 info 레벨로 기록되어야 합니다.")
+		assert.Equal(t, "req-2", call[types.RequestIDField])
+		assert.Equal(t, "NotFound", call[types.GRPCCodeField])
+		assert.Equal(t, "bufconn", call[types.PeerField], "상대방 주소가 기록되어야 합니다.")
+	})
+
+	t.Run("stream 호출이 끝나면 호출 로그가 한 번 기록되는지 테스트", func(t *testing.T) {
+		// given
+		serverLog, clientLog := &captureWriter{}, &captureWriter{}
+		conn := newEchoServer(t, logger.NewWrapper(types.ZeroLog, options.WithOutput(serverLog)),
+			grpc.WithChainStreamInterceptor(interceptor.StreamClientInterceptor(
+				logger.NewWrapper(types.ZeroLog, options.WithOutput(clientLog)),
+				interceptor.WithMessage("echo stream"),
+			)),
+		)
+		stream, err := conn.NewStream(context.Background(), &echoDesc.Streams[0], "/test.Echo/Stream")
+		require.NoError(t, err)
+
+		// when
+		require.NoError(t, stream.SendMsg(wrapperspb.String("a")))
+		require.NoError(t, stream.RecvMsg(&wrapperspb.StringValue{}))
+		require.NoError(t, stream.CloseSend())
+		_ = stream.RecvMsg(&wrapperspb.StringValue{})
+		_ = stream.RecvMsg(&wrapperspb.StringValue{})
+
+		// then
+		lines := clientLog.Lines()
+		require.Len(t, lines, 1)
+		assert.Equal(t, "echo stream", lines[0][types.MessageField])
+		assert.Equal(t, "OK", lines[0][types.GRPCCodeField])
+		assert.Equal(t, float64(1), lines[0][types.MsgSentField])
+		assert.Equal(t, float64(1), lines[0][types.MsgReceivedField])
+		assert.Equal(t, lines[0][types.RequestIDField], serverLog.Map()[types.RequestIDField])
+	})
+}
+
+func TestCodeLevel(t *testing.T) {
+	assert.Equal(t, types.Info, interceptor.CodeLevel(codes.OK))
+	assert.Equal(t, types.Info, interceptor.CodeLevel(codes.InvalidArgument))
+	assert.Equal(t, types.Warn, interceptor.CodeLevel(codes.DeadlineExceeded))
+	assert.Equal(t, types.Warn, interceptor.CodeLevel(codes.PermissionDenied))
+	assert.Equal(t, types.Error, interceptor.CodeLevel(codes.Unavailable))
+	assert.Equal(t, types.Error, interceptor.CodeLevel(codes.Code(100)))
+}
+
+// echoServer : 테스트용 Echo 서비스
+type echoServer interface{}
+
+var echoDesc = grpc.ServiceDesc{
+	ServiceName: "test.Echo",
+	HandlerType: (*echoServer)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Unary",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, in grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := &wrapperspb.StringValue{}
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.Echo/Unary"}
+			return in(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				switch value := req.(*wrapperspb.StringValue).Value; value {
+				case "internal":
+					return nil, status.Error(codes.Internal, "boom")
+				case "not_found":
+					return nil, status.Error(codes.NotFound, "missing")
+				default:
+					logger.FromContext(ctx, types.ZeroLog).Info(options.WithMessage("handling"))
+					return wrapperspb.String(value), nil
+				}
+			})
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Stream",
+		ServerStreams: true,
+		ClientStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			for {
+				msg := &wrapperspb.StringValue{}
+				if err := stream.RecvMsg(msg); err != nil {
+					if err == io.EOF {
+						return nil
+					}
+					return err
+				}
+				if err := stream.SendMsg(msg); err != nil {
+					return err
+				}
+			}
+		},
+	}},
+}
+
+// newEchoServer : 서버 인터셉터를 적용한 Echo 서비스를 bufconn 으로 띄우고 연결을 반환하는 함수
+func newEchoServer(t *testing.T, log logger.Logger, dialOpts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	ln := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptor.UnaryServerInterceptor(log)),
+		grpc.ChainStreamInterceptor(interceptor.StreamServerInterceptor(log)),
+	)
+	server.RegisterService(&echoDesc, struct{}{})
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(server.Stop)
+
+	dialOpts = append(dialOpts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.Dial("bufnet", dialOpts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+type captureWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *captureWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *captureWriter) Lines() []map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var lines []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(w.buf.Bytes()), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var m map[string]interface{}
+		_ = json.Unmarshal(line, &m)
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func (w *captureWriter) Map() map[string]interface{} {
+	lines := w.Lines()
+	if len(lines) == 0 {
+		return nil
+	}
+	return lines[len(lines)-1]
+}
@@ -0,0 +1,100 @@
+package interceptor
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+	"google.golang.org/grpc/codes"
+)
+
+// setting : 인터셉터 설정
+type setting struct {
+	requestIDKey      string
+	requestIDField    string
+	generateRequestID func() string
+	levelFunc         func(codes.Code) types.LogLevel
+	message           string
+}
+
+func newSetting(opts []Option, message string) setting {
+	s := setting{
+		requestIDKey:   "x-request-id",
+		requestIDField: types.RequestIDField,
+		levelFunc:      CodeLevel,
+		message:        message,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// requestID : 설정된 생성 함수 또는 랜덤 16바이트 hex 로 요청 ID 를 생성하는 메서드
+func (s setting) requestID() string {
+	if s.generateRequestID != nil {
+		return s.generateRequestID()
+	}
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Option 인터셉터 설정을 위한 옵션 타입
+//   - WithRequestIDKey: 요청 ID 메타데이터 키를 설정하는 옵션
+//   - WithRequestIDField: 요청 ID 공통 필드 이름을 설정하는 옵션
+//   - WithRequestIDGenerator: 요청 ID 생성 함수를 설정하는 옵션
+//   - WithLevelFunc: gRPC 상태 코드별 로그 레벨 함수를 설정하는 옵션
+//   - WithMessage: 호출 로그 메시지를 설정하는 옵션
+type Option func(*setting)
+
+// WithRequestIDKey 요청 ID 를 주고받을 메타데이터 키를 설정하는 옵션 (default: x-request-id)
+//
+// gRPC 메타데이터 키는 소문자로 다뤄진다.
+func WithRequestIDKey(key string) Option {
+	return func(s *setting) {
+		s.requestIDKey = key
+	}
+}
+
+// WithRequestIDField 요청 ID 공통 필드 이름을 설정하는 옵션 (default: rid)
+func WithRequestIDField(field string) Option {
+	return func(s *setting) {
+		s.requestIDField = field
+	}
+}
+
+// WithRequestIDGenerator 요청 ID 가 없거나 신뢰할 수 없을 때 사용할 생성 함수를 설정하는 옵션 (default: 랜덤 16바이트 hex)
+//
+// Example:
+//
+//	interceptor.UnaryServerInterceptor(log, interceptor.WithRequestIDGenerator(uuid.NewString))
+func WithRequestIDGenerator(generate func() string) Option {
+	return func(s *setting) {
+		s.generateRequestID = generate
+	}
+}
+
+// WithLevelFunc gRPC 상태 코드별 로그 레벨 함수를 설정하는 옵션 (default: CodeLevel)
+//
+// Example:
+//
+//	// NotFound 도 경고로 기록
+//	interceptor.UnaryServerInterceptor(log, interceptor.WithLevelFunc(func(code codes.Code) types.LogLevel {
+//		if code == codes.NotFound {
+//			return types.Warn
+//		}
+//		return interceptor.CodeLevel(code)
+//	}))
+func WithLevelFunc(levelFunc func(codes.Code) types.LogLevel) Option {
+	return func(s *setting) {
+		s.levelFunc = levelFunc
+	}
+}
+
+// WithMessage 호출 로그 메시지를 설정하는 옵션 (default: 서버 "grpc request", 클라이언트 "grpc call")
+func WithMessage(message string) Option {
+	return func(s *setting) {
+		s.message = message
+	}
+}
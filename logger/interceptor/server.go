@@ -0,0 +1,111 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/wjddn3711/structured-logger/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// UnaryServerInterceptor : 요청 단위 로거를 등록하고 호출 로그를 기록하는 unary 서버 인터셉터
+//   - base(logger.Logger): 요청마다 복사하여 사용할 기반 로거
+//   - opts(...Option): 인터셉터 설정 옵션
+//
+// 수신 메타데이터의 요청 ID 를 사용하고 없으면 생성하여, 공통 필드와 응답 헤더에 등록한다.
+// 값이 128자를 넘거나 영문자, 숫자, '-', '_', '.', ':' 외의 문자를 포함하면 신뢰하지 않고 새로 생성한다 (logger.ValidRequestID).
+//
+// Example:
+//
+//	func (s *server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+//		// 요청 ID 가 공통 필드로 등록된 로거
+//		log := logger.FromContext(ctx, types.ZeroLog)
+//		log.Info(options.WithMessage("get user"))
+//		...
+//	}
+//	// output: {"level":"info","rid":"...","grpc_service":"user.UserService","grpc_method":"GetUser","grpc_code":"OK","elapsed":3,"peer":"...","message":"grpc request"}
+func UnaryServerInterceptor(base logger.Logger, opts ...Option) grpc.UnaryServerInterceptor {
+	s := newSetting(opts, "grpc request")
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		requestID := s.incomingRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(s.requestIDKey, requestID))
+
+		c := newCall(base, s, requestID, info.FullMethod, false)
+		ctx = serverContext(ctx, c, requestID)
+
+		resp, err := handler(ctx, req)
+		c.finish(s, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor : 요청 단위 로거를 등록하고 호출 로그를 기록하는 stream 서버 인터셉터
+//   - base(logger.Logger): 요청마다 복사하여 사용할 기반 로거
+//   - opts(...Option): 인터셉터 설정 옵션
+//
+// 호출 로그에는 스트림에서 주고받은 메시지 수가 함께 기록된다.
+func StreamServerInterceptor(base logger.Logger, opts ...Option) grpc.StreamServerInterceptor {
+	s := newSetting(opts, "grpc request")
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		requestID := s.incomingRequestID(ctx)
+		_ = ss.SetHeader(metadata.Pairs(s.requestIDKey, requestID))
+
+		c := newCall(base, s, requestID, info.FullMethod, true)
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: serverContext(ctx, c, requestID), call: c})
+		c.finish(s, err)
+		return err
+	}
+}
+
+// incomingRequestID : 수신 메타데이터의 요청 ID 를 반환하고, 없거나 신뢰할 수 없으면 생성하는 메서드
+//
+// HTTPMiddleware 와 같이 logger.ValidRequestID 를 통과한 값만 그대로 사용한다.
+func (s setting) incomingRequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if requestID := firstValue(md.Get(s.requestIDKey)); logger.ValidRequestID(requestID) {
+		return requestID
+	}
+	return s.requestID()
+}
+
+// serverContext : 요청 단위 로거와 요청 ID 를 등록한 컨텍스트를 반환하는 함수
+func serverContext(ctx context.Context, c *call, requestID string) context.Context {
+	if p, ok := peer.FromContext(ctx); ok {
+		c.peer = p.Addr
+	}
+	return c.log.WithContext(logger.WithRequestID(ctx, requestID))
+}
+
+// serverStream : 요청 단위 컨텍스트를 제공하고 메시지 수를 세는 grpc.ServerStream 래퍼
+type serverStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	call *call
+}
+
+// Context : 요청 단위 로거가 등록된 컨텍스트를 반환하는 메서드
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg : 보낸 메시지 수를 세는 메서드
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.call.sent.Add(1)
+	}
+	return err
+}
+
+// RecvMsg : 받은 메시지 수를 세는 메서드
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.call.received.Add(1)
+	}
+	return err
+}
@@ -3,6 +3,7 @@ package logger
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/wjddn3711/structured-logger/logger/options"
 	"github.com/wjddn3711/structured-logger/logger/types"
@@ -23,6 +24,10 @@ type Wrapper struct {
 //   - loggerType(types.LoggerType): 로거 타입
 //   - settingOpts(...LogSettingOption): 로거 설정 옵션
 //
+// 설정 옵션 없이 호출하면, WatchConfig 로 같은 백엔드의 설정 파일을 감시하는 중인 경우
+// Watcher.Logger 처럼 다시 읽은 설정이 반영되는 로거를 반환한다.
+// 설정 옵션을 넘기면 감시 중인 설정과 관계없이 넘긴 설정으로 생성한다.
+//
 // Example:
 //
 //	// zerolog 로거 생성
@@ -35,6 +40,16 @@ func NewWrapper(
 	loggerType types.LoggerType,
 	settingOpts ...options.LogSettingOption,
 ) (logger Logger) {
+	if len(settingOpts) == 0 {
+		if watched, ok := watchedLogger(loggerType); ok {
+			return watched
+		}
+	}
+	return newWrapper(loggerType, settingOpts...)
+}
+
+// newWrapper : 설정 옵션으로 로거를 생성하는 함수 (감시 중인 설정 파일과 관계없이 생성)
+func newWrapper(loggerType types.LoggerType, settingOpts ...options.LogSettingOption) (logger Logger) {
 	settings := &options.LogSetting{
 		Level:      types.Info,            // default log level
 		TimeFormat: "2006-01-02 15:04:05", // default time format
@@ -44,6 +59,10 @@ func NewWrapper(
 	for _, opt := range settingOpts {
 		opt(settings)
 	}
+	if settings.Schema == types.ECS || settings.Schema == types.GCP {
+		// ECS 의 @timestamp, Cloud Logging 의 timestamp 는 RFC3339 형식이어야 한다.
+		settings.TimeFormat = time.RFC3339Nano
+	}
 
 	switch loggerType {
 	case types.Logrus:
@@ -52,6 +71,17 @@ func NewWrapper(
 		logger = newZerologLogger(*settings)
 	default:
 		// no op
+		return
+	}
+
+	if len(settings.Masking) > 0 {
+		logger = newMaskedLogger(logger, loggerType, settings.Masking)
+	}
+	if settings.Sampling != nil {
+		logger = newSampledLogger(logger, loggerType, *settings.Sampling)
+	}
+	if settings.Deduplication != nil {
+		logger = newDedupLogger(logger, loggerType, *settings.Deduplication)
 	}
 	return
 }
@@ -59,6 +89,7 @@ func NewWrapper(
 // FromContext : 컨텍스트에서 지정된 로거를 가져오는 메서드
 //
 // 만약 존재하지 않는 경우, 지정된 타입의 새로운 로거를 생성하여 반환
+// (설정 파일을 감시하는 중이면 다시 읽은 설정이 반영되는 로거, NewWrapper 참고)
 //
 // Example:
 //
@@ -70,21 +101,47 @@ func NewWrapper(
 //	// ---------doSomething 함수 내부---------
 //	log := logger.FromContext(types.ZeroLog, ctx)
 func FromContext(ctx context.Context, loggerType types.LoggerType) (logger Logger) {
-	var logKey types.LogContextKey
-	switch loggerType {
-	case types.Logrus:
-		logKey = types.LogrusKey
-	case types.ZeroLog:
-		logKey = types.ZerologKey
-	default:
+	if _, ok := contextKey(loggerType); !ok {
 		// no op
 		return
 	}
 
-	logger, ok := ctx.Value(logKey).(Logger)
+	logger, ok := LookupContext(ctx, loggerType)
 	if !ok {
 		return NewWrapper(loggerType)
 	}
 
 	return logger
 }
+
+// LookupContext : 컨텍스트에 등록된 로거를 가져오는 메서드
+//
+// 등록된 로거가 없으면 FromContext 와 달리 새로 생성하지 않고 false 를 반환
+//
+// Example:
+//
+//	log, ok := logger.LookupContext(ctx, types.ZeroLog)
+//	if !ok {
+//		log = defaultLog
+//	}
+func LookupContext(ctx context.Context, loggerType types.LoggerType) (Logger, bool) {
+	logKey, ok := contextKey(loggerType)
+	if !ok {
+		return nil, false
+	}
+
+	logger, ok := ctx.Value(logKey).(Logger)
+	return logger, ok
+}
+
+// contextKey : 로거 타입에 해당하는 컨텍스트 키를 반환하는 함수
+func contextKey(loggerType types.LoggerType) (types.LogContextKey, bool) {
+	switch loggerType {
+	case types.Logrus:
+		return types.LogrusKey, true
+	case types.ZeroLog:
+		return types.ZerologKey, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// logfmtLeadingKeys : logfmt 출력에서 가장 앞에 기록하는 키 (나머지 키는 이름순, ECS, GCP 스키마의 키 포함)
+var logfmtLeadingKeys = []string{types.TimeField, "@timestamp", "timestamp", types.LevelField, "log.level", "severity", types.MessageField}
+
+// encodeLogfmt : 필드를 "key=value" 형태의 logfmt 한 줄로 인코딩하는 함수
+//
+// 시간, 레벨, 메시지를 먼저 기록하고 나머지 키는 이름순으로 기록한다.
+// 중첩된 맵은 점(.)으로 연결한 키로 펼친다 (예: {"http":{"status":200}} → http.status=200).
+func encodeLogfmt(buf *bytes.Buffer, fields map[string]interface{}) {
+	flat := make(map[string]interface{}, len(fields))
+	flattenFields(flat, "", fields)
+
+	keys := make([]string, 0, len(flat))
+	for _, key := range logfmtLeadingKeys {
+		if _, ok := flat[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	leading := len(keys)
+	for key := range flat {
+		if !isLogfmtLeadingKey(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys[leading:])
+
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		writeLogfmtKey(buf, key)
+		buf.WriteByte('=')
+		writeLogfmtValue(buf, flat[key])
+	}
+	buf.WriteByte('\n')
+}
+
+// flattenFields : 중첩된 맵을 점(.)으로 연결한 키로 펼치는 함수
+func flattenFields(dst map[string]interface{}, prefix string, fields map[string]interface{}) {
+	for key, value := range fields {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenFields(dst, key, nested)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+func isLogfmtLeadingKey(key string) bool {
+	for _, leading := range logfmtLeadingKeys {
+		if key == leading {
+			return true
+		}
+	}
+	return false
+}
+
+// writeLogfmtKey : 키에서 logfmt 구분 문자(공백, =, ")와 제어 문자를 _ 로 바꿔 기록하는 함수
+func writeLogfmtKey(buf *bytes.Buffer, key string) {
+	if key == "" {
+		buf.WriteByte('_')
+		return
+	}
+	for _, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			buf.WriteByte('_')
+			continue
+		}
+		buf.WriteRune(r)
+	}
+}
+
+// writeLogfmtValue : 값을 logfmt 값으로 기록하는 함수
+//   - 문자열: 공백, =, ", 제어 문자가 있거나 빈 문자열인 경우 따옴표로 감싸고 이스케이프
+//   - 숫자, 불리언: 그대로
+//   - null: 빈 값
+//   - 배열, 빈 맵: JSON 문자열
+func writeLogfmtValue(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+	case string:
+		writeLogfmtString(buf, v)
+	case stdjson.Number:
+		buf.WriteString(v.String())
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			writeLogfmtString(buf, err.Error())
+			return
+		}
+		writeLogfmtString(buf, string(encoded))
+	}
+}
+
+func writeLogfmtString(buf *bytes.Buffer, s string) {
+	if s != "" && !strings.ContainsFunc(s, needsLogfmtQuote) && utf8.ValidString(s) {
+		buf.WriteString(s)
+		return
+	}
+	buf.WriteString(strconv.Quote(s))
+}
+
+func needsLogfmtQuote(r rune) bool {
+	return r <= ' ' || r == '=' || r == '"' || r == '\\' || r == 0x7f
+}
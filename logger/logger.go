@@ -2,8 +2,10 @@ package logger
 
 import (
 	"context"
+	"io"
 
 	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
 )
 
 // LogEntry 로그 엔트리 필드 타입
@@ -56,8 +58,21 @@ type Logger interface {
 	//   log.RegisterCommonFields(entry) // 공통 필드들 등록
 	//   ctx = log.WithContext(context.Background()) // 컨텍스트에 로거 등록
 	RegisterCommonFields(fields options.LogEntry)
+	// Clone : 공통 필드와 등록된 엔트리를 복사한 새 로거를 반환하는 메서드
+	//
+	// 복사된 로거에 등록한 공통 필드나 엔트리는 원래 로거에 영향을 주지 않는다.
+	//
+	// Example:
+	//   // 요청마다 독립된 공통 필드를 가지는 로거 생성
+	//   reqLog := log.Clone()
+	//   reqLog.RegisterCommonField("rid", reqID)
+	Clone() Logger
 	// ApplyOption : 로그 엔트리 옵션을 적용하는 메서드
 	//   - opts([]EntryOption): 로그 엔트리 옵션
+	//
+	// Debug, Info 등 로그 메서드는 비활성 레벨이면 옵션을 적용하지 않고 바로 반환한다.
+	// 다만 인터페이스 메서드에 넘기는 옵션(options.WithMessage 등)과 가변 인자 배열은 컴파일러가 힙에 할당하므로 비활성 레벨에서도 할당된다.
+	// 비활성 레벨을 할당 없이 거르려면 로거의 메서드 대신 logger.Log 나 LogAttrs 를 사용한다.
 	ApplyOption([]options.EntryOption)
 	// Debug : 디버그 로그를 출력하는 메서드
 	//   - opts(...EntryOption): 로그 엔트리 옵션
@@ -125,3 +140,134 @@ type Logger interface {
 	//   log.Fatal()
 	Fatal(opts ...options.EntryOption)
 }
+
+// Log : 레벨에 해당하는 로그 메서드를 호출하는 함수
+//   - l(Logger): 로거
+//   - level(types.LogLevel): 로그 레벨 (알 수 없는 레벨은 info)
+//   - opts(...EntryOption): 로그 엔트리 옵션
+//
+// 데코레이터 없이 생성한 zerolog, logrus 로거는 로그 메서드를 인터페이스로 거치지 않고 호출하므로,
+// 비활성 레벨이면 옵션(options.WithMessage 등)과 인자 배열을 할당하지 않고 반환한다.
+// (options.Fields 맵처럼 호출하는 쪽에서 만드는 값은 비활성 레벨에서도 할당된다)
+//
+// Example:
+//
+//	// 응답 상태에 따라 레벨을 정해서 로깅
+//	logger.Log(log, types.Warn, options.WithMessage("slow request"))
+func Log(l Logger, level types.LogLevel, opts ...options.EntryOption) {
+	switch l := l.(type) {
+	case *zerologLogger:
+		l.log(l.newEvent(level), opts)
+	case *logrusLogger:
+		l.log(logrusLevel(level), opts)
+	default:
+		// opts 를 인터페이스 메서드에 그대로 넘기면 위의 경로에서도 옵션과 인자 배열이 힙에 할당되므로,
+		// 옵션을 적용한 엔트리를 다시 적용하는 옵션 하나로 바꾸어 넘긴다.
+		opt := entryOption(options.NewEntry(opts...))
+		switch level {
+		case types.Debug:
+			l.Debug(opt)
+		case types.Warn:
+			l.Warn(opt)
+		case types.Error:
+			l.Error(opt)
+		case types.Fatal:
+			l.Fatal(opt)
+		default:
+			l.Info(opt)
+		}
+	}
+}
+
+// entryOption : 옵션을 적용한 엔트리의 값을 다시 적용하는 옵션을 반환하는 함수
+//
+// 로거는 옵션을 빈 엔트리에 적용한 뒤 비어 있지 않은 값만 사용하므로, 원래 옵션들을 적용한 것과 같은 로그를 출력한다.
+func entryOption(entry *options.Entry) options.EntryOption {
+	return func(e *options.Entry) {
+		if entry.Message != "" {
+			e.Message = entry.Message
+		}
+		if entry.Fields != nil {
+			e.Fields = entry.Fields
+		}
+		e.Attrs = append(e.Attrs, entry.Attrs...)
+		if entry.Metrics != nil {
+			e.Metrics = entry.Metrics
+		}
+		if !entry.Time.IsZero() {
+			e.Time = entry.Time
+		}
+		if entry.Caller != "" {
+			e.Caller = entry.Caller
+		}
+	}
+}
+
+// LogAttrs : 레벨에 해당하는 로그를 메시지와 타입이 지정된 필드로 출력하는 함수
+//   - l(Logger): 로거
+//   - level(types.LogLevel): 로그 레벨 (알 수 없는 레벨은 info)
+//   - msg(string): 로그 메시지 (빈 문자열이면 기록하지 않음)
+//   - attrs(...options.Attr): 로그 필드
+//
+// Log(l, level, options.WithMessage(msg), options.WithAttrs(attrs...)) 와 같은 로그를 출력하지만, 메시지와 필드를 로거의 엔트리에 남기지 않는다.
+// 데코레이터 없이 생성한 zerolog, logrus 로거는 엔트리 옵션을 거치지 않고 백엔드에 바로 기록하므로,
+// 비활성 레벨은 할당 없이 반환하고 zerolog 로거는 활성 레벨도 백엔드 버퍼 외에는 할당하지 않는다.
+// (options.Object, options.Array, options.Any 는 값을 담을 배열이나 인터페이스를 할당한다)
+// 마스킹, 샘플링 등 데코레이터가 적용된 로거는 Log 를 통해 출력한다.
+//
+// Example:
+//
+//	logger.LogAttrs(log, types.Info, "paid",
+//		options.String("order_id", orderID),
+//		options.Int64("amount", amount),
+//		options.Duration(types.ElapsedField, time.Since(start)),
+//	)
+func LogAttrs(l Logger, level types.LogLevel, msg string, attrs ...options.Attr) {
+	switch l := l.(type) {
+	case *zerologLogger:
+		l.logAttrs(level, msg, attrs)
+	case *logrusLogger:
+		l.logAttrs(level, msg, attrs)
+	default:
+		// attrs 를 그대로 옵션에 넘기면 위의 경로에서도 인자 배열이 힙에 할당되므로 복사하여 넘긴다.
+		opts := []options.EntryOption{options.WithAttrs(append([]options.Attr(nil), attrs...)...)}
+		if msg != "" {
+			opts = append(opts, options.WithMessage(msg))
+		}
+		// 옵션이 로거의 엔트리에 남지 않도록 복사한 로거로 기록한다.
+		Log(l.Clone(), level, opts...)
+	}
+}
+
+// commonCloner : 공통 필드만 복사하고 엔트리는 비운 새 로거를 반환할 수 있는 로거
+//
+// 샘플링 요약처럼 라이브러리가 직접 기록하는 로그가 사용자 로그의 엔트리 필드를 물려받지 않도록 사용한다.
+type commonCloner interface {
+	cloneCommon() Logger
+}
+
+// cloneCommon : 공통 필드만 가진 새 로거를 반환하는 함수 (commonCloner 를 구현하지 않으면 Clone)
+func cloneCommon(l Logger) Logger {
+	if c, ok := l.(commonCloner); ok {
+		return c.cloneCommon()
+	}
+	return l.Clone()
+}
+
+// Close : 로거가 보관 중인 요약 로그를 기록하고 정리하는 함수
+//   - l(Logger): 로거
+//
+// 샘플링 등 주기 단위로 요약 로그를 남기는 로거는 마지막 주기의 요약을 다음 로그와 함께 기록하므로,
+// 종료 전에 Close 를 호출해야 남은 요약이 유실되지 않는다.
+// 로거가 io.Closer 를 구현하지 않으면 아무것도 하지 않는다. 출력(싱크)은 닫지 않는다.
+//
+// Example:
+//
+//	log := logger.NewWrapper(types.ZeroLog, options.WithSampling(sampling))
+//	defer logger.Close(log)
+func Close(l Logger) error {
+	if closer, ok := l.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
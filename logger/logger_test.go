@@ -3,14 +3,30 @@ package logger_test
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	stdlog "log"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ggwhite/go-masker"
 
 	jsoniter "github.com/json-iterator/go"
+	zlog "github.com/rs/zerolog/log"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/masking"
 	"github.com/wjddn3711/structured-logger/logger/options"
 	"github.com/wjddn3711/structured-logger/logger/types"
 )
@@ -186,6 +202,820 @@ func TestLogger(t *testing.T) {
 	})
 }
 
+func TestCaller(t *testing.T) {
+	for _, logType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		t.Run(string(logType)+" WithCaller 설정 시, 호출 위치가 기록되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithCaller(true),
+			)
+
+			// when
+			log.Info(options.WithMessage("info message"))
+
+			// then
+			entries := captureWriter.Map()
+			caller, _ := entries[types.CallerField].(string)
+			assert.Contains(t, caller, "logger_test.go:", "로거를 호출한 위치가 기록되어야 합니다.")
+		})
+	}
+}
+
+func TestSampling(t *testing.T) {
+	for _, logType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		t.Run(string(logType)+" 처음 N개 이후 M개마다 1개만 기록되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithSampling(options.Sampling{
+					Interval: time.Hour,
+					Levels:   map[types.LogLevel]options.SamplingPolicy{types.Info: {First: 2, Thereafter: 3}},
+				}),
+			)
+
+			// when
+			for i := 0; i < 8; i++ {
+				log.Info(options.WithMessage("hot path"))
+				log.Warn(options.WithMessage("not sampled"))
+			}
+
+			// then
+			var info, warn int
+			for _, line := range captureWriter.Lines() {
+				if line[types.MessageField] == "hot path" {
+					info++
+				} else {
+					warn++
+				}
+			}
+			assert.Equal(t, 4, info, "1, 2, 5, 8 번째 로그만 기록되어야 합니다.")
+			assert.Equal(t, 8, warn, "정책이 없는 레벨은 모두 기록되어야 합니다.")
+		})
+
+		t.Run(string(logType)+" 메시지와 키 필드 조합별로 샘플링 되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithSampling(options.Sampling{
+					Interval:  time.Hour,
+					Levels:    map[types.LogLevel]options.SamplingPolicy{types.Info: {First: 1}},
+					KeyFields: []string{"uri"},
+				}),
+			)
+
+			// when
+			log.Info(options.WithMessage("request"), options.WithFields(options.Fields{"uri": "/a"}))
+			log.Info(options.WithMessage("request"), options.WithFields(options.Fields{"uri": "/a"}))
+			log.Info(options.WithMessage("request"), options.WithFields(options.Fields{"uri": "/b"}))
+			log.Info(options.WithMessage("other"), options.WithFields(options.Fields{"uri": "/a"}))
+
+			// then
+			assert.Len(t, captureWriter.Lines(), 3, "키별로 첫 번째 로그만 기록되어야 합니다.")
+		})
+
+		t.Run(string(logType)+" 주기가 끝나면 버려진 엔트리 수가 보고되는지 테스트", func(t *testing.T) {
+			// given
+			var reported uint64
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithSampling(options.Sampling{
+					Interval: 20 * time.Millisecond,
+					Levels:   map[types.LogLevel]options.SamplingPolicy{types.Info: {First: 1}},
+					OnDropped: func(level types.LogLevel, dropped uint64) {
+						reported += dropped
+					},
+				}),
+			)
+
+			// when
+			for i := 0; i < 5; i++ {
+				log.Info(options.WithMessage("hot path"))
+			}
+			time.Sleep(30 * time.Millisecond)
+			log.Info(options.WithMessage("hot path"))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 3)
+			assert.Equal(t, "log entries sampled out", lines[1][types.MessageField])
+			assert.Equal(t, float64(4), lines[1][types.SampledOutField], "버려진 엔트리 수가 요약 로그로 기록되어야 합니다.")
+			assert.Equal(t, "info", lines[1][types.LevelField])
+			assert.Equal(t, "hot path", lines[2][types.MessageField])
+			assert.Nil(t, lines[2][types.SampledOutField], "요약 필드가 이후 로그에 남지 않아야 합니다.")
+			assert.Equal(t, uint64(4), reported, "버려진 엔트리 수가 콜백으로 전달되어야 합니다.")
+		})
+
+		t.Run(string(logType)+" 요약 로그가 공통 필드만 가지고 fatal 요약은 error 로 기록되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithSampling(options.Sampling{
+					Interval: time.Hour,
+					Levels: map[types.LogLevel]options.SamplingPolicy{
+						types.Info:  {First: 1},
+						types.Fatal: {Thereafter: 1000},
+					},
+				}),
+			)
+			log.RegisterCommonField("service", "payment")
+
+			// when
+			log.Info(options.WithMessage("request"), options.WithFields(options.Fields{"uri": "/a"}))
+			log.Info(options.WithMessage("request"), options.WithFields(options.Fields{"uri": "/a"}))
+			log.Fatal(options.WithMessage("db down"), options.WithFields(options.Fields{"uri": "/a"}))
+			require.NoError(t, logger.Close(log))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 3)
+			var levels []interface{}
+			for _, summary := range lines[1:] {
+				assert.Equal(t, "log entries sampled out", summary[types.MessageField])
+				assert.Equal(t, "payment", summary["service"], "공통 필드는 요약 로그에도 기록되어야 합니다.")
+				assert.NotContains(t, summary, "uri", "엔트리 필드가 요약 로그에 섞이지 않아야 합니다.")
+				levels = append(levels, summary[types.LevelField])
+			}
+			assert.ElementsMatch(t, []interface{}{"info", "error"}, levels, "fatal 요약은 종료하지 않도록 error 로 기록되어야 합니다.")
+		})
+
+		t.Run(string(logType)+" Close 하면 주기가 끝나지 않아도 버려진 엔트리 수가 보고되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithSampling(options.Sampling{
+					Interval: time.Hour,
+					Levels:   map[types.LogLevel]options.SamplingPolicy{types.Info: {First: 1}},
+				}),
+			)
+			for i := 0; i < 5; i++ {
+				log.Clone().Info(options.WithMessage("hot path"))
+			}
+
+			// when
+			require.NoError(t, logger.Close(log))
+			require.NoError(t, logger.Close(log))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 2, "요약 로그는 한 번만 기록되어야 합니다.")
+			assert.Equal(t, "log entries sampled out", lines[1][types.MessageField])
+			assert.Equal(t, float64(4), lines[1][types.SampledOutField])
+		})
+
+		t.Run(string(logType)+" 확률 샘플링 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithSampling(options.Sampling{
+					Interval: time.Hour,
+					Levels:   map[types.LogLevel]options.SamplingPolicy{types.Info: {Rate: 0.5}},
+				}),
+			)
+
+			// when
+			for i := 0; i < 1000; i++ {
+				log.Info(options.WithMessage("random"))
+			}
+
+			// then
+			n := len(captureWriter.Lines())
+			assert.True(t, n > 350 && n < 650, "약 50%%의 로그만 기록되어야 합니다. (기록된 로그: %d)", n)
+		})
+	}
+}
+
+func TestDeduplication(t *testing.T) {
+	for _, logType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		t.Run(string(logType)+" 반복되는 로그는 첫 로그와 요약 로그만 기록되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithDeduplication(options.Deduplication{
+					Levels: map[types.LogLevel]options.DedupPolicy{types.Error: {Window: 50 * time.Millisecond}},
+				}),
+			)
+
+			// when
+			for i := 0; i < 5; i++ {
+				log.Error(options.WithMessage("db timeout"), options.WithFields(options.Fields{"db": "main"}))
+			}
+			log.Info(options.WithMessage("not limited"))
+			log.Info(options.WithMessage("not limited"))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 3, "첫 로그는 바로 기록되고, 이후 로그는 억제되어야 합니다.")
+			assert.Equal(t, "db timeout", lines[0][types.MessageField])
+			assert.Nil(t, lines[0][types.RepeatCountField])
+
+			require.Eventually(t, func() bool { return len(captureWriter.Lines()) == 4 }, time.Second, 5*time.Millisecond,
+				"억제 기간이 끝나면 요약 로그가 기록되어야 합니다.")
+			summary := captureWriter.Lines()[3]
+			assert.Equal(t, "db timeout", summary[types.MessageField])
+			assert.Equal(t, "error", summary[types.LevelField])
+			assert.Equal(t, "main", summary["db"], "요약 로그에 원래 로그의 필드가 기록되어야 합니다.")
+			assert.Equal(t, float64(4), summary[types.RepeatCountField])
+			assert.NotEmpty(t, summary[types.FirstSeenField])
+			assert.NotEmpty(t, summary[types.LastSeenField])
+		})
+
+		t.Run(string(logType)+" Burst 만큼 기록되고 키 필드가 다르면 따로 억제되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithDeduplication(options.Deduplication{
+					Levels:    map[types.LogLevel]options.DedupPolicy{types.Warn: {Window: time.Hour, Burst: 2}},
+					KeyFields: []string{"db"},
+				}),
+			)
+
+			// when
+			for i := 0; i < 3; i++ {
+				log.Warn(options.WithMessage("slow"), options.WithFields(options.Fields{"db": "main"}))
+				log.Warn(options.WithMessage("slow"), options.WithFields(options.Fields{"db": "replica"}))
+			}
+
+			// then
+			assert.Len(t, captureWriter.Lines(), 4, "키별로 Burst 개까지만 기록되어야 합니다.")
+		})
+
+		t.Run(string(logType)+" Close 하면 억제 기간이 끝나지 않아도 요약 로그가 기록되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(
+				logType,
+				options.WithOutput(captureWriter),
+				options.WithDeduplication(options.Deduplication{
+					Levels: map[types.LogLevel]options.DedupPolicy{types.Error: {Window: time.Hour}},
+				}),
+				options.WithSampling(options.Sampling{
+					Interval: time.Hour,
+					Levels:   map[types.LogLevel]options.SamplingPolicy{types.Info: {First: 1}},
+				}),
+			)
+			for i := 0; i < 3; i++ {
+				log.Clone().Error(options.WithMessage("db timeout"))
+				log.Clone().Info(options.WithMessage("hot path"))
+			}
+
+			// when
+			require.NoError(t, logger.Close(log))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 4)
+			assert.Equal(t, "db timeout", lines[2][types.MessageField])
+			assert.Equal(t, float64(2), lines[2][types.RepeatCountField], "억제된 로그의 요약 로그가 기록되어야 합니다.")
+			assert.Equal(t, float64(2), lines[3][types.SampledOutField], "감싼 샘플링 로거도 닫혀야 합니다.")
+		})
+	}
+}
+
+func TestBufferedLogger(t *testing.T) {
+	for _, logType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		t.Run(string(logType)+" 에러가 기록되면 보관된 로그가 순서대로 출력되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			base := logger.NewWrapper(logType, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+			buf := logger.NewBufferedLogger(base, logType, options.Buffering{Trigger: types.Error})
+			defer buf.Close()
+			ctx := buf.WithContext(context.Background())
+
+			// when
+			log := logger.FromContext(ctx, logType)
+			log.Debug(options.WithMessage("step 1"))
+			log.Info(options.WithMessage("step 2"))
+			assert.Empty(t, captureWriter.Lines(), "Trigger 전에는 로그가 보관되어야 합니다.")
+			log.Error(options.WithMessage("failed"))
+			log.Debug(options.WithMessage("after"))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 4)
+			assert.Equal(t, "step 1", lines[0][types.MessageField])
+			assert.Equal(t, "debug", lines[0][types.LevelField], "보관된 로그는 원래 레벨로 출력되어야 합니다.")
+			assert.Equal(t, "step 2", lines[1][types.MessageField])
+			assert.Equal(t, "failed", lines[2][types.MessageField])
+			assert.Equal(t, "after", lines[3][types.MessageField], "Trigger 이후 로그는 바로 기록되어야 합니다.")
+		})
+
+		t.Run(string(logType)+" 보관된 로그가 원래 시각과 호출 위치로 출력되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			base := logger.NewWrapper(logType,
+				options.WithLevel(types.Debug),
+				options.WithOutput(captureWriter),
+				options.WithTimeFormat(time.RFC3339Nano),
+				options.WithCaller(true),
+			)
+			buf := logger.NewBufferedLogger(base, logType, options.Buffering{Trigger: types.Error})
+			defer buf.Close()
+
+			// when
+			buf.Debug(options.WithMessage("step 1"))
+			time.Sleep(20 * time.Millisecond)
+			buf.Error(options.WithMessage("failed"))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 2)
+			buffered, err := time.Parse(time.RFC3339Nano, lines[0][types.TimeField].(string))
+			require.NoError(t, err)
+			trigger, err := time.Parse(time.RFC3339Nano, lines[1][types.TimeField].(string))
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, trigger.Sub(buffered), 20*time.Millisecond, "보관된 로그는 호출한 시각으로 기록되어야 합니다.")
+			assert.Contains(t, lines[0][types.CallerField], "logger_test.go")
+			assert.NotEqual(t, lines[1][types.CallerField], lines[0][types.CallerField], "보관된 로그는 호출한 위치로 기록되어야 합니다.")
+		})
+
+		t.Run(string(logType)+" 스코프가 끝난 뒤의 로그는 바로 기록되는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			base := logger.NewWrapper(logType, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+			buf := logger.NewBufferedLogger(base, logType, options.Buffering{Trigger: types.Error})
+			buf.Close()
+
+			// when
+			buf.Debug(options.WithMessage("late"))
+
+			// then
+			require.Len(t, captureWriter.Lines(), 1)
+			assert.Equal(t, "late", captureWriter.Map()[types.MessageField])
+		})
+
+		t.Run(string(logType)+" 에러 없이 스코프가 끝나면 보관된 로그가 버려지는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			base := logger.NewWrapper(logType, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+			buf := logger.NewBufferedLogger(base, logType, options.Buffering{
+				Trigger:     types.Error,
+				PassThrough: types.Warn,
+				MaxEntries:  2,
+			})
+
+			// when
+			buf.Debug(options.WithMessage("1"))
+			buf.Debug(options.WithMessage("2"))
+			buf.Debug(options.WithMessage("3"))
+			buf.Warn(options.WithMessage("warn"))
+			overflowed := buf.Dropped()
+			err := buf.Close()
+
+			// then
+			require.NoError(t, err)
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 1, "PassThrough 레벨 이상의 로그만 기록되어야 합니다.")
+			assert.Equal(t, "warn", lines[0][types.MessageField])
+			assert.Equal(t, uint64(1), overflowed, "MaxEntries 를 넘은 로그가 버려져야 합니다.")
+			assert.Equal(t, uint64(3), buf.Dropped(), "버퍼 초과분과 남은 로그가 버려져야 합니다.")
+		})
+	}
+
+	t.Run("버퍼링 로거의 공통 필드가 기반 로거에 남지 않는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		base := logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+		buf := logger.NewBufferedLogger(base, types.ZeroLog, options.Buffering{})
+
+		// when
+		buf.RegisterCommonField("rid", "1234")
+		buf.Error(options.WithMessage("request"))
+		base.Info(options.WithMessage("base"))
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 2)
+		assert.Equal(t, "1234", lines[0]["rid"])
+		assert.Nil(t, lines[1]["rid"], "기반 로거에는 공통 필드가 등록되지 않아야 합니다.")
+	})
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	t.Run("요청 ID 가 핸들러 로그와 액세스 로그에 함께 기록되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		base := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter))
+		handler := logger.HTTPMiddleware(base)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger.FromContext(r.Context(), types.ZeroLog).Info(options.WithMessage("handling"))
+			assert.Equal(t, "req-1", logger.RequestIDFromContext(r.Context()))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("hello"))
+		}))
+		req := httptest.NewRequest(http.MethodPost, "/users?page=1", nil)
+		req.Header.Set("X-Request-ID", "req-1")
+		req.Header.Set("User-Agent", "test-agent")
+		rec := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(rec, req)
+		base.Info(options.WithMessage("base"))
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 3)
+		assert.Equal(t, "req-1", rec.Header().Get("X-Request-ID"), "요청 ID 가 응답 헤더로 전달되어야 합니다.")
+		assert.Equal(t, "req-1", lines[0][types.RequestIDField], "핸들러 로그에 요청 ID 가 기록되어야 합니다.")
+
+		access := lines[1]
+		assert.Equal(t, "req-1", access[types.RequestIDField])
+		assert.Equal(t, "http request", access[types.MessageField])
+		assert.Equal(t, "info", access[types.LevelField])
+		assert.Equal(t, float64(http.StatusCreated), access[types.StatusCodeField])
+		assert.Equal(t, float64(5), access[types.BytesWrittenField])
+		assert.Equal(t, "POST", access[types.MethodField])
+		assert.Equal(t, "/users?page=1", access[types.URIField])
+		assert.Equal(t, "test-agent", access[types.UserAgentField])
+		assert.Contains(t, access, types.ElapsedField)
+		assert.Contains(t, access, types.StartTimeField)
+		assert.Contains(t, access, types.EndTimeField)
+
+		assert.Nil(t, lines[2][types.RequestIDField], "기반 로거에는 요청 ID 가 등록되지 않아야 합니다.")
+	})
+
+	t.Run("허용되지 않는 요청 ID 헤더는 새로 생성한 ID 로 대체되는지 테스트", func(t *testing.T) {
+		for name, requestID := range map[string]string{
+			"너무 긴 ID":    strings.Repeat("a", 129),
+			"개행 문자":      "req-1\nforged=1",
+			"허용되지 않는 문자": "req 1;<script>",
+		} {
+			t.Run(name, func(t *testing.T) {
+				// given
+				captureWriter := &captureWriter{}
+				base := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter))
+				handler := logger.HTTPMiddleware(base,
+					options.WithRequestIDGenerator(func() string { return "generated" }),
+				)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("X-Request-ID", requestID)
+				rec := httptest.NewRecorder()
+
+				// when
+				handler.ServeHTTP(rec, req)
+
+				// then
+				assert.Equal(t, "generated", rec.Header().Get("X-Request-ID"))
+				assert.Equal(t, "generated", captureWriter.Map()[types.RequestIDField])
+			})
+		}
+	})
+
+	t.Run("요청 ID 생성, 필드 이름 변경, 쿼리 마스킹 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		base := logger.NewWrapper(types.Logrus, options.WithOutput(captureWriter))
+		handler := logger.HTTPMiddleware(base,
+			options.WithRequestIDGenerator(func() string { return "generated" }),
+			options.WithAccessLogFields(options.AccessLogFields{StatusCode: "status", Referer: "-"}),
+			options.WithQueryMasking(masking.Rules{"token": masking.Password}),
+		)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/login?user=kim&token=secret", nil)
+		rec := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(rec, req)
+
+		// then
+		access := captureWriter.Map()
+		assert.Equal(t, "generated", rec.Header().Get("X-Request-ID"))
+		assert.Equal(t, "generated", access[types.RequestIDField])
+		assert.Equal(t, "error", access[types.LevelField], "5xx 응답은 error 레벨로 기록되어야 합니다.")
+		assert.Equal(t, float64(http.StatusInternalServerError), access["status"])
+		assert.NotContains(t, access, types.StatusCodeField)
+		assert.NotContains(t, access, types.RefererField, "\"-\" 로 지정한 필드는 기록되지 않아야 합니다.")
+		assert.Equal(t, "/login?user=kim&token=************", access[types.URIField], "지정한 쿼리 파라미터가 마스킹되어야 합니다.")
+	})
+
+	t.Run("응답 래퍼가 Flusher 를 유지하는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		base := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter))
+		var flushed bool
+		handler := logger.HTTPMiddleware(base)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			flusher, ok := w.(http.Flusher)
+			require.True(t, ok, "Flusher 가 유지되어야 합니다.")
+			_, hijackable := w.(http.Hijacker)
+			assert.False(t, hijackable, "원래 지원하지 않는 Hijacker 는 노출되지 않아야 합니다.")
+			flusher.Flush()
+			flushed = true
+		}))
+		rec := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+		// then
+		assert.True(t, flushed)
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, float64(http.StatusOK), captureWriter.Map()[types.StatusCodeField])
+	})
+}
+
+func TestRoundTripper(t *testing.T) {
+	t.Run("요청 컨텍스트의 로거로 호출 로그가 기록되고 요청 ID 가 전달되는지 테스트", func(t *testing.T) {
+		// given
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Header.Get("X-Request-ID"))
+			if len(received) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter))
+		log.RegisterCommonField(types.RequestIDField, "req-1")
+		ctx := logger.WithRetryAttempts(log.WithContext(logger.WithRequestID(context.Background(), "req-1")))
+		client := &http.Client{Transport: logger.NewRoundTripper(nil, types.ZeroLog)}
+
+		// when
+		for i := 0; i < 2; i++ {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v1/orders?id=1", nil)
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Empty(t, req.Header.Get("X-Request-ID"), "원래 요청은 변경되지 않아야 합니다.")
+		}
+
+		// then
+		assert.Equal(t, []string{"req-1", "req-1"}, received, "요청 ID 가 헤더로 전달되어야 합니다.")
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 2)
+		assert.Equal(t, "http call", lines[0][types.MessageField])
+		assert.Equal(t, "error", lines[0][types.LevelField], "5xx 응답은 error 레벨로 기록되어야 합니다.")
+		assert.Equal(t, "req-1", lines[0][types.RequestIDField], "컨텍스트 로거의 공통 필드가 기록되어야 합니다.")
+		assert.Equal(t, "GET", lines[0][types.MethodField])
+		assert.Equal(t, server.Listener.Addr().String(), lines[0][types.HostField])
+		assert.Equal(t, "/v1/orders", lines[0][types.PathField])
+		assert.Equal(t, float64(http.StatusServiceUnavailable), lines[0][types.StatusCodeField])
+		assert.Equal(t, float64(1), lines[0][types.AttemptField])
+		assert.Contains(t, lines[0], types.ElapsedField)
+		assert.Equal(t, "info", lines[1][types.LevelField])
+		assert.Equal(t, float64(2), lines[1][types.AttemptField], "같은 컨텍스트의 재시도는 시도 횟수가 증가해야 합니다.")
+	})
+
+	t.Run("본문이 마스킹되어 기록되고 민감한 헤더가 제외되는지 테스트", func(t *testing.T) {
+		// given
+		var requestBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestBody, _ = io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Set-Cookie", "session=secret")
+			_, _ = w.Write([]byte(`{"name":"kim","phone":"01012345678"}`))
+		}))
+		defer server.Close()
+
+		captureWriter := &captureWriter{}
+		ctx := logger.NewWrapper(types.Logrus, options.WithOutput(captureWriter)).WithContext(context.Background())
+		client := &http.Client{Transport: logger.NewRoundTripper(nil, types.Logrus,
+			options.WithBodyCapture(1024, masking.Rules{"password": masking.Password, "phone": masking.Mobile}),
+			options.WithHeaderCapture("X-Api-Key"),
+		)}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/login",
+			io.NopCloser(strings.NewReader(`{"user":"kim","password":"p@ss"}`)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("X-Api-Key", "key")
+		req.Header.Set("X-Trace", "trace")
+
+		// when
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		responseBody, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		// then
+		assert.Equal(t, `{"user":"kim","password":"p@ss"}`, string(requestBody), "요청 본문은 그대로 전송되어야 합니다.")
+		assert.Equal(t, `{"name":"kim","phone":"01012345678"}`, string(responseBody), "응답 본문은 그대로 읽을 수 있어야 합니다.")
+
+		call := captureWriter.Map()
+		assert.Equal(t, `{"password":"************","user":"kim"}`, call[types.RequestBodyField])
+		assert.Equal(t, `{"name":"kim","phone":"0101***5678"}`, call[types.ResponseBodyField])
+		requestHeaders := call[types.RequestHeadersField].(map[string]interface{})
+		assert.Equal(t, "trace", requestHeaders["X-Trace"])
+		assert.NotContains(t, requestHeaders, "Authorization", "기본 제외 헤더는 기록되지 않아야 합니다.")
+		assert.NotContains(t, requestHeaders, "X-Api-Key", "지정한 제외 헤더는 기록되지 않아야 합니다.")
+		assert.NotContains(t, call[types.ResponseHeadersField], "Set-Cookie")
+	})
+
+	t.Run("응답 본문을 읽는 만큼만 복사하고 본문을 닫을 때 기록하는지 테스트", func(t *testing.T) {
+		// given
+		body := strings.Repeat("0123456789", 100)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter))
+		ctx := log.WithContext(context.Background())
+		client := &http.Client{Transport: logger.NewRoundTripper(nil, types.ZeroLog, options.WithBodyCapture(8, nil))}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		// when
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		before := len(captureWriter.Lines())
+		responseBody, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		log.Info(options.WithMessage("next"))
+
+		// then
+		assert.Zero(t, before, "본문을 다 읽기 전에는 기록되지 않아야 합니다.")
+		assert.Equal(t, body, string(responseBody), "응답 본문은 그대로 읽을 수 있어야 합니다.")
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 2, "본문을 끝까지 읽고 닫아도 한 번만 기록되어야 합니다.")
+		assert.Equal(t, "01234567...", lines[0][types.ResponseBodyField])
+		assert.NotContains(t, lines[1], types.ResponseBodyField, "호출 로그의 필드는 요청 로거에 남지 않아야 합니다.")
+	})
+
+	t.Run("전송 에러가 error 레벨로 기록되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		ctx := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter)).WithContext(context.Background())
+		client := &http.Client{Transport: logger.NewRoundTripper(nil, types.ZeroLog)}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:1/", nil)
+		require.NoError(t, err)
+
+		// when
+		_, err = client.Do(req)
+
+		// then
+		require.Error(t, err)
+		call := captureWriter.Map()
+		assert.Equal(t, "error", call[types.LevelField])
+		assert.NotEmpty(t, call[types.ErrorField])
+		assert.NotContains(t, call, types.StatusCodeField)
+	})
+}
+
+func TestRecover(t *testing.T) {
+	t.Run("패닉이 컨텍스트 로거의 공통 필드와 함께 기록되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter))
+		log.RegisterCommonField(types.RequestIDField, "req-1")
+		ctx := log.WithContext(context.Background())
+
+		// when
+		func() {
+			defer logger.Recover(ctx, types.ZeroLog)
+			var items []int
+			_ = items[3]
+		}()
+		log.Info(options.WithMessage("after"))
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 2)
+		assert.Equal(t, "panic recovered", lines[0][types.MessageField])
+		assert.Equal(t, "error", lines[0][types.LevelField])
+		assert.Equal(t, "req-1", lines[0][types.RequestIDField])
+		assert.Contains(t, lines[0][types.PanicField], "index out of range")
+		assert.Contains(t, lines[0][types.StackField], "TestRecover", "패닉이 발생한 위치의 스택이 기록되어야 합니다.")
+		assert.NotContains(t, lines[1], types.PanicField, "크래시 필드가 컨텍스트 로거에 남지 않아야 합니다.")
+	})
+
+	t.Run("WithRePanic 설정 시 로그를 남긴 뒤 다시 패닉이 발생하는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		ctx := logger.NewWrapper(types.Logrus, options.WithOutput(captureWriter)).WithContext(context.Background())
+
+		// when
+		panicFunc := func() {
+			defer logger.Recover(ctx, types.Logrus, options.WithRePanic(true), options.WithRecoverLevel(types.Warn))
+			panic("boom")
+		}
+
+		// then
+		assert.PanicsWithValue(t, "boom", panicFunc)
+		crash := captureWriter.Map()
+		assert.Equal(t, "warning", crash[types.LevelField])
+		assert.Equal(t, "boom", crash[types.PanicField])
+	})
+
+	t.Run("Go 로 실행한 고루틴의 패닉이 기록되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter))
+		log.RegisterCommonField(types.RequestIDField, "req-2")
+		ctx := log.WithContext(context.Background())
+
+		// when
+		logger.Go(ctx, types.ZeroLog, func(ctx context.Context) {
+			panic(errors.New("worker failed"))
+		}, options.WithRecoverMessage("worker panic"))
+
+		// then
+		require.Eventually(t, func() bool { return len(captureWriter.Lines()) == 1 }, time.Second, 5*time.Millisecond)
+		crash := captureWriter.Map()
+		assert.Equal(t, "worker panic", crash[types.MessageField])
+		assert.Equal(t, "req-2", crash[types.RequestIDField])
+		assert.Equal(t, "worker failed", crash[types.PanicField])
+	})
+}
+
+func TestRedirect(t *testing.T) {
+	t.Run("표준 log 패키지 출력이 레벨과 함께 전달되고 복구되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+		log.RegisterCommonField("service", "payment")
+		restore := logger.RedirectStdLog(log)
+
+		// when
+		stdlog.Printf("[WARN] retrying %s", "partner")
+		stdlog.Print("error: connection refused")
+		stdlog.Print("plain message")
+		restore()
+		stdlog.SetOutput(io.Discard)
+		defer stdlog.SetOutput(os.Stderr)
+		stdlog.Print("after restore")
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 3, "restore 이후 로그는 전달되지 않아야 합니다.")
+		assert.Equal(t, "warn", lines[0][types.LevelField])
+		assert.Equal(t, "retrying partner", lines[0][types.MessageField])
+		assert.Equal(t, "payment", lines[0]["service"], "공통 필드가 함께 기록되어야 합니다.")
+		assert.Equal(t, "error", lines[1][types.LevelField])
+		assert.Equal(t, "connection refused", lines[1][types.MessageField])
+		assert.Equal(t, "info", lines[2][types.LevelField])
+		assert.Equal(t, stdlog.LstdFlags, stdlog.Flags(), "플래그가 복구되어야 합니다.")
+	})
+
+	t.Run("logrus 표준 로거 출력이 레벨, 필드와 함께 전달되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.Logrus, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+		restore := logger.RedirectLogrus(log)
+
+		// when
+		logrus.WithField("user", "kim").Warn("login failed")
+		logrus.WithError(errors.New("timeout")).Debug("retry")
+		logrus.Trace("trace")
+		restore()
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 3)
+		assert.Equal(t, "warning", lines[0][types.LevelField])
+		assert.Equal(t, "login failed", lines[0][types.MessageField])
+		assert.Equal(t, "kim", lines[0]["user"])
+		assert.Nil(t, lines[1]["user"], "이전 로그의 필드가 남지 않아야 합니다.")
+		assert.Equal(t, "timeout", lines[1][logrus.ErrorKey])
+		assert.Equal(t, "debug", lines[2][types.LevelField], "trace 는 debug 로 기록되어야 합니다.")
+		assert.Equal(t, logrus.InfoLevel, logrus.GetLevel(), "레벨이 복구되어야 합니다.")
+		assert.Equal(t, os.Stderr, logrus.StandardLogger().Out, "출력이 복구되어야 합니다.")
+	})
+
+	t.Run("zerolog 전역 로거 출력이 레벨, 필드와 함께 전달되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+		restore := logger.RedirectZerolog(log)
+
+		// when
+		zlog.Error().Str("job", "sync").Int("count", 3).Msg("failed")
+		zlog.Info().Msg("done")
+		restore()
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 2)
+		assert.Equal(t, "error", lines[0][types.LevelField])
+		assert.Equal(t, "failed", lines[0][types.MessageField])
+		assert.Equal(t, "sync", lines[0]["job"])
+		assert.Equal(t, float64(3), lines[0]["count"])
+		assert.Equal(t, "info", lines[1][types.LevelField])
+		assert.Nil(t, lines[1]["job"])
+	})
+}
+
 type Example struct {
 	StartTime   string `json:"start_time,omitempty"`
 	EndTime     string `json:"end_time,omitempty"`
@@ -194,14 +1024,1219 @@ type Example struct {
 	PhoneNumber string `json:"phone_number,omitempty" mask:"mobile"`
 }
 
-func (e Example) ToFields() map[string]interface{} {
-	entryMap := make(map[string]interface{})
-	// masker.Struct(e)로 구조체 필드에 마스킹 처리
-	t, _ := masker.Struct(e)
-	entryBytes, _ := json.Marshal(t)
-	_ = json.Unmarshal(entryBytes, &entryMap)
+func (e Example) ToFields() map[string]interface{} {
+	entryMap := make(map[string]interface{})
+	// masker.Struct(e)로 구조체 필드에 마스킹 처리
+	t, _ := masker.Struct(e)
+	entryBytes, _ := json.Marshal(t)
+	_ = json.Unmarshal(entryBytes, &entryMap)
+
+	return entryMap
+}
+
+func TestConfig(t *testing.T) {
+	t.Run("YAML 설정 파일로 로거를 생성하는지 테스트", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		logPath := filepath.Join(dir, "app.log")
+		configPath := filepath.Join(dir, "logging.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(`
+backend: logrus
+level: debug
+outputs:
+  - type: file
+    path: `+logPath+`
+sampling:
+  interval: 1s
+  levels:
+    info: {first: 1, thereafter: 0}
+masking:
+  phone: mobile
+`), 0o644))
+
+		// when
+		cfg, err := logger.LoadConfig(configPath)
+		require.NoError(t, err)
+		log, closer, err := logger.NewFromConfig(cfg)
+		require.NoError(t, err)
+		defer closer.Close()
+		log.Clone().Debug(options.WithMessage("debug message"), options.WithFields(options.Fields{"phone": "01012345678"}))
+		log.Clone().Info(options.WithMessage("sampled"))
+		log.Clone().Info(options.WithMessage("sampled"))
+
+		// then
+		assert.Equal(t, types.Logrus, cfg.Backend)
+		assert.Equal(t, time.Second, time.Duration(cfg.Sampling.Interval))
+		lines := readLines(t, logPath)
+		require.Len(t, lines, 2, "샘플링 설정이 적용되어야 합니다.")
+		assert.Equal(t, "debug message", lines[0][types.MessageField])
+		assert.Equal(t, masker.Mobile("01012345678"), lines[0]["phone"], "마스킹 규칙이 적용되어야 합니다.")
+		assert.Equal(t, "sampled", lines[1][types.MessageField])
+	})
+
+	t.Run("JSON 설정 파일과 환경 변수 덮어쓰기가 적용되는지 테스트", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		logPath := filepath.Join(dir, "app.log")
+		configPath := filepath.Join(dir, "logging.json")
+		require.NoError(t, os.WriteFile(configPath, []byte(`{"backend":"zerolog","level":"error","outputs":[{"type":"stdout"}]}`), 0o644))
+		t.Setenv("LOG_LEVEL", "warn")
+		t.Setenv("LOG_OUTPUT", "file:"+logPath)
+
+		// when
+		cfg, err := logger.LoadConfig(configPath)
+		require.NoError(t, err)
+		log, closer, err := logger.NewFromConfig(cfg)
+		require.NoError(t, err)
+		defer closer.Close()
+		log.Clone().Info(options.WithMessage("info message"))
+		log.Clone().Warn(options.WithMessage("warn message"))
+
+		// then
+		assert.Equal(t, types.Warn, cfg.Level)
+		require.Equal(t, []logger.OutputConfig{{Type: "file", Path: logPath}}, cfg.Outputs)
+		lines := readLines(t, logPath)
+		require.Len(t, lines, 1, "환경 변수의 레벨이 적용되어야 합니다.")
+		assert.Equal(t, "warn message", lines[0][types.MessageField])
+	})
+
+	t.Run("OTLP 출력의 insecure, service_name 설정을 읽는지 테스트", func(t *testing.T) {
+		// given
+		data := []byte(`
+outputs:
+  - type: otlp_grpc
+    address: otel-collector:4317
+    insecure: true
+    service_name: payment-api
+`)
+
+		// when
+		cfg, err := logger.ParseConfig(data, "yaml")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []logger.OutputConfig{{
+			Type:        "otlp_grpc",
+			Address:     "otel-collector:4317",
+			Insecure:    true,
+			ServiceName: "payment-api",
+		}}, cfg.Outputs)
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("정의되지 않은 설정 항목이 있으면 에러를 반환하는지 테스트", func(t *testing.T) {
+		// given
+		configPath := filepath.Join(t.TempDir(), "logging.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte("levle: debug\n"), 0o644))
+
+		// when
+		_, err := logger.LoadConfig(configPath)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "levle")
+	})
+
+	t.Run("잘못된 설정 항목을 경로와 함께 모두 반환하는지 테스트", func(t *testing.T) {
+		// given
+		cfg := logger.Config{
+			Backend: "slog",
+			Level:   "verbose",
+			Outputs: []logger.OutputConfig{{Type: "stdout"}, {Type: "tcp"}, {Type: "kafka"}},
+			Sampling: &logger.SamplingConfig{
+				Levels: map[types.LogLevel]logger.SamplingPolicyConfig{types.Debug: {Rate: 1.5}},
+			},
+			Masking: masking.Rules{"phone": "phone"},
+		}
+
+		// when
+		err := cfg.Validate()
+
+		// then
+		var errs logger.ConfigErrors
+		require.ErrorAs(t, err, &errs)
+		paths := make([]string, len(errs))
+		for i, e := range errs {
+			paths[i] = e.Path
+		}
+		assert.Equal(t, []string{
+			"backend",
+			"level",
+			"outputs[1].address",
+			"outputs[2].type",
+			"sampling.levels.debug.rate",
+			"masking.phone",
+		}, paths)
+	})
+
+	t.Run("잘못된 환경 변수는 환경 변수 이름과 함께 에러를 반환하는지 테스트", func(t *testing.T) {
+		// given
+		t.Setenv("LOG_CALLER", "maybe")
+		t.Setenv("LOG_OUTPUT", "stdout,syslog")
+
+		// when
+		_, err := logger.LoadConfig("")
+
+		// then
+		var errs logger.ConfigErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "env:LOG_CALLER", errs[0].Path)
+		assert.Equal(t, "env:LOG_OUTPUT[1]", errs[1].Path)
+	})
+
+	t.Run("잘못된 백엔드, 레벨, 포맷 환경 변수는 환경 변수 이름과 함께 에러를 반환하는지 테스트", func(t *testing.T) {
+		// given
+		t.Setenv("LOG_BACKEND", "slog")
+		t.Setenv("LOG_LEVEL", "verbose")
+		t.Setenv("LOG_FORMAT", "xml")
+
+		// when
+		_, err := logger.LoadConfig("")
+
+		// then
+		var errs logger.ConfigErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 3)
+		assert.Equal(t, "env:LOG_BACKEND", errs[0].Path)
+		assert.Equal(t, "env:LOG_LEVEL", errs[1].Path)
+		assert.Equal(t, "env:LOG_FORMAT", errs[2].Path)
+	})
+
+	t.Run("텍스트 포맷 설정이 적용되는지 테스트", func(t *testing.T) {
+		// given
+		logPath := filepath.Join(t.TempDir(), "app.log")
+		cfg := logger.Config{Format: types.Text, Outputs: []logger.OutputConfig{{Type: "file", Path: logPath}}}
+
+		// when
+		log, closer, err := logger.NewFromConfig(cfg)
+		require.NoError(t, err)
+		log.Info(options.WithMessage("text message"))
+
+		// then
+		require.NoError(t, closer.Close())
+		data, err := os.ReadFile(logPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "text message")
+		assert.False(t, json.Valid(bytes.TrimSpace(data)), "JSON 이 아닌 텍스트로 출력되어야 합니다.")
+	})
+}
+
+func TestWatchConfig(t *testing.T) {
+	writeConfig := func(t *testing.T, path, level, output string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(path, []byte("level: "+level+"\noutputs:\n  - type: file\n    path: "+output+"\n"), 0o644))
+	}
+
+	t.Run("다시 읽은 레벨과 출력이 컨텍스트의 로거에 적용되는지 테스트", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		configPath, before, after := filepath.Join(dir, "logging.yaml"), filepath.Join(dir, "before.log"), filepath.Join(dir, "after.log")
+		writeConfig(t, configPath, "info", before)
+		w, err := logger.WatchConfig(configPath, options.WithReloadInterval(0), options.WithReloadSignal(false))
+		require.NoError(t, err)
+		defer w.Close()
+		log := w.Logger()
+		log.RegisterCommonField("service", "payment")
+		ctx := log.WithContext(context.Background())
+		logger.FromContext(ctx, types.ZeroLog).Debug(options.WithMessage("dropped"))
+		logger.FromContext(ctx, types.ZeroLog).Info(options.WithMessage("before reload"))
+
+		// when
+		writeConfig(t, configPath, "debug", after)
+		require.NoError(t, w.Reload())
+		logger.FromContext(ctx, types.ZeroLog).Debug(options.WithMessage("after reload"))
+
+		// then
+		beforeLines := readLines(t, before)
+		require.Len(t, beforeLines, 1, "이전 설정의 레벨이 적용되어야 합니다.")
+		assert.Equal(t, "before reload", beforeLines[0][types.MessageField])
+		afterLines := readLines(t, after)
+		require.Len(t, afterLines, 2)
+		assert.Equal(t, "logging config reloaded", afterLines[0][types.MessageField])
+		assert.Equal(t, "after reload", afterLines[1][types.MessageField], "새 레벨과 출력이 적용되어야 합니다.")
+		assert.Equal(t, "payment", afterLines[1]["service"], "공통 필드가 유지되어야 합니다.")
+	})
+
+	t.Run("다시 읽은 레벨이 다른 로거와 서로 영향을 주지 않는지 테스트", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		configPath, output := filepath.Join(dir, "logging.yaml"), filepath.Join(dir, "app.log")
+		writeConfig(t, configPath, "info", output)
+		w, err := logger.WatchConfig(configPath, options.WithReloadInterval(0), options.WithReloadSignal(false))
+		require.NoError(t, err)
+		defer w.Close()
+		plainWriter := &captureWriter{}
+		plain := logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Info), options.WithOutput(plainWriter))
+
+		// when
+		writeConfig(t, configPath, "debug", output)
+		require.NoError(t, w.Reload())
+		// 다시 읽은 뒤 생성한 로거와 FromContext 의 기본 로거가 다시 읽은 레벨을 되돌리지 않아야 한다.
+		_ = logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Error), options.WithOutput(io.Discard))
+		_ = logger.FromContext(context.Background(), types.ZeroLog)
+		w.Logger().Debug(options.WithMessage("watched debug"))
+		plain.Debug(options.WithMessage("plain debug"))
+
+		// then
+		lines := readLines(t, output)
+		require.Len(t, lines, 2)
+		assert.Equal(t, "watched debug", lines[1][types.MessageField])
+		assert.Empty(t, plainWriter.Lines(), "Watcher 의 로거가 아닌 로거의 레벨은 바뀌지 않아야 합니다.")
+	})
+
+	t.Run("감시하는 동안 옵션 없이 생성한 로거에 다시 읽은 설정이 적용되는지 테스트", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		configPath, before, after := filepath.Join(dir, "logging.yaml"), filepath.Join(dir, "before.log"), filepath.Join(dir, "after.log")
+		writeConfig(t, configPath, "info", before)
+		w, err := logger.WatchConfig(configPath, options.WithReloadInterval(0), options.WithReloadSignal(false))
+		require.NoError(t, err)
+		defer w.Close()
+		log := logger.NewWrapper(types.ZeroLog)
+		fallback := logger.FromContext(context.Background(), types.ZeroLog)
+		log.Info(options.WithMessage("before reload"))
+
+		// when
+		writeConfig(t, configPath, "debug", after)
+		require.NoError(t, w.Reload())
+		log.Debug(options.WithMessage("wrapper debug"))
+		fallback.Debug(options.WithMessage("fallback debug"))
+
+		// then
+		beforeLines := readLines(t, before)
+		require.Len(t, beforeLines, 1, "감시 중인 설정의 출력으로 기록되어야 합니다.")
+		assert.Equal(t, "before reload", beforeLines[0][types.MessageField])
+		afterLines := readLines(t, after)
+		require.Len(t, afterLines, 3)
+		assert.Equal(t, "wrapper debug", afterLines[1][types.MessageField], "NewWrapper 로거에 새 레벨과 출력이 적용되어야 합니다.")
+		assert.Equal(t, "fallback debug", afterLines[2][types.MessageField], "FromContext 의 기본 로거에 새 레벨과 출력이 적용되어야 합니다.")
+	})
+
+	t.Run("검증에 실패한 설정은 에러 로그를 남기고 거부되는지 테스트", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		configPath, output := filepath.Join(dir, "logging.yaml"), filepath.Join(dir, "app.log")
+		writeConfig(t, configPath, "warn", output)
+		w, err := logger.WatchConfig(configPath, options.WithReloadInterval(0), options.WithReloadSignal(false))
+		require.NoError(t, err)
+		defer w.Close()
+		log := w.Logger()
+
+		// when
+		writeConfig(t, configPath, "verbose", filepath.Join(dir, "other.log"))
+		err = w.Reload()
+		log.Info(options.WithMessage("info message"))
+		log.Warn(options.WithMessage("warn message"))
+
+		// then
+		var errs logger.ConfigErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, "level", errs[0].Path)
+		lines := readLines(t, output)
+		require.Len(t, lines, 2, "기존 레벨과 출력이 유지되어야 합니다.")
+		assert.Equal(t, "logging config rejected", lines[0][types.MessageField])
+		assert.Equal(t, configPath, lines[0][types.ConfigField])
+		assert.Contains(t, lines[0][types.ErrorField], "level")
+		assert.Equal(t, "warn message", lines[1][types.MessageField])
+		assert.NoFileExists(t, filepath.Join(dir, "other.log"))
+	})
+
+	t.Run("설정 파일 변경을 주기적으로 확인하여 적용하는지 테스트", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		configPath, before, after := filepath.Join(dir, "logging.yaml"), filepath.Join(dir, "before.log"), filepath.Join(dir, "after.log")
+		writeConfig(t, configPath, "info", before)
+		w, err := logger.WatchConfig(configPath, options.WithReloadInterval(10*time.Millisecond), options.WithReloadSignal(false))
+		require.NoError(t, err)
+		defer w.Close()
+
+		// when
+		writeConfig(t, configPath, "info", after)
+
+		// then
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(after)
+			return err == nil
+		}, time.Second, 10*time.Millisecond, "변경된 설정이 적용되어야 합니다.")
+		w.Logger().Info(options.WithMessage("after reload"))
+		lines := readLines(t, after)
+		assert.Equal(t, "after reload", lines[len(lines)-1][types.MessageField])
+	})
+
+	t.Run("설정을 바꾸는 동안 기록 중인 로그가 유실되지 않는지 테스트", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		configPath := filepath.Join(dir, "logging.yaml")
+		outputs := []string{filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log")}
+		writeConfig(t, configPath, "info", outputs[0])
+		w, err := logger.WatchConfig(configPath, options.WithReloadInterval(0), options.WithReloadSignal(false))
+		require.NoError(t, err)
+		const writers, count = 4, 200
+
+		// when
+		var wg sync.WaitGroup
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				log := w.Logger()
+				for j := 0; j < count; j++ {
+					log.Clone().Info(options.WithMessage("message"))
+				}
+			}()
+		}
+		for i := 0; i < 10; i++ {
+			writeConfig(t, configPath, "info", outputs[(i+1)%2])
+			require.NoError(t, w.Reload())
+		}
+		wg.Wait()
+		require.NoError(t, w.Close())
+
+		// then
+		written := 0
+		for _, output := range outputs {
+			for _, line := range readLines(t, output) {
+				if line[types.MessageField] == "message" {
+					written++
+				}
+			}
+		}
+		assert.Equal(t, writers*count, written)
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("가장 가까운 상위 이름의 레벨을 따르는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		registry := logger.NewRegistry(types.ZeroLog, options.WithOutput(captureWriter))
+		registry.SetLevel("payment", types.Warn)
+		registry.SetLevel("payment.client", types.Debug)
 
-	return entryMap
+		// when
+		registry.Named("payment.client.retry").Debug(options.WithMessage("client debug"))
+		registry.Named("payment.server").Info(options.WithMessage("server info"))
+		registry.Named("payment.server").Warn(options.WithMessage("server warn"))
+		registry.Named("order").Debug(options.WithMessage("order debug"))
+		registry.Named("order").Info(options.WithMessage("order info"))
+
+		// then
+		assert.Equal(t, types.Debug, registry.Level("payment.client.retry"))
+		assert.Equal(t, types.Warn, registry.Level("payment.server"))
+		assert.Equal(t, types.Info, registry.Level("order"), "설정되지 않은 이름은 최상위 레벨을 따라야 합니다.")
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 3)
+		assert.Equal(t, "client debug", lines[0][types.MessageField])
+		assert.Equal(t, "payment.client.retry", lines[0][types.ComponentField], "컴포넌트 이름이 기록되어야 합니다.")
+		assert.Equal(t, "server warn", lines[1][types.MessageField])
+		assert.Equal(t, "order info", lines[2][types.MessageField])
+		assert.Equal(t, "order", lines[2][types.ComponentField])
+	})
+
+	t.Run("실행 중에 바꾼 레벨이 이미 생성된 로거에 적용되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		registry := logger.NewRegistry(types.Logrus, options.WithLevel(types.Error), options.WithOutput(captureWriter))
+		log := registry.Named("payment.client")
+		ctx := log.WithContext(context.Background())
+		log.Info(options.WithMessage("before"))
+
+		// when
+		registry.SetLevel("payment", types.Info)
+		logger.FromContext(ctx, types.Logrus).Info(options.WithMessage("after set"))
+		registry.UnsetLevel("payment")
+		logger.FromContext(ctx, types.Logrus).Info(options.WithMessage("after unset"))
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 1)
+		assert.Equal(t, "after set", lines[0][types.MessageField])
+		assert.Equal(t, "payment.client", lines[0][types.ComponentField])
+	})
+
+	t.Run("레지스트리와 일반 로거의 레벨이 서로 영향을 주지 않는지 테스트", func(t *testing.T) {
+		// given
+		registryWriter, plainWriter := &captureWriter{}, &captureWriter{}
+		registry := logger.NewRegistry(types.ZeroLog, options.WithOutput(registryWriter))
+		plain := logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Info), options.WithOutput(plainWriter))
+		registry.SetLevel("payment.client", types.Debug)
+		// 레지스트리 이후에 생성한 로거도 컴포넌트 레벨을 바꾸지 않아야 한다.
+		_ = logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Error), options.WithOutput(io.Discard))
+
+		// when
+		plain.Debug(options.WithMessage("plain debug"))
+		plain.Info(options.WithMessage("plain info"))
+		registry.Named("payment.client").Debug(options.WithMessage("client debug"))
+		registry.Named("payment.client").Info(options.WithMessage("client info"))
+
+		// then
+		plainLines := plainWriter.Lines()
+		require.Len(t, plainLines, 1, "일반 로거는 자신의 레벨을 따라야 합니다.")
+		assert.Equal(t, "plain info", plainLines[0][types.MessageField])
+		registryLines := registryWriter.Lines()
+		require.Len(t, registryLines, 2, "컴포넌트 로거는 레지스트리 레벨을 따라야 합니다.")
+		assert.Equal(t, "client debug", registryLines[0][types.MessageField])
+	})
+
+	t.Run("기본 레지스트리로 Named, SetLevel 을 사용하는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		logger.SetDefaultRegistry(logger.NewRegistry(types.ZeroLog, options.WithOutput(captureWriter)))
+		defer logger.SetDefaultRegistry(logger.NewRegistry(types.ZeroLog))
+
+		// when
+		logger.SetLevel("payment.client", types.Debug)
+		logger.Named("payment.client").Debug(options.WithMessage("client debug"))
+		logger.Named("payment").Debug(options.WithMessage("payment debug"))
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 1)
+		assert.Equal(t, "client debug", lines[0][types.MessageField])
+	})
+}
+
+func TestLogfmt(t *testing.T) {
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		loggerType := loggerType
+		t.Run(string(loggerType)+" 로거가 logfmt 로 출력하는지 테스트", func(t *testing.T) {
+			// given
+			var buf bytes.Buffer
+			log := logger.NewWrapper(loggerType, options.WithFormat(types.Logfmt), options.WithOutput(&buf))
+
+			// when
+			log.Info(
+				options.WithMessage("payment failed"),
+				options.WithFields(options.Fields{
+					"user":   "kim",
+					"reason": "card \"declined\"\nretry=false",
+					"empty":  "",
+					"amount": 1500,
+					"ok":     false,
+					"http":   map[string]interface{}{"status": 402, "method": "POST"},
+					"tags":   []string{"a", "b"},
+				}),
+			)
+
+			// then
+			line := strings.TrimSuffix(buf.String(), "\n")
+			require.NotContains(t, line, "\n", "한 줄로 출력되어야 합니다.")
+			assert.Regexp(t, `^time="\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}" level=info message="payment failed" `, line,
+				"시간, 레벨, 메시지 순으로 시작해야 합니다.")
+			assert.True(t, strings.HasSuffix(line,
+				` amount=1500 empty="" http.method=POST http.status=402 ok=false reason="card \"declined\"\nretry=false" tags="[\"a\",\"b\"]" user=kim`),
+				"나머지 키는 이름순으로, 중첩된 맵은 점으로 연결한 키로 출력되어야 합니다: %s", line)
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		loggerType := loggerType
+		t.Run(string(loggerType)+" 로거가 ECS 필드로 출력하는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(loggerType,
+				options.WithOutput(captureWriter),
+				options.WithSchema(types.ECS, map[string]string{"order_id": "labels.order_id"}),
+			)
+
+			// when
+			log.Error(
+				options.WithMessage("payment failed"),
+				options.WithFields(options.Fields{
+					types.TraceIDField:    "4bf92f3577b34da6a3ce929d0e0e4736",
+					types.StatusCodeField: 502,
+					types.StackField:      "goroutine 1 [running]:",
+					"order_id":            "A-1",
+					"partner":             "kcp",
+				}),
+			)
+
+			// then
+			line := captureWriter.Map()
+			_, err := time.Parse(time.RFC3339Nano, line["@timestamp"].(string))
+			assert.NoError(t, err, "@timestamp 는 RFC3339 형식이어야 합니다.")
+			assert.Equal(t, "payment failed", line["message"])
+			assert.Equal(t, map[string]interface{}{"level": "error"}, line["log"])
+			assert.Equal(t, map[string]interface{}{"id": "4bf92f3577b34da6a3ce929d0e0e4736"}, line["trace"])
+			assert.Equal(t, map[string]interface{}{"response": map[string]interface{}{"status_code": float64(502)}}, line["http"])
+			assert.Equal(t, map[string]interface{}{"stack_trace": "goroutine 1 [running]:"}, line["error"])
+			assert.Equal(t, map[string]interface{}{"order_id": "A-1"}, line["labels"], "설정한 매핑이 적용되어야 합니다.")
+			assert.Equal(t, map[string]interface{}{"version": logger.ECSVersion}, line["ecs"])
+			assert.Equal(t, "kcp", line["partner"], "매핑되지 않은 필드는 그대로 기록되어야 합니다.")
+			assert.NotContains(t, line, types.TimeField)
+			assert.NotContains(t, line, types.LevelField)
+		})
+	}
+
+	t.Run("ECS 로거 뒤에 생성한 일반 로거가 @timestamp 형식을 바꾸지 않는지 테스트", func(t *testing.T) {
+		// given
+		ecsWriter, plainWriter := &captureWriter{}, &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(ecsWriter), options.WithSchema(types.ECS))
+		plain := logger.NewWrapper(types.ZeroLog, options.WithOutput(plainWriter))
+
+		// when
+		log.Info(options.WithMessage("paid"), options.WithAttrs(options.Time("paid_at", time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC))))
+		plain.Info(options.WithMessage("plain"))
+
+		// then
+		line := ecsWriter.Map()
+		_, err := time.Parse(time.RFC3339Nano, line["@timestamp"].(string))
+		assert.NoError(t, err, "@timestamp 는 RFC3339 형식이어야 합니다.")
+		assert.Equal(t, "2024-05-01T09:30:00Z", line["paid_at"], "시각 필드도 로거의 포맷으로 기록되어야 합니다.")
+		assert.Regexp(t, `^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`, plainWriter.Map()[types.TimeField], "일반 로거는 기본 포맷을 유지해야 합니다.")
+	})
+
+	t.Run("ECS 스키마를 logfmt 포맷과 함께 사용하는지 테스트", func(t *testing.T) {
+		// given
+		var buf bytes.Buffer
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(&buf), options.WithFormat(types.Logfmt), options.WithSchema(types.ECS))
+
+		// when
+		log.Info(options.WithMessage("paid"), options.WithFields(options.Fields{types.StatusCodeField: 200}))
+
+		// then
+		assert.Regexp(t, `^@timestamp=\S+ log.level=info message=paid ecs.version=8\.11\.0 http.response.status_code=200\n$`, buf.String())
+	})
+
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		loggerType := loggerType
+		t.Run(string(loggerType)+" 로거가 Cloud Logging 필드로 출력하는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(loggerType,
+				options.WithOutput(captureWriter),
+				options.WithCaller(true),
+				options.WithSchema(types.GCP, map[string]string{"order_id": "logging.googleapis.com/labels"}),
+				options.WithGCPProject("my-project"),
+			)
+
+			// when
+			log.Warn(
+				options.WithMessage("slow request"),
+				options.WithFields(options.Fields{
+					types.TraceIDField:      "4bf92f3577b34da6a3ce929d0e0e4736",
+					types.SpanIDField:       "00f067aa0ba902b7",
+					types.MethodField:       "GET",
+					types.URIField:          "/orders/A-1",
+					types.StatusCodeField:   200,
+					types.BytesWrittenField: 1024,
+					types.RemoteAddrField:   "10.0.0.1:52344",
+					types.ElapsedField:      1250,
+					"order_id":              "A-1",
+				}),
+			)
+
+			// then
+			line := captureWriter.Map()
+			_, err := time.Parse(time.RFC3339Nano, line["timestamp"].(string))
+			assert.NoError(t, err, "timestamp 는 RFC3339 형식이어야 합니다.")
+			assert.Equal(t, "WARNING", line["severity"])
+			assert.Equal(t, "slow request", line["message"])
+			assert.Equal(t, "projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736", line["logging.googleapis.com/trace"])
+			assert.Equal(t, "00f067aa0ba902b7", line["logging.googleapis.com/spanId"])
+			assert.Equal(t, map[string]interface{}{
+				"requestMethod": "GET",
+				"requestUrl":    "/orders/A-1",
+				"status":        float64(200),
+				"responseSize":  "1024",
+				"remoteIp":      "10.0.0.1",
+				"latency":       "1.25s",
+			}, line["httpRequest"])
+			location, ok := line["logging.googleapis.com/sourceLocation"].(map[string]interface{})
+			require.True(t, ok, "호출 위치가 sourceLocation 으로 기록되어야 합니다.")
+			assert.Contains(t, location["file"], "logger_test.go")
+			assert.Regexp(t, `^\d+$`, location["line"])
+			assert.Equal(t, "A-1", line["logging.googleapis.com/labels"], "설정한 매핑이 적용되어야 합니다.")
+			assert.NotContains(t, line, types.LevelField)
+			assert.NotContains(t, line, types.CallerField)
+			assert.NotContains(t, line, types.TraceIDField)
+		})
+	}
+
+	t.Run("HTTP 액세스 로그가 아닌 로그의 elapsed 는 httpRequest 로 바꾸지 않는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter), options.WithSchema(types.GCP))
+
+		// when
+		log.Clone().Info(options.WithMessage("grpc request"), options.WithFields(options.Fields{
+			types.GRPCMethodField: "GetUser",
+			types.GRPCCodeField:   "OK",
+			types.ElapsedField:    12,
+		}))
+		log.Clone().Info(options.WithMessage("query"), options.WithFields(options.Fields{
+			types.SQLQueryField:   "SELECT 1",
+			types.ElapsedField:    3,
+			types.UserAgentField:  "worker",
+			types.StatusCodeField: 200,
+		}))
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 2)
+		for _, line := range lines {
+			assert.NotContains(t, line, "httpRequest")
+			assert.Contains(t, line, types.ElapsedField, "elapsed 는 일반 필드로 남아야 합니다.")
+		}
+		assert.Equal(t, "worker", lines[1][types.UserAgentField])
+		assert.Equal(t, float64(200), lines[1][types.StatusCodeField])
+	})
+
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		loggerType := loggerType
+		t.Run(string(loggerType)+" GCP 로거 뒤에 생성한 일반 로거가 timestamp 형식을 바꾸지 않는지 테스트", func(t *testing.T) {
+			// given
+			gcpWriter, plainWriter := &captureWriter{}, &captureWriter{}
+			log := logger.NewWrapper(loggerType, options.WithOutput(gcpWriter), options.WithSchema(types.GCP))
+			plain := logger.NewWrapper(loggerType, options.WithOutput(plainWriter))
+
+			// when
+			log.Info(options.WithMessage("paid"))
+			plain.Info(options.WithMessage("plain"))
+
+			// then
+			_, err := time.Parse(time.RFC3339Nano, gcpWriter.Map()["timestamp"].(string))
+			assert.NoError(t, err, "timestamp 는 RFC3339 형식이어야 합니다.")
+			assert.Regexp(t, `^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`, plainWriter.Map()[types.TimeField], "일반 로거는 기본 포맷을 유지해야 합니다.")
+		})
+	}
+
+	t.Run("GCP 스키마에서 레벨이 severity 로 바뀌고 프로젝트가 없으면 trace ID 를 그대로 기록하는지 테스트", func(t *testing.T) {
+		// given
+		t.Setenv("GOOGLE_CLOUD_PROJECT", "")
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.Logrus, options.WithOutput(captureWriter), options.WithLevel(types.Debug), options.WithSchema(types.GCP))
+
+		// when
+		log.Debug(options.WithMessage("debug"))
+		log.Info(options.WithMessage("info"))
+		log.Error(options.WithMessage("error"), options.WithFields(options.Fields{types.TraceIDField: "abc"}))
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 3)
+		assert.Equal(t, "DEBUG", lines[0]["severity"])
+		assert.Equal(t, "INFO", lines[1]["severity"])
+		assert.Equal(t, "ERROR", lines[2]["severity"])
+		assert.Equal(t, "abc", lines[2]["logging.googleapis.com/trace"])
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		loggerType := loggerType
+		t.Run(string(loggerType)+" 로거가 선택한 로그에 EMF 메타데이터를 붙이는지 테스트", func(t *testing.T) {
+			// given
+			metrics, err := options.NewMetrics("payment-api",
+				options.WithMetric(types.ElapsedField, types.Milliseconds),
+				options.WithMetric(types.StatusCodeField, ""),
+				options.WithDimensions("service", types.MethodField),
+				options.WithDimensions("service"),
+			)
+			require.NoError(t, err)
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(loggerType, options.WithOutput(captureWriter))
+			log.RegisterCommonField("service", "payment")
+			before := time.Now().UnixMilli()
+
+			// when
+			log.Clone().Info(options.WithMessage("started"))
+			log.Clone().Info(
+				options.WithMessage("request completed"),
+				options.WithFields(options.Fields{types.MethodField: "GET", types.ElapsedField: 12, types.StatusCodeField: 200}),
+				options.WithMetrics(metrics),
+			)
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 2)
+			assert.NotContains(t, lines[0], types.EMFField, "메트릭을 지정하지 않은 로그에는 붙지 않아야 합니다.")
+
+			line := lines[1]
+			aws, ok := line[types.EMFField].(map[string]interface{})
+			require.True(t, ok)
+			assert.GreaterOrEqual(t, aws["Timestamp"], float64(before))
+			assert.Equal(t, []interface{}{map[string]interface{}{
+				"Namespace":  "payment-api",
+				"Dimensions": []interface{}{[]interface{}{"service", "method"}, []interface{}{"service"}},
+				"Metrics": []interface{}{
+					map[string]interface{}{"Name": "elapsed", "Unit": "Milliseconds"},
+					map[string]interface{}{"Name": "status_code", "Unit": "None"},
+				},
+			}}, aws["CloudWatchMetrics"])
+			assert.Equal(t, float64(12), line[types.ElapsedField], "메트릭 값은 최상위 필드로 기록되어야 합니다.")
+			assert.Equal(t, "payment", line["service"])
+		})
+
+		t.Run(string(loggerType)+" 로거가 같은 로거의 다음 로그에는 EMF 메타데이터를 붙이지 않는지 테스트", func(t *testing.T) {
+			// given
+			metrics, err := options.NewMetrics("payment-api", options.WithMetric(types.ElapsedField, types.Milliseconds))
+			require.NoError(t, err)
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(loggerType, options.WithOutput(captureWriter))
+
+			// when
+			log.Info(options.WithMessage("request completed"), options.WithFields(options.Fields{types.ElapsedField: 12}), options.WithMetrics(metrics))
+			log.Info(options.WithMessage("next"))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 2)
+			assert.Contains(t, lines[0], types.EMFField)
+			assert.NotContains(t, lines[1], types.EMFField, "메트릭은 옵션을 넘긴 로그에만 붙어야 합니다.")
+			assert.Equal(t, float64(12), lines[1][types.ElapsedField], "필드는 엔트리에 그대로 남아야 합니다.")
+		})
+	}
+
+	t.Run("EMF 제한을 넘는 선언이 에러를 반환하는지 테스트", func(t *testing.T) {
+		// given
+		tooManyMetrics := make([]options.MetricsOption, 0, options.EMFMaxMetrics+1)
+		for i := 0; i <= options.EMFMaxMetrics; i++ {
+			tooManyMetrics = append(tooManyMetrics, options.WithMetric(fmt.Sprintf("m%d", i), types.Count))
+		}
+		dimensions := make([]string, options.EMFMaxDimensions+1)
+		for i := range dimensions {
+			dimensions[i] = fmt.Sprintf("d%d", i)
+		}
+
+		// when
+		_, metricsErr := options.NewMetrics("ns", tooManyMetrics...)
+		_, dimensionsErr := options.NewMetrics("ns", options.WithMetric("m", types.Count), options.WithDimensions(dimensions...))
+		_, okErr := options.NewMetrics("ns", append(tooManyMetrics[:options.EMFMaxMetrics:options.EMFMaxMetrics], options.WithDimensions(dimensions[:options.EMFMaxDimensions]...))...)
+		_, unitErr := options.NewMetrics("ns", options.WithMetric("m", "Hours"))
+		_, emptyErr := options.NewMetrics("ns")
+		_, namespaceErr := options.NewMetrics("", options.WithMetric("m", types.Count))
+
+		// then
+		assert.ErrorIs(t, metricsErr, options.ErrTooManyMetrics)
+		assert.ErrorIs(t, dimensionsErr, options.ErrTooManyDimensions)
+		assert.NoError(t, okErr, "제한과 같은 개수는 허용되어야 합니다.")
+		assert.Error(t, unitErr)
+		assert.Error(t, emptyErr)
+		assert.Error(t, namespaceErr)
+	})
+}
+
+func TestBinaryFormat(t *testing.T) {
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		for _, format := range []types.LogFormat{types.CBOR, types.MsgPack} {
+			loggerType, format := loggerType, format
+			t.Run(string(loggerType)+" 로거가 "+string(format)+" 로 출력하고 JSON 으로 되돌리는지 테스트", func(t *testing.T) {
+				// given
+				var buf bytes.Buffer
+				log := logger.NewWrapper(loggerType, options.WithFormat(format), options.WithOutput(&buf))
+
+				// when
+				log.Clone().Info(options.WithMessage("first"))
+				log.Clone().Warn(
+					options.WithMessage("second"),
+					options.WithFields(options.Fields{
+						"amount": 1500,
+						"ratio":  0.25,
+						"debt":   -42,
+						"ok":     false,
+						"none":   nil,
+						"http":   map[string]interface{}{"status": 402},
+						"tags":   []string{"a", "b"},
+					}),
+				)
+				var out bytes.Buffer
+				err := logger.DecodeBinary(&out, bytes.NewReader(buf.Bytes()), format)
+
+				// then
+				require.NoError(t, err)
+				assert.NotContains(t, buf.String(), `"message"`, "JSON 이 아닌 바이너리로 기록되어야 합니다.")
+				lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+				require.Len(t, lines, 2)
+				var first, second map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+				require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+				assert.Equal(t, "first", first["message"])
+				assert.Equal(t, "info", first["level"])
+				assert.Contains(t, first, types.TimeField)
+				assert.Equal(t, "second", second["message"])
+				assert.Contains(t, []interface{}{"warn", "warning"}, second["level"])
+				assert.Equal(t, float64(1500), second["amount"])
+				assert.Equal(t, 0.25, second["ratio"])
+				assert.Equal(t, float64(-42), second["debt"])
+				assert.Equal(t, false, second["ok"])
+				assert.Contains(t, second, "none")
+				assert.Nil(t, second["none"])
+				assert.Equal(t, map[string]interface{}{"status": float64(402)}, second["http"])
+				assert.Equal(t, []interface{}{"a", "b"}, second["tags"])
+			})
+		}
+	}
+
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		for _, format := range []types.LogFormat{types.CBOR, types.MsgPack} {
+			loggerType, format := loggerType, format
+			t.Run(string(loggerType)+" 로거의 "+string(format)+" 로그가 JSON 포맷과 같은 값으로 되돌아오는지 테스트", func(t *testing.T) {
+				// given
+				ids := make([]int, 20)
+				labels := map[string]interface{}{}
+				for i := range ids {
+					ids[i] = i * 1000
+					labels[strconv.Itoa(i)] = strings.Repeat("x", i)
+				}
+				fields := options.Fields{
+					"quoted": "say \"hi\"\n\t결제",
+					"long":   strings.Repeat("a", 300),
+					"ids":    ids,
+					"labels": labels,
+					"max":    uint64(math.MaxUint64),
+					"small":  int8(-100),
+					"ratio":  float32(0.1),
+					"empty":  map[string]interface{}{"list": []interface{}{}},
+				}
+				var jsonBuf, binaryBuf bytes.Buffer
+				jsonLog := logger.NewWrapper(loggerType, options.WithOutput(&jsonBuf))
+				binaryLog := logger.NewWrapper(loggerType, options.WithFormat(format), options.WithOutput(&binaryBuf))
+
+				// when
+				jsonLog.Clone().Info(options.WithMessage("paid"), options.WithFields(fields))
+				binaryLog.Clone().Info(options.WithMessage("paid"), options.WithFields(fields))
+				var out bytes.Buffer
+				err := logger.DecodeBinary(&out, bytes.NewReader(binaryBuf.Bytes()), format)
+
+				// then
+				require.NoError(t, err)
+				var want, got map[string]interface{}
+				require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &want))
+				require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+				// 시간은 기록 시각이 다르며, logrus 의 JSONFormatter 는 빈 msg 필드를 함께 기록한다.
+				delete(want, types.TimeField)
+				delete(got, types.TimeField)
+				delete(want, logrus.FieldKeyMsg)
+				assert.Equal(t, want, got)
+			})
+		}
+	}
+
+	t.Run("zerolog binary_log 빌드 형식의 CBOR 를 JSON 으로 변환하는지 테스트", func(t *testing.T) {
+		// given
+		// {_ "level": "info", "time": 1(1700000000), "message": (_ "hi", "!"), "ratio": 1.5 (half float)}
+		stream := []byte{0xbf,
+			0x65, 'l', 'e', 'v', 'e', 'l', 0x64, 'i', 'n', 'f', 'o',
+			0x64, 't', 'i', 'm', 'e', 0xc1, 0x1a, 0x65, 0x53, 0xf1, 0x00,
+			0x67, 'm', 'e', 's', 's', 'a', 'g', 'e', 0x7f, 0x62, 'h', 'i', 0x61, '!', 0xff,
+			0x65, 'r', 'a', 't', 'i', 'o', 0xf9, 0x3e, 0x00,
+			0xff,
+		}
+		var out bytes.Buffer
+
+		// when
+		err := logger.DecodeBinary(&out, bytes.NewReader(stream), types.CBOR)
+
+		// then
+		require.NoError(t, err)
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+		assert.Equal(t, map[string]interface{}{
+			"level":   "info",
+			"time":    time.Unix(1700000000, 0).Format(time.RFC3339Nano),
+			"message": "hi!",
+			"ratio":   1.5,
+		}, line)
+	})
+
+	t.Run("타임스탬프 확장 타입을 가진 MessagePack 을 JSON 으로 변환하는지 테스트", func(t *testing.T) {
+		// given
+		// {"time": ext(-1, 1700000000), "debt": int16(-300), "ratio": float32(1.5), "ok": true}
+		stream := []byte{0x84,
+			0xa4, 't', 'i', 'm', 'e', 0xd6, 0xff, 0x65, 0x53, 0xf1, 0x00,
+			0xa4, 'd', 'e', 'b', 't', 0xd1, 0xfe, 0xd4,
+			0xa5, 'r', 'a', 't', 'i', 'o', 0xca, 0x3f, 0xc0, 0x00, 0x00,
+			0xa2, 'o', 'k', 0xc3,
+		}
+		var out bytes.Buffer
+
+		// when
+		err := logger.DecodeBinary(&out, bytes.NewReader(stream), types.MsgPack)
+
+		// then
+		require.NoError(t, err)
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+		assert.Equal(t, map[string]interface{}{
+			"time":  time.Unix(1700000000, 0).Format(time.RFC3339Nano),
+			"debt":  float64(-300),
+			"ratio": 1.5,
+			"ok":    true,
+		}, line)
+	})
+
+	t.Run("잘린 스트림과 지원하지 않는 포맷이 에러를 반환하는지 테스트", func(t *testing.T) {
+		// when
+		truncatedErr := logger.DecodeBinary(io.Discard, bytes.NewReader([]byte{0xa1, 0x61, 'a'}), types.CBOR)
+		truncatedMsgPackErr := logger.DecodeBinary(io.Discard, bytes.NewReader([]byte{0x81, 0xa1, 'a'}), types.MsgPack)
+		formatErr := logger.DecodeBinary(io.Discard, bytes.NewReader(nil), types.Logfmt)
+
+		// then
+		assert.ErrorIs(t, truncatedErr, io.ErrUnexpectedEOF)
+		assert.ErrorIs(t, truncatedMsgPackErr, io.ErrUnexpectedEOF)
+		assert.Error(t, formatErr)
+	})
+}
+
+func BenchmarkLogger(b *testing.B) {
+	fields := options.Fields{
+		types.RequestIDField:    "f3b1c2d4",
+		types.MethodField:       "GET",
+		types.URIField:          "/orders/A-1",
+		types.StatusCodeField:   200,
+		types.ElapsedField:      12,
+		types.RemoteAddrField:   "10.0.0.1",
+		types.UserAgentField:    "curl/8.0",
+		types.BytesWrittenField: 512,
+		"order_id":              "A-1",
+		"retry":                 false,
+	}
+	attrs := func(log logger.Logger) {
+		logger.LogAttrs(log, types.Info, "request completed",
+			options.String(types.RequestIDField, "f3b1c2d4"),
+			options.String(types.MethodField, "GET"),
+			options.String(types.URIField, "/orders/A-1"),
+			options.Int(types.StatusCodeField, 200),
+			options.Duration(types.ElapsedField, 12*time.Millisecond),
+			options.String(types.RemoteAddrField, "10.0.0.1"),
+			options.String(types.UserAgentField, "curl/8.0"),
+			options.Int64(types.BytesWrittenField, 512),
+			options.String("order_id", "A-1"),
+			options.Bool("retry", false),
+		)
+	}
+	cases := []struct {
+		name   string
+		common bool
+		log    func(log logger.Logger)
+	}{
+		{name: "disabled", log: func(log logger.Logger) { log.Debug(options.WithMessage("request completed")) }},
+		{name: "disabled_attrs", log: func(log logger.Logger) {
+			logger.LogAttrs(log, types.Debug, "request completed", options.String(types.MethodField, "GET"))
+		}},
+		{name: "message", log: func(log logger.Logger) { log.Info(options.WithMessage("request completed")) }},
+		{name: "fields10", log: func(log logger.Logger) {
+			log.Info(options.WithMessage("request completed"), options.WithFields(fields))
+		}},
+		{name: "attrs10", log: attrs},
+		{name: "common_fields", common: true, log: func(log logger.Logger) { log.Info(options.WithMessage("request completed")) }},
+		{name: "common_fields_attrs10", common: true, log: attrs},
+	}
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		for _, c := range cases {
+			b.Run(string(loggerType)+"/"+c.name, func(b *testing.B) {
+				log := logger.NewWrapper(loggerType, options.WithOutput(io.Discard))
+				if c.common {
+					log.RegisterCommonFields(fields)
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					c.log(log)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkFormat(b *testing.B) {
+	fields := options.Fields{
+		types.MethodField:     "GET",
+		types.URIField:        "/orders/A-1",
+		types.StatusCodeField: 200,
+		types.ElapsedField:    12,
+		"user":                map[string]interface{}{"id": 42, "tier": "gold"},
+	}
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		for _, format := range []types.LogFormat{types.JSON, types.Logfmt, types.Text, types.CBOR, types.MsgPack} {
+			b.Run(string(loggerType)+"/"+string(format), func(b *testing.B) {
+				log := logger.NewWrapper(loggerType, options.WithFormat(format), options.WithOutput(io.Discard))
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					log.Clone().Info(options.WithMessage("request completed"), options.WithFields(fields))
+				}
+			})
+		}
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, loggerType := range []types.LoggerType{types.ZeroLog, types.Logrus} {
+		loggerType := loggerType
+		t.Run(string(loggerType)+" 로거가 타입이 지정된 필드를 타입에 맞게 기록하는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(loggerType, options.WithOutput(captureWriter))
+
+			// when
+			log.Clone().Info(
+				options.WithMessage("paid"),
+				options.WithAttrs(
+					options.String("order_id", "A-1"),
+					options.Int64("amount", 9007199254740993),
+					options.Float64("ratio", 0.25),
+					options.Bool("retry", true),
+					options.Duration(types.ElapsedField, 1500*time.Millisecond),
+					options.Time("paid_at", at),
+					options.Err(errors.New("card declined")),
+					options.Object("user", options.String("id", "u-1"), options.Int("age", 30), options.Err(nil)),
+					options.Array("tags", "a", "b"),
+					options.Array("waits", time.Second, 2*time.Second),
+				),
+				options.WithAttrs(options.String("order_id", "A-2")),
+			)
+
+			// then
+			assert.Contains(t, captureWriter.String(), `"amount":9007199254740993`, "정수는 실수로 바뀌지 않아야 합니다.")
+			line := captureWriter.Map()
+			assert.Equal(t, "paid", line[types.MessageField])
+			assert.Equal(t, "A-2", line["order_id"], "같은 키는 나중에 등록한 값이어야 합니다.")
+			assert.Equal(t, 0.25, line["ratio"])
+			assert.Equal(t, true, line["retry"])
+			assert.Equal(t, float64(1500), line[types.ElapsedField], "시간 간격은 밀리초로 기록되어야 합니다.")
+			assert.Equal(t, "2024-01-02 03:04:05", line["paid_at"], "시각은 로거의 시간 포맷으로 기록되어야 합니다.")
+			assert.Equal(t, "card declined", line[types.ErrorField])
+			assert.Equal(t, map[string]interface{}{"id": "u-1", "age": float64(30)}, line["user"], "nil 에러는 기록되지 않아야 합니다.")
+			assert.Equal(t, []interface{}{"a", "b"}, line["tags"])
+			assert.Equal(t, []interface{}{float64(1000), float64(2000)}, line["waits"])
+		})
+
+		t.Run(string(loggerType)+" 로거의 LogAttrs 가 엔트리에 남기지 않고 기록하는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(loggerType, options.WithOutput(captureWriter), options.WithLevel(types.Info))
+			log.RegisterCommonField("service", "payment")
+			log.ApplyOption([]options.EntryOption{
+				options.WithMessage("registered"),
+				options.WithFields(options.Fields{"order_id": "A-0", "channel": "web"}),
+			})
+
+			// when
+			logger.LogAttrs(log, types.Debug, "hidden")
+			logger.LogAttrs(log, types.Error, "paid", options.String("order_id", "A-1"), options.Int64("amount", 15000))
+			log.Info()
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 2, "비활성 레벨은 기록되지 않아야 합니다.")
+			assert.Equal(t, "error", lines[0][types.LevelField])
+			assert.Equal(t, "paid", lines[0][types.MessageField])
+			assert.Equal(t, "A-1", lines[0]["order_id"], "함께 넘긴 필드가 등록된 엔트리보다 우선해야 합니다.")
+			assert.Equal(t, float64(15000), lines[0]["amount"])
+			assert.Equal(t, "web", lines[0]["channel"])
+			assert.Equal(t, "payment", lines[0]["service"])
+			assert.Equal(t, "registered", lines[1][types.MessageField], "LogAttrs 의 메시지와 필드는 엔트리에 남지 않아야 합니다.")
+			assert.Equal(t, "A-0", lines[1]["order_id"])
+			assert.NotContains(t, lines[1], "amount")
+			assert.Equal(t, len(lines), strings.Count(captureWriter.String(), `"order_id"`), "필드는 로그마다 한 번씩만 기록되어야 합니다.")
+		})
+
+		t.Run(string(loggerType)+" 로거의 필드와 타입이 지정된 필드의 키가 겹치면 나중에 적용한 값만 기록하는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(loggerType, options.WithOutput(captureWriter))
+
+			// when
+			log.Info(options.WithFields(options.Fields{"user": "kim"}), options.WithAttrs(options.String("user", "lee")))
+			log.Info(options.WithFields(options.Fields{"user": "park"}))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 2)
+			assert.Equal(t, "lee", lines[0]["user"], "같은 호출에서는 타입이 지정된 필드가 우선해야 합니다.")
+			assert.Equal(t, "park", lines[1]["user"], "나중에 적용한 필드가 우선해야 합니다.")
+			assert.Equal(t, len(lines), strings.Count(captureWriter.String(), `"user"`), "필드는 로그마다 한 번씩만 기록되어야 합니다.")
+		})
+
+		t.Run(string(loggerType)+" 데코레이터가 적용된 로거의 LogAttrs 가 엔트리에 남기지 않는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(loggerType,
+				options.WithOutput(captureWriter),
+				options.WithMasking(masking.Rules{"phone": masking.Mobile}),
+			)
+
+			// when
+			logger.LogAttrs(log, types.Info, "paid", options.String("phone", "01012345678"), options.Int64("amount", 15000))
+			log.Info(options.WithMessage("next"))
+
+			// then
+			lines := captureWriter.Lines()
+			require.Len(t, lines, 2)
+			assert.Equal(t, "0101***5678", lines[0]["phone"])
+			assert.Equal(t, float64(15000), lines[0]["amount"])
+			assert.Equal(t, "next", lines[1][types.MessageField])
+			assert.NotContains(t, lines[1], "phone", "LogAttrs 의 필드는 엔트리에 남지 않아야 합니다.")
+			assert.NotContains(t, lines[1], "amount")
+		})
+
+		t.Run(string(loggerType)+" 로거가 타입이 지정된 필드를 마스킹하는지 테스트", func(t *testing.T) {
+			// given
+			captureWriter := &captureWriter{}
+			log := logger.NewWrapper(loggerType,
+				options.WithOutput(captureWriter),
+				options.WithMasking(masking.Rules{"phone": masking.Mobile, "card": masking.Password}),
+			)
+
+			// when
+			log.Info(options.WithAttrs(
+				options.String("phone", "01012345678"),
+				options.Int64("card", 1234),
+				options.Object("user", options.String("phone", "01012345678"), options.Int("age", 30)),
+			))
+
+			// then
+			line := captureWriter.Map()
+			assert.Equal(t, "0101***5678", line["phone"])
+			assert.Equal(t, masking.Mask(masking.Password, "1234"), line["card"])
+			assert.Equal(t, map[string]interface{}{"phone": "0101***5678", "age": float64(30)}, line["user"])
+		})
+	}
+
+	t.Run("샘플링 키 필드에 타입이 지정된 필드를 사용하는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog,
+			options.WithOutput(captureWriter),
+			options.WithSampling(options.Sampling{
+				Interval:      time.Minute,
+				Levels:        map[types.LogLevel]options.SamplingPolicy{types.Info: {First: 1}},
+				KeyFields:     []string{"route"},
+				DisableReport: true,
+			}),
+		)
+
+		// when
+		for _, route := range []string{"/a", "/a", "/b"} {
+			log.Clone().Info(options.WithMessage("request"), options.WithAttrs(options.String("route", route)))
+		}
+
+		// then
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 2, "키 필드 값이 다른 로그는 따로 샘플링되어야 합니다.")
+		assert.Equal(t, "/a", lines[0]["route"])
+		assert.Equal(t, "/b", lines[1]["route"])
+	})
+}
+
+// readLines : 파일에 기록된 JSON 로그를 줄 단위로 읽는 함수
+func readLines(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var lines []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		var m map[string]interface{}
+		require.NoError(t, json.Unmarshal(line, &m))
+		lines = append(lines, m)
+	}
+	return lines
 }
 
 type captureWriter struct {
@@ -221,11 +2256,27 @@ func (w *captureWriter) String() string {
 	return w.buf.String()
 }
 
+func (w *captureWriter) Lines() []map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var lines []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(w.buf.Bytes()), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var m map[string]interface{}
+		json.Unmarshal(line, &m)
+		lines = append(lines, m)
+	}
+	return lines
+}
+
 func (w *captureWriter) Map() map[string]interface{} {
 	w.mu.Lock()
 	defer w.mu.Unlock()
-	str := w.buf.String()
+	// 마지막으로 기록된 로그 라인을 반환
+	lines := bytes.Split(bytes.TrimSpace(w.buf.Bytes()), []byte("\n"))
 	var m map[string]interface{}
-	json.Unmarshal([]byte(str), &m)
+	json.Unmarshal(lines[len(lines)-1], &m)
 	return m
 }
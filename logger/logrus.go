@@ -2,6 +2,7 @@ package logger
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/wjddn3711/structured-logger/logger/options"
@@ -17,14 +18,34 @@ func init() {
 type logrusLogger struct {
 	logger *logrus.Logger
 	entry  *logrus.Entry
+	// common : 공통 필드만 가진 엔트리 (cloneCommon)
+	common *logrus.Entry
 	ctx    context.Context
+	caller bool
+	// timeFormat : 타입이 지정된 시각 필드(options.Time)의 포맷
+	timeFormat string
+	// metrics : 다음 로그 하나에만 기록할 EMF 메트릭 선언 (엔트리에 남기지 않음)
+	metrics *options.Metrics
+	// at, callerAt : 다음 로그 하나에만 적용할 시각과 호출 위치 (options.WithTime, options.WithCallerAt)
+	at       time.Time
+	callerAt string
 }
 
 func newLogrusLogger(settings options.LogSetting) Logger {
 	logger := logrus.New()
-	logger.SetFormatter(&logrus.JSONFormatter{
-		TimestampFormat: settings.TimeFormat,
-	})
+	if settings.Format == types.Text {
+		logger.SetFormatter(&logrus.TextFormatter{
+			TimestampFormat: settings.TimeFormat,
+			FullTimestamp:   true,
+			DisableColors:   true,
+		})
+	} else if encoder := newLineEncoder(settings); encoder != nil {
+		logger.SetFormatter(&encodedFormatter{timeFormat: settings.TimeFormat, encoder: encoder})
+	} else {
+		logger.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: settings.TimeFormat,
+		})
+	}
 
 	// 로그 레벨 설정
 	switch settings.Level {
@@ -44,7 +65,14 @@ func newLogrusLogger(settings options.LogSetting) Logger {
 	// 로그 출력 설정
 	logger.SetOutput(settings.Output)
 
-	return &logrusLogger{logger: logger, entry: logger.WithFields(logrus.Fields{})}
+	entry := logger.WithFields(logrus.Fields{})
+	return &logrusLogger{
+		logger:     logger,
+		entry:      entry,
+		common:     entry,
+		caller:     settings.Caller,
+		timeFormat: settings.TimeFormat,
+	}
 }
 
 // AddHook : 로거에 후크를 추가하는 메서드
@@ -55,6 +83,20 @@ func (l *logrusLogger) AddHook(hook interface{}) {
 	}
 }
 
+// Clone : 공통 필드와 등록된 엔트리를 복사한 새 로거를 반환하는 메서드
+func (l *logrusLogger) Clone() Logger {
+	clone := *l
+	return &clone
+}
+
+// cloneCommon : 공통 필드만 복사하고 엔트리는 비운 새 로거를 반환하는 메서드
+func (l *logrusLogger) cloneCommon() Logger {
+	clone := *l
+	clone.entry = l.common
+	clone.metrics, clone.at, clone.callerAt = nil, time.Time{}, ""
+	return &clone
+}
+
 // ApplyOption : 로그 엔트리 옵션을 적용하는 메서드
 func (l *logrusLogger) ApplyOption(opts []options.EntryOption) {
 	entryOpt := &options.Entry{}
@@ -69,6 +111,18 @@ func (l *logrusLogger) ApplyOption(opts []options.EntryOption) {
 	if entryOpt.Fields != nil {
 		l.entry = l.entry.WithFields(logrus.Fields(entryOpt.Fields.ToFields()))
 	}
+	if len(entryOpt.Attrs) > 0 {
+		l.entry = l.entry.WithFields(l.fields(entryOpt.Attrs))
+	}
+	if entryOpt.Metrics != nil {
+		l.metrics = entryOpt.Metrics
+	}
+	if !entryOpt.Time.IsZero() {
+		l.at = entryOpt.Time
+	}
+	if entryOpt.Caller != "" {
+		l.callerAt = entryOpt.Caller
+	}
 }
 
 // WithContext : 컨텍스트에 로거를 등록하는 메서드
@@ -79,39 +133,144 @@ func (l *logrusLogger) WithContext(ctx context.Context) context.Context {
 // RegisterCommonField : 로거에 공통 필드를 등록하는 메서드
 func (l *logrusLogger) RegisterCommonField(key string, value interface{}) {
 	l.entry = l.entry.WithField(key, value)
+	l.common = l.common.WithField(key, value)
 }
 
 // RegisterCommonFields : 로거에 공통 필드들을 등록하는 메서드
 func (l *logrusLogger) RegisterCommonFields(entry options.LogEntry) {
-	l.entry = l.entry.WithFields(logrus.Fields(entry.ToFields()))
+	fields := logrus.Fields(entry.ToFields())
+	l.entry = l.entry.WithFields(fields)
+	l.common = l.common.WithFields(fields)
 }
 
 // Debug : 디버그 로그를 출력하는 메서드
 func (l *logrusLogger) Debug(opts ...options.EntryOption) {
-	l.ApplyOption(opts)
-	l.entry.Debug()
+	l.log(logrus.DebugLevel, opts)
 }
 
 // Info : 정보 로그를 출력하는 메서드
 func (l *logrusLogger) Info(opts ...options.EntryOption) {
-	l.ApplyOption(opts)
-	l.entry.Info()
+	l.log(logrus.InfoLevel, opts)
 }
 
 // Warn : 경고 로그를 출력하는 메서드
 func (l *logrusLogger) Warn(opts ...options.EntryOption) {
-	l.ApplyOption(opts)
-	l.entry.Warn()
+	l.log(logrus.WarnLevel, opts)
 }
 
 // Error : 에러 로그를 출력하는 메서드
 func (l *logrusLogger) Error(opts ...options.EntryOption) {
-	l.ApplyOption(opts)
-	l.entry.Error()
+	l.log(logrus.ErrorLevel, opts)
 }
 
 // Fatal : 치명적인 에러 로그를 출력하는 메서드
 func (l *logrusLogger) Fatal(opts ...options.EntryOption) {
+	l.log(logrus.FatalLevel, opts)
+}
+
+// log : 엔트리 옵션을 적용하여 로그를 출력하는 메서드
+//
+// 비활성 레벨이면 옵션을 적용하지 않고 바로 반환한다.
+func (l *logrusLogger) log(level logrus.Level, opts []options.EntryOption) {
+	if !l.logger.IsLevelEnabled(level) {
+		return
+	}
 	l.ApplyOption(opts)
-	l.entry.Fatal()
+	if level == logrus.FatalLevel {
+		l.current().Fatal()
+		return
+	}
+	l.current().Log(level)
+}
+
+// logAttrs : 엔트리에 남기지 않고 메시지와 타입이 지정된 필드를 출력하는 메서드 (LogAttrs 참고)
+func (l *logrusLogger) logAttrs(level types.LogLevel, msg string, attrs []options.Attr) {
+	logrusLevel := logrusLevel(level)
+	if !l.logger.IsLevelEnabled(logrusLevel) {
+		return
+	}
+	fields := l.fields(attrs)
+	if msg != "" {
+		fields[types.MessageField] = msg
+	}
+	entry := l.entry.WithFields(fields)
+	if l.caller {
+		entry = entry.WithField(types.CallerField, caller())
+	}
+	if logrusLevel == logrus.FatalLevel {
+		entry.Fatal()
+		return
+	}
+	entry.Log(logrusLevel)
+}
+
+// logrusLevel : 로그 레벨에 해당하는 logrus 레벨을 반환하는 함수 (알 수 없는 레벨은 info)
+func logrusLevel(level types.LogLevel) logrus.Level {
+	switch level {
+	case types.Debug:
+		return logrus.DebugLevel
+	case types.Warn:
+		return logrus.WarnLevel
+	case types.Error:
+		return logrus.ErrorLevel
+	case types.Fatal:
+		return logrus.FatalLevel
+	default:
+		return logrus.InfoLevel
+	}
+}
+
+// current : 호출 위치 등 공통 항목을 추가한 출력용 엔트리를 반환하는 메서드
+//
+// ApplyOption 으로 받은 메트릭 선언, 시각, 호출 위치는 이 엔트리에만 추가하고 지운다.
+func (l *logrusLogger) current() *logrus.Entry {
+	entry := l.entry
+	if l.metrics != nil {
+		entry = entry.WithField(types.EMFField, l.metrics)
+		l.metrics = nil
+	}
+	if !l.at.IsZero() {
+		entry = entry.WithTime(l.at)
+		l.at = time.Time{}
+	}
+	callerAt := l.callerAt
+	l.callerAt = ""
+	if l.caller {
+		if callerAt == "" {
+			callerAt = caller()
+		}
+		entry = entry.WithField(types.CallerField, callerAt)
+	}
+	return entry
+}
+
+// fields : 타입이 지정된 필드를 logrus 필드로 바꾸는 메서드 (시각은 로거의 시간 포맷 문자열)
+func (l *logrusLogger) fields(attrs []options.Attr) logrus.Fields {
+	fields := make(logrus.Fields, len(attrs))
+	for _, attr := range attrs {
+		switch attr.Kind() {
+		case options.AttrError:
+			if attr.Omitted() {
+				continue
+			}
+			fields[attr.Key] = attr.Value()
+		case options.AttrTime:
+			fields[attr.Key] = attr.Time().Format(l.timeFormat)
+		case options.AttrObject:
+			fields[attr.Key] = map[string]interface{}(l.fields(attr.Attrs()))
+		case options.AttrArray:
+			if times, ok := attr.Any().([]time.Time); ok {
+				values := make([]string, len(times))
+				for i, t := range times {
+					values[i] = t.Format(l.timeFormat)
+				}
+				fields[attr.Key] = values
+				continue
+			}
+			fields[attr.Key] = attr.Value()
+		default:
+			fields[attr.Key] = attr.Value()
+		}
+	}
+	return fields
 }
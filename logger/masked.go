@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/wjddn3711/structured-logger/logger/masking"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// maskedLogger : 공통 필드와 엔트리 필드를 규칙에 따라 마스킹한 뒤 백엔드 로거로 전달하는 데코레이터
+type maskedLogger struct {
+	Logger
+	loggerType types.LoggerType
+	rules      masking.Rules
+}
+
+func newMaskedLogger(logger Logger, loggerType types.LoggerType, rules masking.Rules) Logger {
+	return &maskedLogger{Logger: logger, loggerType: loggerType, rules: rules}
+}
+
+// WithContext : 컨텍스트에 로거를 등록하는 메서드
+func (l *maskedLogger) WithContext(ctx context.Context) context.Context {
+	key, _ := contextKey(l.loggerType)
+	return context.WithValue(ctx, key, l)
+}
+
+// Clone : 마스킹 규칙을 공유하는 새 로거를 반환하는 메서드
+func (l *maskedLogger) Clone() Logger {
+	return &maskedLogger{Logger: l.Logger.Clone(), loggerType: l.loggerType, rules: l.rules}
+}
+
+// cloneCommon : 공통 필드만 복사하고 엔트리는 비운 새 로거를 반환하는 메서드
+func (l *maskedLogger) cloneCommon() Logger {
+	return &maskedLogger{Logger: cloneCommon(l.Logger), loggerType: l.loggerType, rules: l.rules}
+}
+
+// RegisterCommonField : 값을 마스킹하여 공통 필드를 등록하는 메서드
+func (l *maskedLogger) RegisterCommonField(key string, value interface{}) {
+	l.Logger.RegisterCommonField(key, masking.Fields(map[string]interface{}{key: value}, l.rules)[key])
+}
+
+// RegisterCommonFields : 값을 마스킹하여 공통 필드들을 등록하는 메서드
+func (l *maskedLogger) RegisterCommonFields(fields options.LogEntry) {
+	l.Logger.RegisterCommonFields(options.Fields(masking.Fields(fields.ToFields(), l.rules)))
+}
+
+// ApplyOption : 필드를 마스킹하여 로그 엔트리 옵션을 적용하는 메서드
+func (l *maskedLogger) ApplyOption(opts []options.EntryOption) {
+	l.Logger.ApplyOption(l.mask(opts))
+}
+
+// Debug : 디버그 로그를 출력하는 메서드
+func (l *maskedLogger) Debug(opts ...options.EntryOption) {
+	l.Logger.Debug(l.mask(opts)...)
+}
+
+// Info : 정보 로그를 출력하는 메서드
+func (l *maskedLogger) Info(opts ...options.EntryOption) {
+	l.Logger.Info(l.mask(opts)...)
+}
+
+// Warn : 경고 로그를 출력하는 메서드
+func (l *maskedLogger) Warn(opts ...options.EntryOption) {
+	l.Logger.Warn(l.mask(opts)...)
+}
+
+// Error : 에러 로그를 출력하는 메서드
+func (l *maskedLogger) Error(opts ...options.EntryOption) {
+	l.Logger.Error(l.mask(opts)...)
+}
+
+// Fatal : 치명적인 에러 로그를 출력하는 메서드
+func (l *maskedLogger) Fatal(opts ...options.EntryOption) {
+	l.Logger.Fatal(l.mask(opts)...)
+}
+
+// mask : 엔트리 필드를 마스킹한 옵션으로 바꾸는 메서드
+func (l *maskedLogger) mask(opts []options.EntryOption) []options.EntryOption {
+	entry := options.NewEntry(opts...)
+	if entry.Fields != nil {
+		opts = append(opts, options.WithFields(options.Fields(masking.Fields(entry.Fields.ToFields(), l.rules))))
+	}
+	if len(entry.Attrs) > 0 {
+		attrs := maskAttrs(entry.Attrs, l.rules)
+		opts = append(opts, func(entry *options.Entry) { entry.Attrs = attrs })
+	}
+	return opts
+}
+
+// maskAttrs : 규칙에 해당하는 키의 값을 마스킹한 타입 지정 필드 목록을 반환하는 함수 (객체는 하위 필드까지)
+func maskAttrs(attrs []options.Attr, rules masking.Rules) []options.Attr {
+	masked := make([]options.Attr, len(attrs))
+	for i, attr := range attrs {
+		kind, ok := rules[attr.Key]
+		switch {
+		case attr.Omitted():
+			masked[i] = attr
+		case ok && attr.Kind() == options.AttrString:
+			masked[i] = options.String(attr.Key, masking.Mask(kind, attr.Str()))
+		case !ok && attr.Kind() == options.AttrObject:
+			masked[i] = options.Object(attr.Key, maskAttrs(attr.Attrs(), rules)...)
+		case ok || attr.Kind() == options.AttrAny:
+			// 문자열이 아닌 값과 타입을 알 수 없는 값은 맵 필드와 같은 규칙으로 마스킹한다.
+			masked[i] = options.Any(attr.Key, masking.Fields(map[string]interface{}{attr.Key: attr.Value()}, rules)[attr.Key])
+		default:
+			masked[i] = attr
+		}
+	}
+	return masked
+}
@@ -0,0 +1,184 @@
+package masking
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ggwhite/go-masker"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.Config{EscapeHTML: false, UseNumber: true, SortMapKeys: true}.Froze()
+
+// Kind : 마스킹 종류, go-masker 의 mask 태그 값과 같음
+//
+// Example:
+//
+//	// 휴대폰 번호 마스킹
+//	kind := masking.Mobile
+//	// 전체 마스킹
+//	kind := masking.Password
+type Kind string
+
+const (
+	// Password : 전체 마스킹 (알 수 없는 종류도 전체 마스킹)
+	Password Kind = "password"
+	// Name : 이름 마스킹
+	Name Kind = "name"
+	// Address : 주소 마스킹
+	Address Kind = "addr"
+	// Email : 이메일 마스킹
+	Email Kind = "email"
+	// Mobile : 휴대폰 번호 마스킹
+	Mobile Kind = "mobile"
+	// Telephone : 전화 번호 마스킹
+	Telephone Kind = "tel"
+	// ID : 아이디 마스킹
+	ID Kind = "id"
+	// CreditCard : 카드 번호 마스킹
+	CreditCard Kind = "credit"
+	// URL : URL 의 비밀번호 마스킹
+	URL Kind = "url"
+)
+
+// Valid : 지원하는 마스킹 종류인지 반환하는 메서드
+func (k Kind) Valid() bool {
+	switch k {
+	case Password, Name, Address, Email, Mobile, Telephone, ID, CreditCard, URL:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rules : 키(필드, 쿼리 파라미터, 헤더 등) 별 마스킹 종류
+//
+// Example:
+//
+//	rules := masking.Rules{"token": masking.Password, "phone": masking.Mobile}
+type Rules map[string]Kind
+
+// Mask : 값을 마스킹 종류에 맞게 마스킹하는 함수
+//
+// Example:
+//
+//	masking.Mask(masking.Mobile, "01012345678") // "0101***5678"
+func Mask(kind Kind, value string) string {
+	switch kind {
+	case Name:
+		return masker.String(masker.MName, value)
+	case Address:
+		return masker.String(masker.MAddress, value)
+	case Email:
+		return masker.String(masker.MEmail, value)
+	case Mobile:
+		return masker.String(masker.MMobile, value)
+	case Telephone:
+		return masker.String(masker.MTelephone, value)
+	case ID:
+		return masker.String(masker.MID, value)
+	case CreditCard:
+		return masker.String(masker.MCreditCard, value)
+	case URL:
+		return masker.String(masker.MURL, value)
+	default:
+		return masker.String(masker.MPassword, value)
+	}
+}
+
+// Query : URL 쿼리 문자열에서 규칙에 해당하는 파라미터 값을 마스킹하는 함수
+//   - 파라미터 순서는 유지되며, 규칙에 없는 파라미터는 그대로 남는다.
+//
+// Example:
+//
+//	masking.Query("token=abcd&page=1", masking.Rules{"token": masking.Password}) // "token=****&page=1"
+func Query(rawQuery string, rules Rules) string {
+	if rawQuery == "" || len(rules) == 0 {
+		return rawQuery
+	}
+
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		key, value, found := strings.Cut(param, "=")
+		kind, ok := rules[key]
+		if !ok || !found {
+			continue
+		}
+		params[i] = key + "=" + Mask(kind, value)
+	}
+	return strings.Join(params, "&")
+}
+
+// JSON : JSON 문서에서 규칙에 해당하는 키의 값을 마스킹하는 함수
+//   - 중첩된 객체와 배열 안의 키도 마스킹하며, 문자열이 아닌 값은 문자열로 바꾸어 마스킹한다.
+//   - JSON 이 아닌 경우 false 를 반환한다.
+//
+// Example:
+//
+//	masking.JSON([]byte(`{"user":{"phone":"01012345678"}}`), masking.Rules{"phone": masking.Mobile})
+//	// {"user":{"phone":"0101***5678"}}, true
+func JSON(data []byte, rules Rules) ([]byte, bool) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, false
+	}
+	if len(rules) == 0 {
+		return data, true
+	}
+
+	masked, err := json.Marshal(maskValue(doc, rules))
+	if err != nil {
+		return nil, false
+	}
+	return masked, true
+}
+
+// Fields : 필드 맵에서 규칙에 해당하는 키의 값을 마스킹한 새 맵을 반환하는 함수
+//   - 중첩된 맵과 배열 안의 키도 마스킹하며, 원래 맵은 변경하지 않는다.
+//
+// Example:
+//
+//	masking.Fields(map[string]interface{}{"phone": "01012345678", "page": 1}, masking.Rules{"phone": masking.Mobile})
+//	// map[page:1 phone:0101***5678]
+func Fields(fields map[string]interface{}, rules Rules) map[string]interface{} {
+	if len(rules) == 0 || fields == nil {
+		return fields
+	}
+	return maskValue(fields, rules).(map[string]interface{})
+}
+
+// maskValue : 객체와 배열을 따라가며 규칙에 해당하는 키의 값을 마스킹한 복사본을 반환하는 함수
+func maskValue(v interface{}, rules Rules) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if kind, ok := rules[key]; ok && value != nil {
+				masked[key] = maskScalar(kind, value)
+				continue
+			}
+			masked[key] = maskValue(value, rules)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, value := range v {
+			masked[i] = maskValue(value, rules)
+		}
+		return masked
+	default:
+		return v
+	}
+}
+
+// maskScalar : 값을 문자열로 바꾸어 마스킹하는 함수 (객체나 배열은 전체 마스킹)
+func maskScalar(kind Kind, v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return Mask(kind, v)
+	case map[string]interface{}, []interface{}:
+		return Mask(Password, "-")
+	default:
+		return Mask(kind, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,361 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// MessagePack 형식 바이트 (fixint, fixmap, fixarray, fixstr 는 범위로 판별)
+const (
+	msgpackNil     byte = 0xc0
+	msgpackFalse   byte = 0xc2
+	msgpackTrue    byte = 0xc3
+	msgpackBin8    byte = 0xc4
+	msgpackBin16   byte = 0xc5
+	msgpackBin32   byte = 0xc6
+	msgpackExt8    byte = 0xc7
+	msgpackExt16   byte = 0xc8
+	msgpackExt32   byte = 0xc9
+	msgpackFloat32 byte = 0xca
+	msgpackFloat64 byte = 0xcb
+	msgpackUint8   byte = 0xcc
+	msgpackUint16  byte = 0xcd
+	msgpackUint32  byte = 0xce
+	msgpackUint64  byte = 0xcf
+	msgpackInt8    byte = 0xd0
+	msgpackInt16   byte = 0xd1
+	msgpackInt32   byte = 0xd2
+	msgpackInt64   byte = 0xd3
+	msgpackFixExt1 byte = 0xd4
+	msgpackStr8    byte = 0xd9
+	msgpackStr16   byte = 0xda
+	msgpackStr32   byte = 0xdb
+	msgpackArray16 byte = 0xdc
+	msgpackArray32 byte = 0xdd
+	msgpackMap16   byte = 0xde
+	msgpackMap32   byte = 0xdf
+
+	// msgpackExtTimestamp : 타임스탬프 확장 타입
+	msgpackExtTimestamp int8 = -1
+)
+
+// msgpackWriter : MessagePack 값을 기록하는 binaryWriter
+//
+// 길이와 숫자는 가장 짧은 형식으로, 정수로 표현할 수 없는 숫자는 float64 로 기록한다.
+type msgpackWriter struct{}
+
+func (msgpackWriter) null(buf *bytes.Buffer) {
+	buf.WriteByte(msgpackNil)
+}
+
+func (msgpackWriter) boolean(buf *bytes.Buffer, v bool) {
+	if v {
+		buf.WriteByte(msgpackTrue)
+	} else {
+		buf.WriteByte(msgpackFalse)
+	}
+}
+
+func (msgpackWriter) int(buf *bytes.Buffer, n int64) {
+	var b [9]byte
+	switch {
+	case n >= 0 && n <= math.MaxInt8:
+		buf.WriteByte(byte(n))
+	case n >= -32 && n < 0:
+		buf.WriteByte(byte(int8(n)))
+	case n > 0:
+		msgpackWriter{}.uint(buf, uint64(n))
+	case n >= math.MinInt8:
+		b[0], b[1] = msgpackInt8, byte(int8(n))
+		buf.Write(b[:2])
+	case n >= math.MinInt16:
+		b[0] = msgpackInt16
+		binary.BigEndian.PutUint16(b[1:], uint16(int16(n)))
+		buf.Write(b[:3])
+	case n >= math.MinInt32:
+		b[0] = msgpackInt32
+		binary.BigEndian.PutUint32(b[1:], uint32(int32(n)))
+		buf.Write(b[:5])
+	default:
+		b[0] = msgpackInt64
+		binary.BigEndian.PutUint64(b[1:], uint64(n))
+		buf.Write(b[:])
+	}
+}
+
+func (msgpackWriter) uint(buf *bytes.Buffer, n uint64) {
+	var b [9]byte
+	switch {
+	case n <= math.MaxInt8:
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint8:
+		b[0], b[1] = msgpackUint8, byte(n)
+		buf.Write(b[:2])
+	case n <= math.MaxUint16:
+		b[0] = msgpackUint16
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		buf.Write(b[:3])
+	case n <= math.MaxUint32:
+		b[0] = msgpackUint32
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		buf.Write(b[:5])
+	default:
+		b[0] = msgpackUint64
+		binary.BigEndian.PutUint64(b[1:], n)
+		buf.Write(b[:])
+	}
+}
+
+func (msgpackWriter) float(buf *bytes.Buffer, f float64) {
+	var b [9]byte
+	b[0] = msgpackFloat64
+	binary.BigEndian.PutUint64(b[1:], math.Float64bits(f))
+	buf.Write(b[:])
+}
+
+func (msgpackWriter) strHead(buf *bytes.Buffer, n int) {
+	if n >= 32 && n <= math.MaxUint8 {
+		buf.WriteByte(msgpackStr8)
+		buf.WriteByte(byte(n))
+		return
+	}
+	writeMsgPackHead(buf, 0xa0, msgpackStr16, msgpackStr32, 32, n)
+}
+
+func (msgpackWriter) arrayHead(buf *bytes.Buffer, n int) {
+	writeMsgPackHead(buf, 0x90, msgpackArray16, msgpackArray32, 16, n)
+}
+
+func (msgpackWriter) mapHead(buf *bytes.Buffer, n int) {
+	writeMsgPackHead(buf, 0x80, msgpackMap16, msgpackMap32, 16, n)
+}
+
+// writeMsgPackHead : 문자열, 배열, 맵의 길이를 가장 짧은 형태로 기록하는 함수
+//   - fix(byte): fixstr, fixarray, fixmap 의 시작 바이트
+//   - fixLimit(int): fix 형식으로 기록할 수 있는 길이의 상한 (미포함)
+func writeMsgPackHead(buf *bytes.Buffer, fix, head16, head32 byte, fixLimit, n int) {
+	var b [5]byte
+	switch {
+	case n < fixLimit:
+		buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint16:
+		b[0] = head16
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		buf.Write(b[:3])
+	default:
+		b[0] = head32
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		buf.Write(b[:])
+	}
+}
+
+// msgpackDecoder : MessagePack 값을 JSON 으로 표현할 수 있는 값으로 디코딩하는 구조체 (숫자는 json.Number)
+//
+// cborDecoder 와 같은 형태의 값을 반환하며, 타임스탬프 확장 타입은 시간 포맷의 문자열로 변환한다.
+type msgpackDecoder struct {
+	r          cborReader
+	timeFormat string
+}
+
+// newMsgPackDecoder : msgpackDecoder 생성자
+//   - r(io.Reader): MessagePack 스트림
+//   - timeFormat(string): 타임스탬프 확장 타입을 변환할 시간 포맷
+func newMsgPackDecoder(r io.Reader, timeFormat string) *msgpackDecoder {
+	reader, ok := r.(cborReader)
+	if !ok {
+		reader = bufio.NewReader(r)
+	}
+	return &msgpackDecoder{r: reader, timeFormat: timeFormat}
+}
+
+// decode : 다음 값을 디코딩하는 메서드, 스트림이 끝나면 io.EOF 를 반환한다.
+func (d *msgpackDecoder) decode() (interface{}, error) {
+	if _, err := d.r.ReadByte(); err != nil {
+		return nil, err
+	}
+	_ = d.r.UnreadByte()
+	value, err := d.item()
+	if errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return value, err
+}
+
+func (d *msgpackDecoder) item() (interface{}, error) {
+	b, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case b <= 0x7f:
+		return stdjson.Number(strconv.Itoa(int(b))), nil
+	case b >= 0xe0:
+		return stdjson.Number(strconv.Itoa(int(int8(b)))), nil
+	case b&0xf0 == 0x80:
+		return d.dict(int(b & 0x0f))
+	case b&0xf0 == 0x90:
+		return d.array(int(b & 0x0f))
+	case b&0xe0 == 0xa0:
+		return d.str(int(b & 0x1f))
+	}
+
+	switch b {
+	case msgpackNil:
+		return nil, nil
+	case msgpackFalse:
+		return false, nil
+	case msgpackTrue:
+		return true, nil
+	case msgpackUint8, msgpackUint16, msgpackUint32, msgpackUint64:
+		n, err := d.uint(1 << (b - msgpackUint8))
+		return stdjson.Number(strconv.FormatUint(n, 10)), err
+	case msgpackInt8, msgpackInt16, msgpackInt32, msgpackInt64:
+		size := 1 << (b - msgpackInt8)
+		n, err := d.uint(size)
+		shift := 64 - 8*size
+		return stdjson.Number(strconv.FormatInt(int64(n<<shift)>>shift, 10)), err
+	case msgpackFloat32:
+		bits, err := d.uint(4)
+		return cborFloat(float64(math.Float32frombits(uint32(bits)))), err
+	case msgpackFloat64:
+		bits, err := d.uint(8)
+		return cborFloat(math.Float64frombits(bits)), err
+	case msgpackStr8, msgpackStr16, msgpackStr32:
+		n, err := d.length(1 << (b - msgpackStr8))
+		if err != nil {
+			return nil, err
+		}
+		return d.str(n)
+	case msgpackBin8, msgpackBin16, msgpackBin32:
+		n, err := d.length(1 << (b - msgpackBin8))
+		if err != nil {
+			return nil, err
+		}
+		return d.bytes(n)
+	case msgpackArray16, msgpackArray32:
+		n, err := d.length(2 << (b - msgpackArray16))
+		if err != nil {
+			return nil, err
+		}
+		return d.array(n)
+	case msgpackMap16, msgpackMap32:
+		n, err := d.length(2 << (b - msgpackMap16))
+		if err != nil {
+			return nil, err
+		}
+		return d.dict(n)
+	case msgpackExt8, msgpackExt16, msgpackExt32:
+		n, err := d.length(1 << (b - msgpackExt8))
+		if err != nil {
+			return nil, err
+		}
+		return d.ext(n)
+	}
+	if b >= msgpackFixExt1 && b <= msgpackFixExt1+4 {
+		return d.ext(1 << (b - msgpackFixExt1))
+	}
+	return nil, fmt.Errorf("msgpack: invalid format byte 0x%02x", b)
+}
+
+// uint : size 바이트의 빅 엔디언 부호 없는 정수를 읽는 메서드
+func (d *msgpackDecoder) uint(size int) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(d.r, b[8-size:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// length : size 바이트로 기록된 길이를 읽는 메서드
+func (d *msgpackDecoder) length(size int) (int, error) {
+	n, err := d.uint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > cborMaxLength {
+		return 0, fmt.Errorf("msgpack: length %d exceeds limit", n)
+	}
+	return int(n), nil
+}
+
+func (d *msgpackDecoder) bytes(n int) ([]byte, error) {
+	data := make([]byte, n)
+	_, err := io.ReadFull(d.r, data)
+	return data, err
+}
+
+func (d *msgpackDecoder) str(n int) (string, error) {
+	data, err := d.bytes(n)
+	return string(data), err
+}
+
+func (d *msgpackDecoder) array(n int) ([]interface{}, error) {
+	items := make([]interface{}, 0, min(n, 1024))
+	for i := 0; i < n; i++ {
+		item, err := d.item()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// dict : 맵을 읽는 메서드, 문자열이 아닌 키는 문자열로 바꾼다.
+func (d *msgpackDecoder) dict(n int) (map[string]interface{}, error) {
+	fields := make(map[string]interface{}, min(n, 1024))
+	for i := 0; i < n; i++ {
+		key, err := d.item()
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.item()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			name = fmt.Sprint(key)
+		}
+		fields[name] = value
+	}
+	return fields, nil
+}
+
+// ext : 확장 타입을 읽는 메서드, 타임스탬프가 아닌 확장 타입은 데이터를 바이트 문자열로 반환한다.
+func (d *msgpackDecoder) ext(n int) (interface{}, error) {
+	kind, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	data, err := d.bytes(n)
+	if err != nil {
+		return nil, err
+	}
+	if int8(kind) != msgpackExtTimestamp {
+		return data, nil
+	}
+
+	var t time.Time
+	switch n {
+	case 4:
+		t = time.Unix(int64(binary.BigEndian.Uint32(data)), 0)
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		t = time.Unix(int64(v&(1<<34-1)), int64(v>>34))
+	case 12:
+		t = time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data)))
+	default:
+		return nil, fmt.Errorf("msgpack: invalid timestamp length %d", n)
+	}
+	return t.Format(d.timeFormat), nil
+}
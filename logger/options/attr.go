@@ -0,0 +1,231 @@
+package options
+
+import (
+	"math"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// AttrKind : 타입이 지정된 로그 필드(Attr)의 값 종류
+type AttrKind uint8
+
+const (
+	// AttrAny : 타입을 알 수 없는 값 (백엔드가 리플렉션으로 인코딩)
+	AttrAny AttrKind = iota
+	// AttrString : 문자열
+	AttrString
+	// AttrInt64 : 정수
+	AttrInt64
+	// AttrFloat64 : 실수
+	AttrFloat64
+	// AttrBool : 불리언
+	AttrBool
+	// AttrDuration : 시간 간격 (밀리초 정수로 기록)
+	AttrDuration
+	// AttrTime : 시각 (로거의 시간 포맷으로 기록)
+	AttrTime
+	// AttrError : 에러 (에러 메시지로 기록)
+	AttrError
+	// AttrObject : 하위 필드를 가진 객체
+	AttrObject
+	// AttrArray : 같은 타입 값의 배열
+	AttrArray
+)
+
+// ArrayValue : Array 로 기록할 수 있는 배열 요소 타입
+type ArrayValue interface {
+	string | int | int64 | float64 | bool | time.Duration | time.Time
+}
+
+// Attr : 타입이 지정된 로그 필드
+//
+// 값을 interface{} 로 감싸지 않고 종류별로 보관하므로, 백엔드가 맵을 거치지 않고 타입에 맞게 바로 인코딩한다.
+// String, Int64, Duration, Time, Err, Object, Array 등의 생성 함수로 만들고 WithAttrs 로 전달한다.
+type Attr struct {
+	Key   string
+	kind  AttrKind
+	str   string
+	num   int64
+	time  time.Time
+	attrs []Attr
+	value interface{}
+}
+
+// String : 문자열 필드를 생성하는 함수
+func String(key, value string) Attr {
+	return Attr{Key: key, kind: AttrString, str: value}
+}
+
+// Int64 : 정수 필드를 생성하는 함수
+func Int64(key string, value int64) Attr {
+	return Attr{Key: key, kind: AttrInt64, num: value}
+}
+
+// Int : 정수 필드를 생성하는 함수
+func Int(key string, value int) Attr {
+	return Int64(key, int64(value))
+}
+
+// Float64 : 실수 필드를 생성하는 함수
+func Float64(key string, value float64) Attr {
+	return Attr{Key: key, kind: AttrFloat64, num: int64(math.Float64bits(value))}
+}
+
+// Bool : 불리언 필드를 생성하는 함수
+func Bool(key string, value bool) Attr {
+	attr := Attr{Key: key, kind: AttrBool}
+	if value {
+		attr.num = 1
+	}
+	return attr
+}
+
+// Duration : 시간 간격 필드를 생성하는 함수
+//   - 다른 elapsed 필드와 같이 밀리초 정수로 기록한다.
+//
+// Example:
+//
+//	log.Info(options.WithAttrs(options.Duration(types.ElapsedField, 1500*time.Millisecond)))
+//	// output: {"elapsed":1500,"level":"info"}
+func Duration(key string, value time.Duration) Attr {
+	return Attr{Key: key, kind: AttrDuration, num: int64(value)}
+}
+
+// Time : 시각 필드를 생성하는 함수
+//   - 로거의 시간 포맷(WithTimeFormat)으로 기록한다.
+func Time(key string, value time.Time) Attr {
+	return Attr{Key: key, kind: AttrTime, time: value}
+}
+
+// Err : 에러 메시지를 error 필드로 기록하는 함수
+//   - err 가 nil 이면 필드를 기록하지 않는다.
+func Err(err error) Attr {
+	return Attr{Key: types.ErrorField, kind: AttrError, value: err}
+}
+
+// Object : 하위 필드를 가진 객체 필드를 생성하는 함수
+//
+// Example:
+//
+//	log.Info(options.WithAttrs(options.Object("user", options.String("id", "u-1"), options.Int("age", 30))))
+//	// output: {"level":"info","user":{"age":30,"id":"u-1"}}
+func Object(key string, attrs ...Attr) Attr {
+	return Attr{Key: key, kind: AttrObject, attrs: attrs}
+}
+
+// Array : 같은 타입 값의 배열 필드를 생성하는 함수
+//
+// Example:
+//
+//	log.Info(options.WithAttrs(options.Array("tags", "a", "b")))
+//	// output: {"level":"info","tags":["a","b"]}
+func Array[T ArrayValue](key string, values ...T) Attr {
+	return Attr{Key: key, kind: AttrArray, value: values}
+}
+
+// Any : 타입이 정해지지 않은 값의 필드를 생성하는 함수
+//   - 타입이 정해진 생성 함수가 있으면 그 함수를 사용한다.
+func Any(key string, value interface{}) Attr {
+	return Attr{Key: key, kind: AttrAny, value: value}
+}
+
+// Kind : 값의 종류를 반환하는 메서드
+func (a Attr) Kind() AttrKind {
+	return a.kind
+}
+
+// Str : AttrString 의 값을 반환하는 메서드
+func (a Attr) Str() string {
+	return a.str
+}
+
+// Int64 : AttrInt64 의 값을 반환하는 메서드
+func (a Attr) Int64() int64 {
+	return a.num
+}
+
+// Float64 : AttrFloat64 의 값을 반환하는 메서드
+func (a Attr) Float64() float64 {
+	return math.Float64frombits(uint64(a.num))
+}
+
+// Bool : AttrBool 의 값을 반환하는 메서드
+func (a Attr) Bool() bool {
+	return a.num == 1
+}
+
+// Duration : AttrDuration 의 값을 반환하는 메서드
+func (a Attr) Duration() time.Duration {
+	return time.Duration(a.num)
+}
+
+// Time : AttrTime 의 값을 반환하는 메서드
+func (a Attr) Time() time.Time {
+	return a.time
+}
+
+// Err : AttrError 의 값을 반환하는 메서드
+func (a Attr) Err() error {
+	err, _ := a.value.(error)
+	return err
+}
+
+// Attrs : AttrObject 의 하위 필드를 반환하는 메서드
+func (a Attr) Attrs() []Attr {
+	return a.attrs
+}
+
+// Any : AttrArray 의 값([]T)이나 AttrAny 의 값을 반환하는 메서드
+func (a Attr) Any() interface{} {
+	return a.value
+}
+
+// Value : 값을 맵 필드(Fields)로 기록할 때와 같은 형태로 반환하는 메서드
+//   - 시간 간격은 밀리초 정수, 에러는 에러 메시지(nil 이면 nil), 객체는 map[string]interface{} 로 반환한다.
+func (a Attr) Value() interface{} {
+	switch a.kind {
+	case AttrString:
+		return a.str
+	case AttrInt64:
+		return a.num
+	case AttrFloat64:
+		return a.Float64()
+	case AttrBool:
+		return a.Bool()
+	case AttrDuration:
+		return a.Duration().Milliseconds()
+	case AttrTime:
+		return a.time
+	case AttrError:
+		if err := a.Err(); err != nil {
+			return err.Error()
+		}
+		return nil
+	case AttrObject:
+		fields := make(map[string]interface{}, len(a.attrs))
+		for _, attr := range a.attrs {
+			if attr.Omitted() {
+				continue
+			}
+			fields[attr.Key] = attr.Value()
+		}
+		return fields
+	case AttrArray:
+		if durations, ok := a.value.([]time.Duration); ok {
+			values := make([]int64, len(durations))
+			for i, d := range durations {
+				values[i] = d.Milliseconds()
+			}
+			return values
+		}
+		return a.value
+	default:
+		return a.value
+	}
+}
+
+// Omitted : 기록하지 않는 필드인지 반환하는 메서드 (nil 에러)
+func (a Attr) Omitted() bool {
+	return a.kind == AttrError && a.value == nil
+}
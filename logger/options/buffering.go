@@ -0,0 +1,18 @@
+package options
+
+import "github.com/wjddn3711/structured-logger/logger/types"
+
+// Buffering : 요청 단위 버퍼링("fingers-crossed") 설정
+//   - Trigger(types.LogLevel): 이 레벨 이상의 로그가 기록되면 버퍼를 출력 (default: types.Error)
+//   - PassThrough(types.LogLevel): 이 레벨 이상의 로그는 버퍼링하지 않고 바로 기록 (default: 없음)
+//   - MaxEntries(int): 버퍼에 보관할 최대 엔트리 수, 초과하면 오래된 엔트리부터 버림 (default: 100)
+//
+// Example:
+//
+//	// 에러가 발생한 요청만 앞선 debug/info 로그를 함께 기록, warn 은 항상 기록
+//	options.Buffering{Trigger: types.Error, PassThrough: types.Warn, MaxEntries: 200}
+type Buffering struct {
+	Trigger     types.LogLevel
+	PassThrough types.LogLevel
+	MaxEntries  int
+}
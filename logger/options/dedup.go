@@ -0,0 +1,45 @@
+package options
+
+import (
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// Deduplication : 반복되는 로그 억제 설정
+//   - Levels(map[types.LogLevel]DedupPolicy): 레벨별 억제 정책, 정책이 없는 레벨은 모두 기록
+//   - KeyFields([]string): 메시지와 함께 같은 로그인지 판단하는 데 사용할 필드
+type Deduplication struct {
+	Levels    map[types.LogLevel]DedupPolicy
+	KeyFields []string
+}
+
+// DedupPolicy : 레벨별 반복 로그 억제 정책
+//   - Window(time.Duration): 첫 로그부터 같은 로그를 억제하는 기간
+//   - Burst(int): 기간 내에 그대로 기록할 같은 로그의 수 (default: 1)
+type DedupPolicy struct {
+	Window time.Duration
+	Burst  int
+}
+
+// WithDeduplication 반복되는 로그를 억제하는 옵션
+//
+// 같은 로그(레벨, 메시지, KeyFields 값이 같은 로그)는 기간 내에 Burst 개까지만 바로 기록되고,
+// 기간이 끝나면 억제된 횟수(repeat_count)와 처음/마지막 발생 시각(first_seen, last_seen)을 담은
+// 요약 로그가 원래 로그와 같은 레벨, 메시지, 필드로 기록된다.
+// 종료 시 logger.Close 를 호출하면 끝나지 않은 기간의 요약 로그를 바로 기록한다.
+//
+// Example:
+//
+//	// 같은 에러 로그는 10초에 한 번만 기록
+//	log := logger.NewWrapper(types.ZeroLog, options.WithDeduplication(options.Deduplication{
+//		Levels: map[types.LogLevel]options.DedupPolicy{types.Error: {Window: 10 * time.Second}},
+//	}))
+//	log.Error(options.WithMessage("db timeout"))
+//	// output: {"level":"error","message":"db timeout"}
+//	// 10초 후: {"level":"error","message":"db timeout","repeat_count":4999,"first_seen":"...","last_seen":"..."}
+func WithDeduplication(dedup Deduplication) LogSettingOption {
+	return func(setting *LogSetting) {
+		setting.Deduplication = &dedup
+	}
+}
@@ -1,5 +1,7 @@
 package options
 
+import "time"
+
 // LogEntry 로그 엔트리 필드 타입
 //   - ToFields(): 구조체를 map[string]interface{} 형태로 변환하는 메서드
 type LogEntry interface {
@@ -7,15 +9,46 @@ type LogEntry interface {
 	ToFields() map[string]interface{}
 }
 
+// Fields 맵 형태의 로그 필드 타입
+//   - 구조체를 정의하지 않고 필드를 바로 등록할 때 사용
+//
+// Example:
+//
+//	log.Info(options.WithFields(options.Fields{"rid": "1234", "status_code": 200}))
+type Fields map[string]interface{}
+
+// ToFields : 맵을 그대로 반환하는 메서드
+func (f Fields) ToFields() map[string]interface{} {
+	return f
+}
+
 // EntryOption 로깅을 위한 로그 엔트리 옵션 타입
 type EntryOption func(entry *Entry)
 
 // Entry 로깅을 위한 로그 엔트리 타입
 //   - message(string): 로그 메시지
 //   - fields(LogEntry): 로그 필드 (구조체)
+//   - attrs([]Attr): 타입이 지정된 로그 필드
+//   - metrics(*Metrics): CloudWatch Embedded Metric Format 메트릭 선언
+//   - time(time.Time): 로그 시각 (지정하지 않으면 기록하는 시각)
+//   - caller(string): 호출 위치 (지정하지 않으면 기록하는 위치)
 type Entry struct {
 	Message string
 	Fields  LogEntry
+	Attrs   []Attr
+	Metrics *Metrics
+	Time    time.Time
+	Caller  string
+}
+
+// NewEntry 엔트리 옵션을 적용한 로그 엔트리를 생성하는 함수
+//   - opts(...EntryOption): 로그 엔트리 옵션
+func NewEntry(opts ...EntryOption) *Entry {
+	entry := &Entry{}
+	for _, opt := range opts {
+		opt(entry)
+	}
+	return entry
 }
 
 // WithMessage 로그 메시지를 등록하는 옵션
@@ -51,3 +84,53 @@ func WithFields(fields LogEntry) func(entry *Entry) {
 		entry.Fields = fields
 	}
 }
+
+// WithAttrs 타입이 지정된 로그 필드를 등록하는 옵션
+//   - attrs(...Attr): 로그 필드 (String, Int64, Duration, Time, Err, Object, Array 등으로 생성)
+//
+// 맵을 거치지 않고 백엔드가 타입에 맞게 바로 인코딩하므로, WithFields 보다 할당이 적고 정수가 실수로 바뀌지 않는다.
+// 여러 번 사용하면 필드가 누적되며, 같은 키의 필드는 나중에 등록한 값으로 바뀐다.
+//
+// Example:
+//
+//	log.Info(
+//		options.WithMessage("paid"),
+//		options.WithAttrs(
+//			options.String("order_id", "A-1"),
+//			options.Int64("amount", 15000),
+//			options.Duration(types.ElapsedField, elapsed),
+//			options.Err(err),
+//		),
+//	)
+//	// output: {"amount":15000,"elapsed":12,"error":"card declined","level":"info","message":"paid","order_id":"A-1"}
+func WithAttrs(attrs ...Attr) EntryOption {
+	return func(entry *Entry) {
+		entry.Attrs = append(entry.Attrs, attrs...)
+	}
+}
+
+// WithTime 로그 시각을 지정하는 옵션
+//   - t(time.Time): 로그 시각
+//
+// 보관했다가 나중에 출력하는 로그가 원래 시각으로 기록되도록 할 때 사용한다.
+// 로거의 엔트리에 남지 않고 옵션을 넘긴 로그 하나에만 적용된다.
+//
+// Example:
+//
+//	log.Info(options.WithMessage("queued"), options.WithTime(queuedAt))
+func WithTime(t time.Time) EntryOption {
+	return func(entry *Entry) {
+		entry.Time = t
+	}
+}
+
+// WithCallerAt 호출 위치를 지정하는 옵션
+//   - caller(string): "파일:줄" 형태의 호출 위치
+//
+// 로거가 호출 위치를 기록하도록 설정된 경우(WithCaller)에만 기록되며,
+// 로거의 엔트리에 남지 않고 옵션을 넘긴 로그 하나에만 적용된다.
+func WithCallerAt(caller string) EntryOption {
+	return func(entry *Entry) {
+		entry.Caller = caller
+	}
+}
@@ -0,0 +1,186 @@
+package options
+
+import (
+	"github.com/wjddn3711/structured-logger/logger/masking"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// AccessLogFields : 액세스 로그의 필드 이름, 비어있는 항목은 기본 이름을 사용
+//   - 빈 문자열 대신 "-" 를 지정하면 해당 필드를 기록하지 않음
+type AccessLogFields struct {
+	StartTime    string
+	EndTime      string
+	Elapsed      string
+	StatusCode   string
+	Method       string
+	URI          string
+	Referer      string
+	UserAgent    string
+	RemoteAddr   string
+	BytesWritten string
+	// 아래 필드는 logger.NewRoundTripper 에서만 사용
+	Host            string
+	Path            string
+	Attempt         string
+	RequestBody     string
+	ResponseBody    string
+	RequestHeaders  string
+	ResponseHeaders string
+}
+
+// HTTPLogging : HTTP 로깅 설정 (logger.HTTPMiddleware, logger.NewRoundTripper)
+//   - RequestIDHeader(string): 요청 ID 헤더 (default: X-Request-ID)
+//   - RequestIDField(string): 요청 ID 공통 필드 이름 (default: rid)
+//   - GenerateRequestID(func() string): 요청 ID 가 없을 때 사용할 생성 함수 (default: 랜덤 16바이트 hex)
+//   - Message(string): 액세스 로그 메시지 (default: "http request")
+//   - Fields(AccessLogFields): 액세스 로그 필드 이름
+//   - QueryMasking(masking.Rules): 쿼리 파라미터 마스킹 규칙
+//   - BodyLimit(int): 기록할 요청/응답 본문의 최대 바이트 수 (default: 0, 본문을 기록하지 않음)
+//   - BodyMasking(masking.Rules): 본문(JSON, form) 마스킹 규칙
+//   - CaptureHeaders(bool): 요청/응답 헤더 기록 여부 (default: false)
+//   - ExcludeHeaders([]string): 기록하지 않을 헤더 (default: Authorization, Proxy-Authorization, Cookie, Set-Cookie)
+type HTTPLogging struct {
+	RequestIDHeader   string
+	RequestIDField    string
+	GenerateRequestID func() string
+	Message           string
+	Fields            AccessLogFields
+	QueryMasking      masking.Rules
+	BodyLimit         int
+	BodyMasking       masking.Rules
+	CaptureHeaders    bool
+	ExcludeHeaders    []string
+}
+
+// HTTPOption HTTP 로깅 설정을 위한 옵션 타입
+//   - WithRequestIDHeader: 요청 ID 헤더를 설정하는 옵션
+//   - WithRequestIDField: 요청 ID 공통 필드 이름을 설정하는 옵션
+//   - WithRequestIDGenerator: 요청 ID 생성 함수를 설정하는 옵션
+//   - WithAccessLogMessage: 액세스 로그 메시지를 설정하는 옵션
+//   - WithAccessLogFields: 액세스 로그 필드 이름을 설정하는 옵션
+//   - WithQueryMasking: 쿼리 파라미터 마스킹 규칙을 설정하는 옵션
+//   - WithBodyCapture: 요청/응답 본문 기록을 설정하는 옵션
+//   - WithHeaderCapture: 요청/응답 헤더 기록을 설정하는 옵션
+type HTTPOption func(*HTTPLogging)
+
+// NewHTTPLogging 기본값에 옵션을 적용한 HTTP 로깅 설정을 생성하는 함수
+func NewHTTPLogging(opts ...HTTPOption) HTTPLogging {
+	setting := HTTPLogging{
+		RequestIDHeader: "X-Request-ID",
+		RequestIDField:  types.RequestIDField,
+		Message:         "http request",
+		ExcludeHeaders:  []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"},
+	}
+	for _, opt := range opts {
+		opt(&setting)
+	}
+
+	fields := &setting.Fields
+	defaultName(&fields.StartTime, types.StartTimeField)
+	defaultName(&fields.EndTime, types.EndTimeField)
+	defaultName(&fields.Elapsed, types.ElapsedField)
+	defaultName(&fields.StatusCode, types.StatusCodeField)
+	defaultName(&fields.Method, types.MethodField)
+	defaultName(&fields.URI, types.URIField)
+	defaultName(&fields.Referer, types.RefererField)
+	defaultName(&fields.UserAgent, types.UserAgentField)
+	defaultName(&fields.RemoteAddr, types.RemoteAddrField)
+	defaultName(&fields.BytesWritten, types.BytesWrittenField)
+	defaultName(&fields.Host, types.HostField)
+	defaultName(&fields.Path, types.PathField)
+	defaultName(&fields.Attempt, types.AttemptField)
+	defaultName(&fields.RequestBody, types.RequestBodyField)
+	defaultName(&fields.ResponseBody, types.ResponseBodyField)
+	defaultName(&fields.RequestHeaders, types.RequestHeadersField)
+	defaultName(&fields.ResponseHeaders, types.ResponseHeadersField)
+	return setting
+}
+
+func defaultName(name *string, value string) {
+	if *name == "" {
+		*name = value
+	}
+}
+
+// WithRequestIDHeader 요청 ID 를 주고받을 헤더를 설정하는 옵션 (default: X-Request-ID)
+func WithRequestIDHeader(header string) HTTPOption {
+	return func(setting *HTTPLogging) {
+		setting.RequestIDHeader = header
+	}
+}
+
+// WithRequestIDField 요청 ID 를 등록할 공통 필드 이름을 설정하는 옵션 (default: rid)
+func WithRequestIDField(field string) HTTPOption {
+	return func(setting *HTTPLogging) {
+		setting.RequestIDField = field
+	}
+}
+
+// WithRequestIDGenerator 요청에 ID 가 없을 때 사용할 생성 함수를 설정하는 옵션
+//
+// Example:
+//
+//	options.WithRequestIDGenerator(func() string { return uuid.NewString() })
+func WithRequestIDGenerator(generate func() string) HTTPOption {
+	return func(setting *HTTPLogging) {
+		setting.GenerateRequestID = generate
+	}
+}
+
+// WithAccessLogMessage 액세스 로그 메시지를 설정하는 옵션 (default: "http request")
+func WithAccessLogMessage(message string) HTTPOption {
+	return func(setting *HTTPLogging) {
+		setting.Message = message
+	}
+}
+
+// WithAccessLogFields 액세스 로그 필드 이름을 설정하는 옵션
+//
+// Example:
+//
+//	// elapsed 대신 latency_ms, remote_addr 는 기록하지 않음
+//	options.WithAccessLogFields(options.AccessLogFields{Elapsed: "latency_ms", RemoteAddr: "-"})
+func WithAccessLogFields(fields AccessLogFields) HTTPOption {
+	return func(setting *HTTPLogging) {
+		setting.Fields = fields
+	}
+}
+
+// WithQueryMasking 쿼리 파라미터 마스킹 규칙을 설정하는 옵션
+//
+// Example:
+//
+//	// /login?token=abcd&phone=01012345678 -> /login?token=****&phone=0101***5678
+//	options.WithQueryMasking(masking.Rules{"token": masking.Password, "phone": masking.Mobile})
+func WithQueryMasking(rules masking.Rules) HTTPOption {
+	return func(setting *HTTPLogging) {
+		setting.QueryMasking = rules
+	}
+}
+
+// WithBodyCapture 요청/응답 본문을 limit 바이트까지 기록하도록 설정하는 옵션 (logger.NewRoundTripper)
+//   - JSON 과 form 본문은 rules 에 따라 마스킹되며, 마스킹 규칙이 있는데 본문을 해석할 수 없으면 기록하지 않는다.
+//
+// Example:
+//
+//	// 본문 4KiB 까지 기록, password 와 card_no 는 마스킹
+//	options.WithBodyCapture(4<<10, masking.Rules{"password": masking.Password, "card_no": masking.CreditCard})
+func WithBodyCapture(limit int, rules masking.Rules) HTTPOption {
+	return func(setting *HTTPLogging) {
+		setting.BodyLimit = limit
+		setting.BodyMasking = rules
+	}
+}
+
+// WithHeaderCapture 요청/응답 헤더를 기록하도록 설정하는 옵션 (logger.NewRoundTripper)
+//   - exclude(...string): 기본 제외 헤더(Authorization, Proxy-Authorization, Cookie, Set-Cookie)에 더해 기록하지 않을 헤더
+//
+// Example:
+//
+//	options.WithHeaderCapture("X-Api-Key")
+func WithHeaderCapture(exclude ...string) HTTPOption {
+	return func(setting *HTTPLogging) {
+		setting.CaptureHeaders = true
+		setting.ExcludeHeaders = append(setting.ExcludeHeaders, exclude...)
+	}
+}
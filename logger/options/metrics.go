@@ -0,0 +1,178 @@
+package options
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// CloudWatch Embedded Metric Format 의 제한
+const (
+	// EMFMaxMetrics : 한 로그에 선언할 수 있는 최대 메트릭 수
+	EMFMaxMetrics = 100
+	// EMFMaxDimensions : 디멘션 셋 하나에 넣을 수 있는 최대 디멘션 수
+	EMFMaxDimensions = 30
+)
+
+var (
+	// ErrTooManyMetrics : 메트릭이 EMFMaxMetrics 개를 넘는 경우의 에러
+	ErrTooManyMetrics = errors.New("too many metrics")
+	// ErrTooManyDimensions : 디멘션 셋의 디멘션이 EMFMaxDimensions 개를 넘는 경우의 에러
+	ErrTooManyDimensions = errors.New("too many dimensions")
+)
+
+// Metrics : 로그에 CloudWatch Embedded Metric Format(EMF) 메타데이터를 붙이기 위한 메트릭 선언
+//
+// NewMetrics 로 생성하며, 생성 후에는 바꿀 수 없으므로 여러 고루틴에서 함께 사용할 수 있다.
+type Metrics struct {
+	namespace  string
+	metrics    []Metric
+	dimensions [][]string
+}
+
+// Metric : 메트릭으로 사용할 로그 필드
+//   - Name(string): 숫자 값을 가진 최상위 로그 필드 이름
+//   - Unit(types.MetricUnit): 단위 (default: types.None)
+type Metric struct {
+	Name string
+	Unit types.MetricUnit
+}
+
+// MetricsOption : 메트릭 선언 옵션 타입
+type MetricsOption func(metrics *Metrics)
+
+// NewMetrics : 메트릭 선언 생성자
+//   - namespace(string): CloudWatch 네임스페이스
+//   - opts(...MetricsOption): 메트릭 선언 옵션
+//
+// 메트릭이 없거나, 메트릭이 EMFMaxMetrics 개를 넘거나, 디멘션 셋의 디멘션이 EMFMaxDimensions 개를 넘는 경우 등
+// EMF 로 기록할 수 없는 선언이면 에러를 반환한다.
+//
+// Example:
+//
+//	metrics, err := options.NewMetrics("payment-api",
+//		options.WithMetric(types.ElapsedField, types.Milliseconds),
+//		options.WithMetric(types.StatusCodeField, types.None),
+//		options.WithDimensions("service", types.MethodField),
+//	)
+func NewMetrics(namespace string, opts ...MetricsOption) (*Metrics, error) {
+	metrics := &Metrics{namespace: namespace}
+	for _, opt := range opts {
+		opt(metrics)
+	}
+	if err := metrics.validate(); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// WithMetric : 메트릭으로 사용할 로그 필드를 추가하는 옵션
+//   - name(string): 숫자 값을 가진 최상위 로그 필드 이름
+//   - unit(types.MetricUnit): 단위
+func WithMetric(name string, unit types.MetricUnit) MetricsOption {
+	return func(metrics *Metrics) {
+		metrics.metrics = append(metrics.metrics, Metric{Name: name, Unit: unit})
+	}
+}
+
+// WithDimensions : 디멘션 셋을 추가하는 옵션, 여러 번 호출하면 디멘션 셋별로 메트릭이 집계된다.
+//   - names(...string): 문자열 값을 가진 최상위 로그 필드 이름
+func WithDimensions(names ...string) MetricsOption {
+	return func(metrics *Metrics) {
+		metrics.dimensions = append(metrics.dimensions, append([]string(nil), names...))
+	}
+}
+
+// validate : EMF 의 제한을 확인하는 메서드
+func (m *Metrics) validate() error {
+	if m.namespace == "" || len(m.namespace) > 255 {
+		return fmt.Errorf("metrics namespace must be 1 to 255 characters: %q", m.namespace)
+	}
+	if len(m.metrics) == 0 {
+		return errors.New("metrics must declare at least one metric")
+	}
+	if len(m.metrics) > EMFMaxMetrics {
+		return fmt.Errorf("%w: %d (max %d)", ErrTooManyMetrics, len(m.metrics), EMFMaxMetrics)
+	}
+
+	names := make(map[string]bool, len(m.metrics))
+	for i, metric := range m.metrics {
+		if metric.Name == "" {
+			return fmt.Errorf("metrics[%d]: empty name", i)
+		}
+		if names[metric.Name] {
+			return fmt.Errorf("metrics[%d]: duplicate name %q", i, metric.Name)
+		}
+		names[metric.Name] = true
+		if metric.Unit == "" {
+			m.metrics[i].Unit = types.None
+		} else if !metric.Unit.Valid() {
+			return fmt.Errorf("metrics[%d]: unsupported unit %q", i, metric.Unit)
+		}
+	}
+	for i, dimensions := range m.dimensions {
+		if len(dimensions) > EMFMaxDimensions {
+			return fmt.Errorf("dimensions[%d]: %w: %d (max %d)", i, ErrTooManyDimensions, len(dimensions), EMFMaxDimensions)
+		}
+		for _, name := range dimensions {
+			if name == "" {
+				return fmt.Errorf("dimensions[%d]: empty name", i)
+			}
+			if names[name] {
+				return fmt.Errorf("dimensions[%d]: %q is declared as a metric", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalJSON : 기록하는 시점의 Timestamp 를 포함한 EMF 메타데이터(_aws)로 인코딩하는 메서드
+func (m *Metrics) MarshalJSON() ([]byte, error) {
+	dimensions := m.dimensions
+	if len(dimensions) == 0 {
+		dimensions = [][]string{{}}
+	}
+	return json.Marshal(emfMetadata{
+		Timestamp: time.Now().UnixMilli(),
+		CloudWatchMetrics: []emfDirective{{
+			Namespace:  m.namespace,
+			Dimensions: dimensions,
+			Metrics:    m.metrics,
+		}},
+	})
+}
+
+// emfMetadata : EMF 메타데이터의 JSON 구조
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+type emfDirective struct {
+	Namespace  string     `json:"Namespace"`
+	Dimensions [][]string `json:"Dimensions"`
+	Metrics    []Metric   `json:"Metrics"`
+}
+
+// WithMetrics CloudWatch Embedded Metric Format 메타데이터(_aws)를 로그에 붙이는 옵션
+//   - metrics(*Metrics): 메트릭 선언 (NewMetrics 참고), nil 이면 붙이지 않음
+//
+// 선언한 메트릭, 디멘션 필드는 같은 로그에 최상위 필드로 기록되어야 하며, JSON 포맷에서만 사용할 수 있다.
+// 메타데이터는 로거의 엔트리에 남지 않고 옵션을 넘긴 로그 하나에만 기록된다.
+//
+// Example:
+//
+//	log.Info(
+//		options.WithMessage("request completed"),
+//		options.WithFields(options.Fields{"service": "payment", types.ElapsedField: 12}),
+//		options.WithMetrics(metrics),
+//	)
+//	// output: {"_aws":{"Timestamp":1700000000000,"CloudWatchMetrics":[{"Namespace":"payment-api","Dimensions":[["service"]],"Metrics":[{"Name":"elapsed","Unit":"Milliseconds"}]}]},"service":"payment","elapsed":12,...}
+func WithMetrics(metrics *Metrics) func(entry *Entry) {
+	return func(entry *Entry) {
+		entry.Metrics = metrics
+	}
+}
@@ -0,0 +1,62 @@
+package options
+
+import "github.com/wjddn3711/structured-logger/logger/types"
+
+// Recovery : 패닉 복구 설정 (logger.Recover, logger.Go)
+//   - Level(types.LogLevel): 크래시 로그 레벨 (default: types.Error, types.Fatal 인 경우 로그 후 프로세스 종료)
+//   - RePanic(bool): 로그를 남긴 뒤 다시 패닉을 발생시킬지 여부 (default: false)
+//   - Message(string): 크래시 로그 메시지 (default: "panic recovered")
+type Recovery struct {
+	Level   types.LogLevel
+	RePanic bool
+	Message string
+}
+
+// RecoverOption 패닉 복구 설정을 위한 옵션 타입
+//   - WithRecoverLevel: 크래시 로그 레벨을 설정하는 옵션
+//   - WithRePanic: 다시 패닉을 발생시킬지 설정하는 옵션
+//   - WithRecoverMessage: 크래시 로그 메시지를 설정하는 옵션
+type RecoverOption func(*Recovery)
+
+// NewRecovery 기본값에 옵션을 적용한 패닉 복구 설정을 생성하는 함수
+func NewRecovery(opts ...RecoverOption) Recovery {
+	recovery := Recovery{
+		Level:   types.Error,
+		Message: "panic recovered",
+	}
+	for _, opt := range opts {
+		opt(&recovery)
+	}
+	return recovery
+}
+
+// WithRecoverLevel 크래시 로그 레벨을 설정하는 옵션 (default: types.Error)
+//
+// Example:
+//
+//	// 패닉을 기록한 뒤 프로세스 종료
+//	defer logger.Recover(ctx, types.ZeroLog, options.WithRecoverLevel(types.Fatal))
+func WithRecoverLevel(level types.LogLevel) RecoverOption {
+	return func(recovery *Recovery) {
+		recovery.Level = level
+	}
+}
+
+// WithRePanic 로그를 남긴 뒤 같은 값으로 다시 패닉을 발생시킬지 설정하는 옵션 (default: false)
+//
+// Example:
+//
+//	// 상위의 복구 로직(예: net/http 서버)에 패닉을 그대로 전달
+//	defer logger.Recover(ctx, types.ZeroLog, options.WithRePanic(true))
+func WithRePanic(rePanic bool) RecoverOption {
+	return func(recovery *Recovery) {
+		recovery.RePanic = rePanic
+	}
+}
+
+// WithRecoverMessage 크래시 로그 메시지를 설정하는 옵션 (default: "panic recovered")
+func WithRecoverMessage(message string) RecoverOption {
+	return func(recovery *Recovery) {
+		recovery.Message = message
+	}
+}
@@ -0,0 +1,52 @@
+package options
+
+import "time"
+
+// Reload : 설정 파일 감시 설정 (logger.WatchConfig)
+//   - Interval(time.Duration): 설정 파일 변경을 확인하는 주기 (default: 5초, 0 인 경우 주기적으로 확인하지 않음)
+//   - Signal(bool): SIGHUP 수신 시 설정을 다시 읽을지 여부 (default: true)
+type Reload struct {
+	Interval time.Duration
+	Signal   bool
+}
+
+// ReloadOption 설정 파일 감시 설정을 위한 옵션 타입
+//   - WithReloadInterval: 설정 파일 변경을 확인하는 주기를 설정하는 옵션
+//   - WithReloadSignal: SIGHUP 수신 시 설정을 다시 읽을지 설정하는 옵션
+type ReloadOption func(*Reload)
+
+// NewReload 기본값에 옵션을 적용한 설정 파일 감시 설정을 생성하는 함수
+func NewReload(opts ...ReloadOption) Reload {
+	reload := Reload{
+		Interval: 5 * time.Second,
+		Signal:   true,
+	}
+	for _, opt := range opts {
+		opt(&reload)
+	}
+	return reload
+}
+
+// WithReloadInterval 설정 파일 변경을 확인하는 주기를 설정하는 옵션 (default: 5초)
+//   - interval(time.Duration): 확인 주기, 0 인 경우 주기적으로 확인하지 않고 SIGHUP 이나 Reload 호출 시에만 다시 읽는다.
+//
+// Example:
+//
+//	w, err := logger.WatchConfig("logging.yaml", options.WithReloadInterval(time.Second))
+func WithReloadInterval(interval time.Duration) ReloadOption {
+	return func(reload *Reload) {
+		reload.Interval = interval
+	}
+}
+
+// WithReloadSignal SIGHUP 수신 시 설정을 다시 읽을지 설정하는 옵션 (default: true)
+//
+// Example:
+//
+//	// 파일 변경 확인만 사용
+//	w, err := logger.WatchConfig("logging.yaml", options.WithReloadSignal(false))
+func WithReloadSignal(enabled bool) ReloadOption {
+	return func(reload *Reload) {
+		reload.Signal = enabled
+	}
+}
@@ -0,0 +1,60 @@
+package options
+
+import (
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// Sampling : 로그 샘플링 설정
+//   - Interval(time.Duration): 키별 카운트를 초기화하는 주기 (default: 1s)
+//   - Levels(map[types.LogLevel]SamplingPolicy): 레벨별 샘플링 정책, 정책이 없는 레벨은 모두 기록
+//   - KeyFields([]string): 메시지와 함께 샘플링 키로 사용할 필드
+//   - OnDropped(func): 주기가 끝날 때 레벨별로 버려진 엔트리 수를 전달받는 콜백
+//   - DisableReport(bool): 버려진 엔트리 수를 요약 로그로 기록하지 않음
+type Sampling struct {
+	Interval      time.Duration
+	Levels        map[types.LogLevel]SamplingPolicy
+	KeyFields     []string
+	OnDropped     func(level types.LogLevel, dropped uint64)
+	DisableReport bool
+}
+
+// SamplingPolicy : 레벨별 샘플링 정책
+//   - First(int): 주기마다 키별로 처음 N개는 모두 기록
+//   - Thereafter(int): 이후 M개마다 1개를 기록 (0 인 경우 이후 엔트리는 모두 버림)
+//   - Rate(float64): 카운트 정책을 통과한 엔트리를 기록할 확률 (0 인 경우 확률 샘플링 안 함)
+//
+// Example:
+//
+//	// 주기마다 키별로 처음 100개, 이후 100개마다 1개
+//	options.SamplingPolicy{First: 100, Thereafter: 100}
+//	// 10% 확률로 기록
+//	options.SamplingPolicy{Rate: 0.1}
+type SamplingPolicy struct {
+	First      int
+	Thereafter int
+	Rate       float64
+}
+
+// WithSampling 로그 샘플링을 설정하는 옵션
+//
+// 샘플링 키는 레벨, 메시지, KeyFields 값의 조합이며, 주기가 끝나면 버려진 엔트리 수가
+// 같은 레벨의 요약 로그({"message":"log entries sampled out","sampled_out":N})로 기록된다.
+// 요약 로그는 공통 필드만 가지며, fatal 은 프로세스를 종료하지 않도록 error 레벨로 기록된다.
+// 요약 로그는 주기가 끝난 뒤의 첫 로그와 함께 기록되며, 종료 시 logger.Close 를 호출하면
+// 아직 보고되지 않은 수도 기록된다.
+//
+// Example:
+//
+//	// 요청마다 찍히는 info 로그는 uri 별로 초당 처음 10개, 이후 100개마다 1개만 기록
+//	log := logger.NewWrapper(types.ZeroLog, options.WithSampling(options.Sampling{
+//		Interval:  time.Second,
+//		Levels:    map[types.LogLevel]options.SamplingPolicy{types.Info: {First: 10, Thereafter: 100}},
+//		KeyFields: []string{"uri"},
+//	}))
+func WithSampling(sampling Sampling) LogSettingOption {
+	return func(setting *LogSetting) {
+		setting.Sampling = &sampling
+	}
+}
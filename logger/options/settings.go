@@ -3,6 +3,7 @@ package options
 import (
 	"io"
 
+	"github.com/wjddn3711/structured-logger/logger/masking"
 	"github.com/wjddn3711/structured-logger/logger/types"
 )
 
@@ -21,12 +22,40 @@ type LogSetting struct {
 	Output io.Writer
 	// timeFormat: 시간 포맷
 	TimeFormat string
+	// Format : 로그 출력 포맷
+	//   - types.JSON: JSON (default)
+	//   - types.Text: 사람이 읽기 쉬운 텍스트
+	//   - types.Logfmt: key=value 형태의 logfmt
+	//   - types.CBOR, types.MsgPack: 바이너리 (logger.DecodeBinary 로 JSON 변환)
+	Format types.LogFormat
+	// Caller : 로그 호출 위치(file:line)를 caller 필드로 기록할지 여부
+	Caller bool
+	// Sampling : 로그 샘플링 설정 (nil 인 경우 샘플링 안 함)
+	Sampling *Sampling
+	// Deduplication : 반복 로그 억제 설정 (nil 인 경우 억제 안 함)
+	Deduplication *Deduplication
+	// Masking : 필드 이름별 마스킹 규칙 (공통 필드와 엔트리 필드에 적용)
+	Masking masking.Rules
+	// Schema : 출력 필드 스키마 (빈 값인 경우 라이브러리 기본 필드 이름)
+	//   - types.ECS: Elastic Common Schema
+	//   - types.GCP: Google Cloud Logging
+	Schema types.Schema
+	// SchemaMapping : 스키마의 기본 필드 매핑에 추가하거나 덮어쓸 매핑 (필드 이름 → 스키마 필드 경로)
+	SchemaMapping map[string]string
+	// GCPProject : types.GCP 스키마에서 trace 리소스 이름(projects/<project>/traces/<trace_id>)에 사용할 프로젝트 ID
+	GCPProject string
 }
 
 // LogSettingOption 로그 설정을 위한 옵션 타입
 //   - WithLevel: 로그 레벨을 설정하는 옵션 (default: info)
 //   - WithOutput: 로그 출력 위치를 설정하는 옵션 (default: os.Stdout)
 //   - WithTimeFormat: 로그의 시간 포맷을 설정하는 옵션 (default: "2006-01-02 15:04:05")
+//   - WithFormat: 로그 출력 포맷을 설정하는 옵션 (default: types.JSON)
+//   - WithCaller: 로그 호출 위치를 기록하는 옵션 (default: false)
+//   - WithSampling: 로그 샘플링을 설정하는 옵션 (default: 샘플링 안 함)
+//   - WithDeduplication: 반복 로그를 억제하는 옵션 (default: 억제 안 함)
+//   - WithMasking: 필드 마스킹 규칙을 설정하는 옵션 (default: 마스킹 안 함)
+//   - WithSchema: 출력 필드 스키마를 설정하는 옵션 (default: 라이브러리 기본 필드 이름)
 type LogSettingOption func(*LogSetting)
 
 // WithLevel 로그 레벨을 설정하는 옵션
@@ -83,3 +112,95 @@ func WithTimeFormat(timeFormat string) LogSettingOption {
 		setting.TimeFormat = timeFormat
 	}
 }
+
+// WithCaller 로그 호출 위치(file:line)를 caller 필드로 기록하는 옵션
+//   - enabled(bool): 호출 위치 기록 여부
+//
+// Example:
+//
+//	log := logger.NewWrapper(types.ZeroLog, options.WithCaller(true))
+//	log.Info(options.WithMessage("info message"))
+//	// output: {"level":"info","caller":"/app/main.go:42","message":"info message"}
+func WithCaller(enabled bool) LogSettingOption {
+	return func(setting *LogSetting) {
+		setting.Caller = enabled
+	}
+}
+
+// WithFormat 로그 출력 포맷을 설정하는 옵션
+//   - format(types.LogFormat): 출력 포맷, 지정 하지 않을 경우 types.JSON
+//
+// Example:
+//
+//	// 로컬 개발 환경에서 텍스트로 출력
+//	log := logger.NewWrapper(types.ZeroLog, options.WithFormat(types.Text))
+//	log.Info(options.WithMessage("info message"))
+//	// output: 2024-01-01 12:00:00 INF info message
+//
+// types.CBOR, types.MsgPack 은 줄바꿈 없이 로그마다 데이터 아이템 하나를 기록하므로,
+// JSON 줄을 읽는 싱크(sink 패키지)가 아닌 파일이나 스트림에 기록할 때 사용한다.
+// logrus 는 엔트리 필드를 JSON 을 거치지 않고 바로 인코딩하며, zerolog 는 JSON 출력을 맵으로 디코딩하지 않고
+// 한 번 읽으며 바로 변환하므로 JSON 포맷보다 할당이 늘지 않는다.
+// zerolog 를 binary_log 빌드 태그로 빌드하면(go build -tags binary_log) types.CBOR 는 zerolog 의 CBOR 인코더가
+// 기록한 출력을 그대로 사용한다. 스키마(WithSchema)를 지정하면 JSON 값으로 바꾼 필드에 스키마를 적용한 뒤 인코딩한다.
+func WithFormat(format types.LogFormat) LogSettingOption {
+	return func(setting *LogSetting) {
+		setting.Format = format
+	}
+}
+
+// WithMasking 필드 이름별 마스킹 규칙을 설정하는 옵션
+//   - 공통 필드와 엔트리 필드(중첩된 맵 포함) 중 규칙에 해당하는 이름의 값을 마스킹한다.
+//
+// Example:
+//
+//	log := logger.NewWrapper(types.ZeroLog, options.WithMasking(masking.Rules{"phone": masking.Mobile}))
+//	log.Info(options.WithFields(options.Fields{"phone": "01012345678"}))
+//	// output: {"level":"info","phone":"0101***5678"}
+func WithMasking(rules masking.Rules) LogSettingOption {
+	return func(setting *LogSetting) {
+		setting.Masking = rules
+	}
+}
+
+// WithSchema 출력 필드 스키마를 설정하는 옵션
+//   - schema(types.Schema): 출력 스키마
+//   - mapping(...map[string]string): 기본 필드 매핑에 추가하거나 덮어쓸 매핑 (필드 이름 → 스키마 필드 경로, 빈 문자열이면 이름을 바꾸지 않음)
+//
+// 스키마는 JSON, logfmt, CBOR, MessagePack 포맷에 적용되며 텍스트 포맷에는 적용되지 않는다.
+// 시간은 RFC3339 (나노초) 형식으로 기록된다.
+//   - types.ECS: 필드 경로는 점으로 구분하여 중첩되며, ecs.version 이 함께 기록된다.
+//   - types.GCP: 필드 경로는 최상위 키로 그대로 사용된다 (WithGCPProject 참고).
+//
+// Example:
+//
+//	log := logger.NewWrapper(types.ZeroLog, options.WithSchema(types.ECS, map[string]string{"order_id": "labels.order_id"}))
+//	log.Info(options.WithMessage("paid"), options.WithFields(options.Fields{"order_id": "A-1", "status_code": 200}))
+//	// output: {"@timestamp":"2024-01-01T12:00:00.123456789+09:00","ecs":{"version":"8.11.0"},"http":{"response":{"status_code":200}},"labels":{"order_id":"A-1"},"log":{"level":"info"},"message":"paid"}
+func WithSchema(schema types.Schema, mapping ...map[string]string) LogSettingOption {
+	return func(setting *LogSetting) {
+		setting.Schema = schema
+		for _, m := range mapping {
+			if setting.SchemaMapping == nil {
+				setting.SchemaMapping = map[string]string{}
+			}
+			for field, path := range m {
+				setting.SchemaMapping[field] = path
+			}
+		}
+	}
+}
+
+// WithGCPProject types.GCP 스키마에서 trace 필드를 리소스 이름으로 기록할 프로젝트 ID 를 설정하는 옵션
+//   - project(string): 프로젝트 ID, 지정 하지 않을 경우 GOOGLE_CLOUD_PROJECT 환경 변수
+//
+// Example:
+//
+//	log := logger.NewWrapper(types.ZeroLog, options.WithSchema(types.GCP), options.WithGCPProject("my-project"))
+//	log.Info(options.WithFields(options.Fields{types.TraceIDField: "4bf92f3577b34da6a3ce929d0e0e4736"}))
+//	// output: {"logging.googleapis.com/trace":"projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736","severity":"INFO",...}
+func WithGCPProject(project string) LogSettingOption {
+	return func(setting *LogSetting) {
+		setting.GCPProject = project
+	}
+}
@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// Recover : 패닉을 복구하고 컨텍스트 로거로 크래시 로그를 기록하는 함수 (defer 로 호출)
+//   - ctx(context.Context): 로거가 등록된 컨텍스트, 로거의 공통 필드가 크래시 로그에 함께 기록된다.
+//   - loggerType(types.LoggerType): 컨텍스트에서 가져올 로거 타입
+//   - opts(...RecoverOption): 패닉 복구 설정 옵션
+//
+// 크래시 로그에는 패닉 값과 고루틴 스택 트레이스가 기록된다.
+// recover 는 defer 된 함수에서 직접 호출되어야 하므로, 반드시 `defer logger.Recover(...)` 형태로 사용해야 한다.
+//
+// Example:
+//
+//	func handle(ctx context.Context) {
+//		defer logger.Recover(ctx, types.ZeroLog)
+//		...
+//	}
+//	// output: {"level":"error","rid":"...","panic":"runtime error: index out of range [3] with length 3","stack":"goroutine 7 [running]:\n...","message":"panic recovered"}
+func Recover(ctx context.Context, loggerType types.LoggerType, opts ...options.RecoverOption) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	report(ctx, loggerType, options.NewRecovery(opts...), value)
+}
+
+// Go : 패닉을 복구하고 크래시 로그를 기록하는 고루틴을 실행하는 함수
+//   - ctx(context.Context): fn 에 전달할 컨텍스트, 로거의 공통 필드가 크래시 로그에 함께 기록된다.
+//   - loggerType(types.LoggerType): 컨텍스트에서 가져올 로거 타입
+//   - fn(func(ctx context.Context)): 고루틴에서 실행할 함수
+//   - opts(...RecoverOption): 패닉 복구 설정 옵션
+//
+// Example:
+//
+//	logger.Go(ctx, types.ZeroLog, func(ctx context.Context) {
+//		sendNotification(ctx, user)
+//	})
+func Go(ctx context.Context, loggerType types.LoggerType, fn func(ctx context.Context), opts ...options.RecoverOption) {
+	recovery := options.NewRecovery(opts...)
+	go func() {
+		defer func() {
+			if value := recover(); value != nil {
+				report(ctx, loggerType, recovery, value)
+			}
+		}()
+		fn(ctx)
+	}()
+}
+
+// report : 크래시 로그를 기록하고, 설정에 따라 다시 패닉을 발생시키는 함수
+func report(ctx context.Context, loggerType types.LoggerType, recovery options.Recovery, value interface{}) {
+	if log := FromContext(ctx, loggerType); log != nil {
+		// 크래시 필드가 컨텍스트 로거에 남지 않도록 복사한 로거로 기록
+		Log(log.Clone(), recovery.Level,
+			options.WithMessage(recovery.Message),
+			options.WithFields(options.Fields{
+				types.PanicField: fmt.Sprint(value),
+				types.StackField: string(debug.Stack()),
+			}),
+		)
+	}
+	if recovery.RePanic {
+		panic(value)
+	}
+}
@@ -0,0 +1,254 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// Registry : 점(.)으로 구분된 컴포넌트 이름별로 레벨을 관리하는 로거 레지스트리
+//
+// Named 로 얻은 로거는 이름과 가장 가까운 상위 이름(예: "payment.client" → "payment" → "")에
+// 설정된 레벨을 따르며, 레벨은 실행 중에도 바꿀 수 있다.
+//
+// Example:
+//
+//	registry := logger.NewRegistry(types.ZeroLog, options.WithLevel(types.Info))
+//	registry.SetLevel("payment.client", types.Debug)
+//	log := registry.Named("payment.client.retry") // debug 레벨
+//	log.Debug(options.WithMessage("retrying"))
+//	// output: {"level":"debug","component":"payment.client.retry","message":"retrying"}
+type Registry struct {
+	loggerType types.LoggerType
+	base       Logger
+
+	mu      sync.RWMutex
+	levels  map[string]types.LogLevel
+	version atomic.Uint64
+}
+
+// NewRegistry : 레지스트리 생성자
+//   - loggerType(types.LoggerType): 로거 타입
+//   - settingOpts(...LogSettingOption): 로거 설정 옵션 (NewWrapper 참고)
+//
+// WithLevel 로 설정한 레벨(default: info)은 최상위("") 레벨이 된다.
+// 레벨 필터링은 레지스트리에서 하므로, 컴포넌트 로거의 백엔드는 debug 레벨로 생성된다.
+func NewRegistry(loggerType types.LoggerType, settingOpts ...options.LogSettingOption) *Registry {
+	settings := options.LogSetting{Level: types.Info}
+	for _, opt := range settingOpts {
+		opt(&settings)
+	}
+
+	settingOpts = append(settingOpts[:len(settingOpts):len(settingOpts)], options.WithLevel(types.Debug))
+	return &Registry{
+		loggerType: loggerType,
+		base:       NewWrapper(loggerType, settingOpts...),
+		levels:     map[string]types.LogLevel{"": settings.Level},
+	}
+}
+
+// Named : 컴포넌트 이름의 로거를 반환하는 메서드
+//   - name(string): 점(.)으로 구분된 컴포넌트 이름 (예: "payment.client")
+//
+// 반환된 로거는 component 필드로 이름을 기록한다.
+func (r *Registry) Named(name string) Logger {
+	if r.base == nil {
+		// no op
+		return nil
+	}
+
+	logger := r.base.Clone()
+	logger.RegisterCommonField(types.ComponentField, name)
+	return &namedLogger{Logger: logger, registry: r, name: name}
+}
+
+// SetLevel : 컴포넌트 이름(접두사)의 레벨을 설정하는 메서드
+//   - prefix(string): 컴포넌트 이름, 빈 문자열은 최상위
+//   - level(types.LogLevel): 해당 이름과 하위 이름에 적용할 레벨
+//
+// 이미 생성된 로거에도 다음 로그부터 적용된다.
+//
+// Example:
+//
+//	registry.SetLevel("", types.Warn)               // 전체 warn
+//	registry.SetLevel("payment", types.Info)        // payment.* 는 info
+//	registry.SetLevel("payment.client", types.Debug) // payment.client.* 는 debug
+func (r *Registry) SetLevel(prefix string, level types.LogLevel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.levels[prefix] = level
+	r.version.Add(1)
+}
+
+// SetLevels : 여러 컴포넌트의 레벨을 한 번에 설정하는 메서드
+func (r *Registry) SetLevels(levels map[string]types.LogLevel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for prefix, level := range levels {
+		r.levels[prefix] = level
+	}
+	r.version.Add(1)
+}
+
+// UnsetLevel : 컴포넌트 이름에 설정된 레벨을 지워 상위 이름의 레벨을 따르게 하는 메서드
+//
+// 최상위("") 레벨은 지울 수 없다.
+func (r *Registry) UnsetLevel(prefix string) {
+	if prefix == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.levels, prefix)
+	r.version.Add(1)
+}
+
+// Level : 컴포넌트 이름에 적용되는 레벨을 반환하는 메서드
+//
+// 이름에 설정된 레벨이 없으면 가장 가까운 상위 이름의 레벨을 반환한다.
+func (r *Registry) Level(name string) types.LogLevel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lookup(name)
+}
+
+// componentLevel : 컴포넌트 레벨과 그 레벨을 계산한 레지스트리 version
+type componentLevel struct {
+	version uint64
+	level   types.LogLevel
+}
+
+// currentLevel : 컴포넌트 이름에 적용되는 레벨을 현재 version 과 함께 반환하는 메서드
+//
+// 레벨을 바꾸는 메서드는 쓰기 잠금을 잡은 채 version 을 올리므로, 읽기 잠금 안에서 읽은 둘은 항상 짝이 맞는다.
+func (r *Registry) currentLevel(name string) *componentLevel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &componentLevel{version: r.version.Load(), level: r.lookup(name)}
+}
+
+// lookup : 이름이나 가장 가까운 상위 이름에 설정된 레벨을 반환하는 메서드 (r.mu 를 잡은 상태에서 호출)
+func (r *Registry) lookup(name string) types.LogLevel {
+	for {
+		if level, ok := r.levels[name]; ok {
+			return level
+		}
+		if name == "" {
+			return types.Info
+		}
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			name = name[:i]
+		} else {
+			name = ""
+		}
+	}
+}
+
+var (
+	defaultRegistryOnce sync.Once
+	defaultRegistry     atomic.Pointer[Registry]
+)
+
+// DefaultRegistry : Named, SetLevel 이 사용하는 기본 레지스트리를 반환하는 함수
+//
+// SetDefaultRegistry 로 지정하지 않은 경우 기본 설정의 zerolog 레지스트리를 사용한다.
+func DefaultRegistry() *Registry {
+	defaultRegistryOnce.Do(func() {
+		defaultRegistry.CompareAndSwap(nil, NewRegistry(types.ZeroLog))
+	})
+	return defaultRegistry.Load()
+}
+
+// SetDefaultRegistry : 기본 레지스트리를 지정하는 함수
+//
+// Example:
+//
+//	logger.SetDefaultRegistry(logger.NewRegistry(types.Logrus, options.WithOutput(os.Stderr)))
+func SetDefaultRegistry(r *Registry) {
+	defaultRegistryOnce.Do(func() {})
+	defaultRegistry.Store(r)
+}
+
+// Named : 기본 레지스트리에서 컴포넌트 이름의 로거를 반환하는 함수 (Registry.Named 참고)
+//
+// Example:
+//
+//	logger.SetLevel("payment.client", types.Debug)
+//	log := logger.Named("payment.client")
+//	log.Debug(options.WithMessage("request sent"))
+func Named(name string) Logger {
+	return DefaultRegistry().Named(name)
+}
+
+// SetLevel : 기본 레지스트리에서 컴포넌트 이름(접두사)의 레벨을 설정하는 함수 (Registry.SetLevel 참고)
+func SetLevel(prefix string, level types.LogLevel) {
+	DefaultRegistry().SetLevel(prefix, level)
+}
+
+// namedLogger : 레지스트리의 컴포넌트 레벨로 로그를 거르는 데코레이터
+type namedLogger struct {
+	Logger
+	registry *Registry
+	name     string
+
+	// level 은 레지스트리 version 이 바뀐 경우에만 다시 계산하며, version 과 함께 한 번에 바꾼다.
+	level atomic.Pointer[componentLevel]
+}
+
+// WithContext : 컨텍스트에 로거를 등록하는 메서드
+func (l *namedLogger) WithContext(ctx context.Context) context.Context {
+	key, _ := contextKey(l.registry.loggerType)
+	return context.WithValue(ctx, key, l)
+}
+
+// Clone : 같은 이름과 레지스트리를 사용하는 새 로거를 반환하는 메서드
+func (l *namedLogger) Clone() Logger {
+	return &namedLogger{Logger: l.Logger.Clone(), registry: l.registry, name: l.name}
+}
+
+// Debug : 디버그 로그를 출력하는 메서드
+func (l *namedLogger) Debug(opts ...options.EntryOption) {
+	if l.enabled(types.Debug) {
+		l.Logger.Debug(opts...)
+	}
+}
+
+// Info : 정보 로그를 출력하는 메서드
+func (l *namedLogger) Info(opts ...options.EntryOption) {
+	if l.enabled(types.Info) {
+		l.Logger.Info(opts...)
+	}
+}
+
+// Warn : 경고 로그를 출력하는 메서드
+func (l *namedLogger) Warn(opts ...options.EntryOption) {
+	if l.enabled(types.Warn) {
+		l.Logger.Warn(opts...)
+	}
+}
+
+// Error : 에러 로그를 출력하는 메서드
+func (l *namedLogger) Error(opts ...options.EntryOption) {
+	if l.enabled(types.Error) {
+		l.Logger.Error(opts...)
+	}
+}
+
+// Fatal : 치명적인 에러 로그를 출력하는 메서드 (레벨과 관계없이 항상 기록)
+func (l *namedLogger) Fatal(opts ...options.EntryOption) {
+	l.Logger.Fatal(opts...)
+}
+
+// enabled : 컴포넌트 레벨에서 해당 레벨의 로그를 기록하는지 확인하는 메서드
+func (l *namedLogger) enabled(level types.LogLevel) bool {
+	current := l.level.Load()
+	if current == nil || current.version != l.registry.version.Load() {
+		current = l.registry.currentLevel(l.name)
+		l.level.Store(current)
+	}
+	return level.Severity() >= current.level.Severity()
+}
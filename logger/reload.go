@@ -0,0 +1,369 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// generation : 한 번의 설정 적용으로 생성된 로거와 출력(싱크)
+//
+// 로그를 기록하는 동안 읽기 잠금을 잡고, 교체된 세대는 쓰기 잠금을 잡은 뒤 싱크를 닫으므로
+// 기록 중인 로그가 닫힌 싱크로 전달되지 않는다.
+type generation struct {
+	mu      sync.RWMutex
+	retired bool
+	logger  Logger
+	closers []io.Closer
+}
+
+// retire : 진행 중인 기록이 끝나기를 기다린 뒤 싱크를 닫는 메서드
+func (g *generation) retire() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.retired = true
+	closeAll(g.closers)
+}
+
+// ErrWatcherClosed : 닫힌 Watcher 에서 Reload 를 호출한 경우의 에러
+var ErrWatcherClosed = errors.New("logger: watcher closed")
+
+// Watcher : 설정 파일 변경이나 SIGHUP 을 감지하여 로거 설정을 다시 적용하는 구조체
+//
+// Logger 로 얻은 로거와 그 로거에서 Clone, WithContext/FromContext 로 얻은 로거들은
+// 설정이 바뀌면 다음 로그부터 새 레벨, 샘플링, 마스킹 규칙, 출력으로 기록한다.
+// 등록한 공통 필드와 후크는 새 설정에서도 유지된다.
+//
+// 감시하는 동안에는 설정 옵션 없이 호출한 NewWrapper 와, 컨텍스트에 로거가 없어 FromContext 가 새로 생성하는 로거도
+// 백엔드가 같으면 Watcher 의 로거를 반환하므로 다시 읽은 설정을 따른다.
+// 여러 Watcher 를 시작하면 마지막으로 시작한 Watcher 를 사용하며, 감시를 시작하기 전에 생성했거나
+// 설정 옵션을 넘겨 생성한 로거는 다시 읽은 설정을 따르지 않는다.
+type Watcher struct {
+	path       string
+	loggerType types.LoggerType
+	current    atomic.Pointer[generation]
+	root       *reloadableLogger
+
+	mu      sync.Mutex
+	content []byte
+	closed  bool
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// watching : 설정 파일을 감시하는 중인 Watcher (마지막으로 시작한 Watcher, 닫히면 nil)
+var watching atomic.Pointer[Watcher]
+
+// watchedLogger : 같은 백엔드의 설정 파일을 감시하는 중이면 설정 변경이 반영되는 로거를 반환하는 함수
+func watchedLogger(loggerType types.LoggerType) (Logger, bool) {
+	w := watching.Load()
+	if w == nil || w.loggerType != loggerType {
+		return nil, false
+	}
+	return w.Logger(), true
+}
+
+// WatchConfig : 설정 파일을 읽어 로거를 생성하고, 변경을 감시하는 함수
+//   - path(string): 설정 파일 경로 (LoadConfig 참고)
+//   - opts(...ReloadOption): 감시 설정 옵션
+//
+// 파일 내용이 바뀌거나 SIGHUP 을 받으면 설정을 다시 읽는다.
+// 다시 읽은 설정이 검증에 실패하면 에러 로그를 남기고 기존 설정을 유지한다.
+// 백엔드(backend)는 실행 중에 바꿀 수 없다.
+// 감시하는 동안 설정 옵션 없이 호출한 NewWrapper 와 FromContext 의 새 로거도 이 설정을 따른다 (Watcher 참고).
+//
+// Example:
+//
+//	w, err := logger.WatchConfig("config/logging.yaml")
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer w.Close()
+//	log := w.Logger()
+//	ctx = log.WithContext(ctx)
+func WatchConfig(path string, opts ...options.ReloadOption) (*Watcher, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("logger: read config: %w", err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	logger, closers, err := cfg.build()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		path:       path,
+		loggerType: cfg.backend(),
+		content:    content,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	w.current.Store(&generation{logger: logger, closers: closers})
+	w.root = &reloadableLogger{watcher: w}
+
+	watching.Store(w)
+
+	go w.run(options.NewReload(opts...))
+	return w, nil
+}
+
+// Logger : 설정 변경이 반영되는 로거를 반환하는 메서드
+//
+// 호출할 때마다 공통 필드가 없는 새 로거를 반환한다.
+func (w *Watcher) Logger() Logger {
+	return w.root.Clone()
+}
+
+// Reload : 설정 파일을 다시 읽어 적용하는 메서드
+//
+// 설정이 올바르지 않으면 에러 로그를 남기고 에러를 반환하며, 기존 설정을 유지한다.
+// 거부된 파일 내용은 다시 바뀌기 전까지 주기적인 확인에서 다시 적용하지 않는다.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrWatcherClosed
+	}
+
+	content, err := os.ReadFile(w.path)
+	if err == nil {
+		w.content = content
+		err = w.apply()
+	}
+	if err != nil {
+		w.log(types.Error, "logging config rejected", err)
+		return err
+	}
+	w.log(types.Info, "logging config reloaded", nil)
+	return nil
+}
+
+// Close : 감시를 멈추고 현재 출력(싱크)을 닫는 메서드
+//
+// 진행 중인 기록이 끝난 뒤 싱크를 닫으며, 이후의 로그는 기록되지 않는다.
+// 이후 설정 옵션 없이 호출한 NewWrapper 는 다시 기본 설정으로 로거를 생성한다.
+func (w *Watcher) Close() error {
+	w.once.Do(func() {
+		watching.CompareAndSwap(w, nil)
+		close(w.stop)
+		<-w.done
+
+		w.mu.Lock()
+		w.closed = true
+		w.mu.Unlock()
+		w.current.Load().retire()
+	})
+	return nil
+}
+
+// apply : 설정을 읽고 검증한 뒤 새 세대로 교체하는 메서드
+func (w *Watcher) apply() error {
+	cfg, err := LoadConfig(w.path)
+	if err != nil {
+		return err
+	}
+	if backend := cfg.backend(); backend != w.loggerType {
+		return ConfigErrors{{Path: "backend", Message: fmt.Sprintf("cannot change backend from %q to %q without restart", w.loggerType, backend)}}
+	}
+	logger, closers, err := cfg.build()
+	if err != nil {
+		return err
+	}
+
+	previous := w.current.Swap(&generation{logger: logger, closers: closers})
+	previous.retire()
+	return nil
+}
+
+// log : 감시 결과를 현재 설정의 로거로 기록하는 메서드
+func (w *Watcher) log(level types.LogLevel, message string, err error) {
+	fields := options.Fields{types.ConfigField: w.path}
+	if err != nil {
+		fields[types.ErrorField] = err.Error()
+	}
+	Log(w.root.Clone(), level, options.WithMessage(message), options.WithFields(fields))
+}
+
+// run : 주기적으로 파일 변경을 확인하고 SIGHUP 을 기다리는 메서드
+func (w *Watcher) run(reload options.Reload) {
+	defer close(w.done)
+
+	var tick <-chan time.Time
+	if reload.Interval > 0 {
+		ticker := time.NewTicker(reload.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	var hangup chan os.Signal
+	if reload.Signal {
+		hangup = make(chan os.Signal, 1)
+		signal.Notify(hangup, syscall.SIGHUP)
+		defer signal.Stop(hangup)
+	}
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-tick:
+			if w.changed() {
+				_ = w.Reload()
+			}
+		case <-hangup:
+			_ = w.Reload()
+		}
+	}
+}
+
+// changed : 마지막으로 적용한 뒤 설정 파일 내용이 바뀌었는지 확인하는 메서드
+//
+// 읽을 수 없는 경우(예: 편집기가 파일을 교체하는 중) 다음 주기에 다시 확인한다.
+func (w *Watcher) changed() bool {
+	content, err := os.ReadFile(w.path)
+	if err != nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return !bytes.Equal(content, w.content)
+}
+
+// reloadableLogger : 현재 세대의 로거로 기록하는 데코레이터
+//
+// 공통 필드, 후크, 엔트리 옵션 등록을 기억해 두었다가 세대가 바뀌면 새 로거에 다시 적용한다.
+type reloadableLogger struct {
+	watcher *Watcher
+
+	mu     sync.Mutex
+	gen    *generation
+	logger Logger
+	replay []func(Logger)
+}
+
+// WithContext : 컨텍스트에 로거를 등록하는 메서드
+func (l *reloadableLogger) WithContext(ctx context.Context) context.Context {
+	key, _ := contextKey(l.watcher.loggerType)
+	return context.WithValue(ctx, key, l)
+}
+
+// Clone : 등록된 공통 필드와 후크를 복사한 새 로거를 반환하는 메서드
+func (l *reloadableLogger) Clone() Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	clone := &reloadableLogger{
+		watcher: l.watcher,
+		gen:     l.gen,
+		replay:  append([]func(Logger){}, l.replay...),
+	}
+	if l.logger != nil {
+		clone.logger = l.logger.Clone()
+	}
+	return clone
+}
+
+// AddHook : 로거에 후크를 추가하는 메서드
+func (l *reloadableLogger) AddHook(hook interface{}) {
+	l.record(func(logger Logger) { logger.AddHook(hook) })
+}
+
+// RegisterCommonField : 로거에 공통 필드를 등록하는 메서드
+func (l *reloadableLogger) RegisterCommonField(key string, value interface{}) {
+	l.record(func(logger Logger) { logger.RegisterCommonField(key, value) })
+}
+
+// RegisterCommonFields : 로거에 공통 필드들을 등록하는 메서드
+func (l *reloadableLogger) RegisterCommonFields(fields options.LogEntry) {
+	l.record(func(logger Logger) { logger.RegisterCommonFields(fields) })
+}
+
+// ApplyOption : 로그 엔트리 옵션을 적용하는 메서드
+func (l *reloadableLogger) ApplyOption(opts []options.EntryOption) {
+	l.record(func(logger Logger) { logger.ApplyOption(opts) })
+}
+
+// Debug : 디버그 로그를 출력하는 메서드
+func (l *reloadableLogger) Debug(opts ...options.EntryOption) {
+	l.write(func(logger Logger) { logger.Debug(opts...) })
+}
+
+// Info : 정보 로그를 출력하는 메서드
+func (l *reloadableLogger) Info(opts ...options.EntryOption) {
+	l.write(func(logger Logger) { logger.Info(opts...) })
+}
+
+// Warn : 경고 로그를 출력하는 메서드
+func (l *reloadableLogger) Warn(opts ...options.EntryOption) {
+	l.write(func(logger Logger) { logger.Warn(opts...) })
+}
+
+// Error : 에러 로그를 출력하는 메서드
+func (l *reloadableLogger) Error(opts ...options.EntryOption) {
+	l.write(func(logger Logger) { logger.Error(opts...) })
+}
+
+// Fatal : 치명적인 에러 로그를 출력하는 메서드
+func (l *reloadableLogger) Fatal(opts ...options.EntryOption) {
+	l.write(func(logger Logger) { logger.Fatal(opts...) })
+}
+
+// record : 등록 작업을 기억하고 현재 로거에 적용하는 메서드
+func (l *reloadableLogger) record(fn func(Logger)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.replay = append(l.replay, fn)
+	if l.logger != nil {
+		fn(l.logger)
+	}
+}
+
+// write : 현재 세대의 로거로 로그를 기록하는 메서드
+func (l *reloadableLogger) write(fn func(Logger)) {
+	for {
+		gen := l.watcher.current.Load()
+		gen.mu.RLock()
+		if gen.retired {
+			gen.mu.RUnlock()
+			if l.watcher.current.Load() == gen {
+				// Watcher 가 닫힌 경우
+				return
+			}
+			// 교체 직후 이전 세대를 읽은 경우 새 세대로 다시 시도
+			continue
+		}
+		fn(l.sync(gen))
+		gen.mu.RUnlock()
+		return
+	}
+}
+
+// sync : 세대가 바뀐 경우 새 로거를 만들고 기억해 둔 등록 작업을 다시 적용하는 메서드
+func (l *reloadableLogger) sync(gen *generation) Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.gen != gen || l.logger == nil {
+		l.gen = gen
+		l.logger = gen.logger.Clone()
+		for _, fn := range l.replay {
+			fn(l.logger)
+		}
+	}
+	return l.logger
+}
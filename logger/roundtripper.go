@@ -0,0 +1,269 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/masking"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// roundTripper : 외부 호출을 요청 컨텍스트의 로거로 기록하는 http.RoundTripper 래퍼
+type roundTripper struct {
+	next       http.RoundTripper
+	loggerType types.LoggerType
+	setting    options.HTTPLogging
+	exclude    map[string]struct{}
+	// fallback : 요청 컨텍스트에 로거가 없을 때 사용할 로거 (생성할 때 한 번만 만든다)
+	fallback Logger
+}
+
+// NewRoundTripper : 외부 호출 로그를 기록하는 http.RoundTripper 생성자
+//   - next(http.RoundTripper): 실제 요청을 보낼 RoundTripper (nil 이면 http.DefaultTransport)
+//   - loggerType(types.LoggerType): 요청 컨텍스트에서 가져올 로거 타입
+//   - opts(...HTTPOption): HTTP 로깅 설정 옵션 (WithBodyCapture, WithHeaderCapture 등)
+//
+// 요청 컨텍스트의 로거(FromContext)로 메서드, 호스트, 경로, 상태 코드, 처리 시간, 시도 횟수를 기록하고,
+// 컨텍스트의 요청 ID 를 요청 헤더로 전달한다 (5xx 와 전송 에러: error, 4xx: warn, 그 외: info).
+// 컨텍스트에 로거가 없으면 생성할 때 만든 기본 설정의 로거로 기록한다.
+//
+// 응답 본문을 기록하는 경우(WithBodyCapture) 본문을 미리 읽지 않고 호출한 쪽이 읽는 앞부분만 복사해 두므로,
+// 호출 로그는 응답 본문을 끝까지 읽거나 닫을 때 기록된다.
+//
+// Example:
+//
+//	client := &http.Client{Transport: logger.NewRoundTripper(nil, types.ZeroLog,
+//		options.WithBodyCapture(4<<10, masking.Rules{"password": masking.Password}),
+//		options.WithHeaderCapture("X-Api-Key"),
+//	)}
+//	req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, "https://partner.example.com/v1/orders", nil)
+//	resp, err := client.Do(req)
+//	// output: {"level":"info","rid":"...","method":"GET","host":"partner.example.com","path":"/v1/orders","status_code":200,"elapsed":42,"attempt":1,"message":"http call"}
+func NewRoundTripper(next http.RoundTripper, loggerType types.LoggerType, opts ...options.HTTPOption) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	setting := options.NewHTTPLogging(append([]options.HTTPOption{options.WithAccessLogMessage("http call")}, opts...)...)
+
+	exclude := make(map[string]struct{}, len(setting.ExcludeHeaders))
+	for _, header := range setting.ExcludeHeaders {
+		exclude[http.CanonicalHeaderKey(header)] = struct{}{}
+	}
+	return &roundTripper{next: next, loggerType: loggerType, setting: setting, exclude: exclude, fallback: NewWrapper(loggerType)}
+}
+
+// WithRetryAttempts : 같은 컨텍스트로 보낸 요청의 시도 횟수를 세는 카운터를 등록하는 함수
+//
+// 재시도 루프에서 같은 컨텍스트를 사용하면 NewRoundTripper 가 기록하는 attempt 필드가 1, 2, 3 ... 으로 증가한다.
+//
+// Example:
+//
+//	ctx := logger.WithRetryAttempts(r.Context())
+//	for i := 0; i < 3; i++ {
+//		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//		if resp, err := client.Do(req); err == nil && resp.StatusCode < 500 {
+//			break
+//		}
+//	}
+func WithRetryAttempts(ctx context.Context) context.Context {
+	return context.WithValue(ctx, types.RetryAttemptsKey, new(atomic.Int64))
+}
+
+// RoundTrip : 요청을 보내고 호출 로그를 기록하는 메서드
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	log, ok := LookupContext(ctx, t.loggerType)
+	if !ok {
+		log = t.fallback
+	}
+	if log == nil {
+		// no op
+		return t.next.RoundTrip(req)
+	}
+	// 동시에 보내는 요청끼리, 그리고 요청 로거에 호출 로그의 필드가 남지 않도록 복사하여 기록한다.
+	log = log.Clone()
+	names := t.setting.Fields
+
+	attempt := int64(1)
+	if counter, ok := ctx.Value(types.RetryAttemptsKey).(*atomic.Int64); ok {
+		attempt = counter.Add(1)
+	}
+
+	// RoundTripper 는 요청을 변경하면 안 되므로, 헤더나 본문을 바꾸는 경우 복사해서 사용
+	if requestID := RequestIDFromContext(ctx); requestID != "" && req.Header.Get(t.setting.RequestIDHeader) == "" {
+		req = req.Clone(ctx)
+		req.Header.Set(t.setting.RequestIDHeader, requestID)
+	}
+
+	fields := options.Fields{}
+	addField(fields, names.Method, req.Method)
+	addField(fields, names.Host, req.URL.Host)
+	addField(fields, names.Path, req.URL.Path)
+	addField(fields, names.Attempt, attempt)
+	if t.setting.CaptureHeaders {
+		addField(fields, names.RequestHeaders, t.headers(req.Header))
+	}
+	if t.setting.BodyLimit > 0 && req.Body != nil && req.Body != http.NoBody {
+		var body []byte
+		req, body = t.captureRequest(req)
+		addField(fields, names.RequestBody, t.maskBody(req.Header.Get("Content-Type"), body))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	addField(fields, names.Elapsed, time.Since(start).Milliseconds())
+
+	if err != nil {
+		fields[types.ErrorField] = err.Error()
+		Log(log, types.Error, options.WithMessage(t.setting.Message), options.WithFields(fields))
+		return resp, err
+	}
+
+	addField(fields, names.StatusCode, resp.StatusCode)
+	if t.setting.CaptureHeaders {
+		addField(fields, names.ResponseHeaders, t.headers(resp.Header))
+	}
+	level := statusLevel(resp.StatusCode)
+	if t.setting.BodyLimit > 0 && resp.Body != nil {
+		contentType := resp.Header.Get("Content-Type")
+		resp.Body = &teeBody{ReadCloser: resp.Body, limit: t.setting.BodyLimit, done: func(body []byte) {
+			addField(fields, names.ResponseBody, t.maskBody(contentType, body))
+			Log(log, level, options.WithMessage(t.setting.Message), options.WithFields(fields))
+		}}
+		return resp, nil
+	}
+
+	Log(log, level, options.WithMessage(t.setting.Message), options.WithFields(fields))
+	return resp, nil
+}
+
+// captureRequest : 요청 본문 앞부분을 읽고, 본문을 그대로 보낼 수 있도록 복사한 요청을 반환하는 메서드
+func (t *roundTripper) captureRequest(req *http.Request) (*http.Request, []byte) {
+	if req.GetBody != nil {
+		// 다시 읽을 수 있는 본문은 원래 요청을 건드리지 않음
+		if body, err := req.GetBody(); err == nil {
+			defer body.Close()
+			captured, _ := io.ReadAll(io.LimitReader(body, int64(t.setting.BodyLimit)+1))
+			return req, captured
+		}
+	}
+
+	clone := req.Clone(req.Context())
+	var captured []byte
+	clone.Body, captured = capture(req.Body, t.setting.BodyLimit)
+	return clone, captured
+}
+
+// maskBody : 본문을 마스킹하여 기록할 문자열로 변환하는 메서드
+//
+// limit 을 넘는 본문은 잘라서 "..." 을 붙이며, 마스킹 규칙이 있는데 본문을 해석할 수 없으면 생략한다.
+func (t *roundTripper) maskBody(contentType string, body []byte) string {
+	truncated := len(body) > t.setting.BodyLimit
+	if truncated {
+		body = body[:t.setting.BodyLimit]
+	}
+
+	rules := t.setting.BodyMasking
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		body = []byte(masking.Query(string(body), rules))
+	case len(rules) == 0:
+	case strings.HasSuffix(mediaType, "json") && !truncated:
+		masked, ok := masking.JSON(body, rules)
+		if !ok {
+			return "(omitted)"
+		}
+		body = masked
+	default:
+		return "(omitted)"
+	}
+
+	if truncated {
+		return string(body) + "..."
+	}
+	return string(body)
+}
+
+// headers : 제외 헤더를 뺀 헤더를 필드로 변환하는 메서드
+func (t *roundTripper) headers(header http.Header) map[string]string {
+	fields := make(map[string]string, len(header))
+	for key, values := range header {
+		if _, ok := t.exclude[http.CanonicalHeaderKey(key)]; ok {
+			continue
+		}
+		fields[key] = strings.Join(values, ", ")
+	}
+	return fields
+}
+
+// capture : 본문 앞부분 limit+1 바이트를 읽고, 읽은 부분을 포함해 처음부터 다시 읽을 수 있는 본문을 반환하는 함수
+func capture(body io.ReadCloser, limit int) (io.ReadCloser, []byte) {
+	captured, err := io.ReadAll(io.LimitReader(body, int64(limit)+1))
+	reader := io.MultiReader(bytes.NewReader(captured), body)
+	if err != nil {
+		reader = io.MultiReader(bytes.NewReader(captured), errReader{err})
+	}
+	return readCloser{Reader: reader, Closer: body}, captured
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// teeBody : 읽는 본문의 앞부분 limit+1 바이트를 복사해 두었다가, 끝까지 읽거나 닫을 때 done 을 한 번 호출하는 io.ReadCloser
+type teeBody struct {
+	io.ReadCloser
+	limit int
+	done  func(body []byte)
+
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	finished bool
+}
+
+// Read : 본문을 읽으면서 앞부분을 복사하는 메서드
+func (b *teeBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.mu.Lock()
+	if remaining := b.limit + 1 - b.buf.Len(); remaining > 0 && n > 0 {
+		b.buf.Write(p[:min(n, remaining)])
+	}
+	b.mu.Unlock()
+	if err == io.EOF {
+		b.finish()
+	}
+	return n, err
+}
+
+// Close : 본문을 닫고, 아직 호출하지 않았다면 done 을 호출하는 메서드
+func (b *teeBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish()
+	return err
+}
+
+func (b *teeBody) finish() {
+	b.mu.Lock()
+	if b.finished {
+		b.mu.Unlock()
+		return
+	}
+	b.finished = true
+	body := b.buf.Bytes()
+	b.mu.Unlock()
+	b.done(body)
+}
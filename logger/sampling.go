@@ -0,0 +1,227 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// sampler : 레벨/메시지/키 필드 조합별로 주기 내 기록 횟수를 세어 샘플링하는 구조체
+//
+// 같은 로거에서 Clone 된 로거들은 하나의 sampler 를 공유한다.
+type sampler struct {
+	config options.Sampling
+	now    func() time.Time
+
+	mu      sync.Mutex
+	start   time.Time
+	counts  map[string]uint64
+	dropped map[types.LogLevel]uint64
+	random  *rand.Rand
+}
+
+func newSampler(config options.Sampling) *sampler {
+	if config.Interval <= 0 {
+		config.Interval = time.Second
+	}
+	return &sampler{
+		config:  config,
+		now:     time.Now,
+		counts:  map[string]uint64{},
+		dropped: map[types.LogLevel]uint64{},
+		random:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// sample : 엔트리를 기록할지 결정하는 메서드
+//
+// 주기가 바뀐 경우, 이전 주기에 레벨별로 버려진 엔트리 수를 함께 반환한다.
+func (s *sampler) sample(level types.LogLevel, entry *options.Entry) (bool, map[types.LogLevel]uint64) {
+	policy, ok := s.config.Levels[level]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := s.roll()
+	if !ok {
+		return true, report
+	}
+
+	allow := true
+	if policy.First > 0 || policy.Thereafter > 0 {
+		key := entryKey(level, entry, s.config.KeyFields)
+		s.counts[key]++
+		n := s.counts[key]
+		if n > uint64(policy.First) {
+			allow = policy.Thereafter > 0 && (n-uint64(policy.First))%uint64(policy.Thereafter) == 0
+		}
+	}
+	if allow && policy.Rate > 0 && policy.Rate < 1 {
+		allow = s.random.Float64() < policy.Rate
+	}
+	if !allow {
+		s.dropped[level]++
+	}
+	return allow, report
+}
+
+// flush : 주기와 관계없이 아직 보고되지 않은 버려진 엔트리 수를 반환하는 메서드
+func (s *sampler) flush() map[types.LogLevel]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.dropped) == 0 {
+		return nil
+	}
+	report := s.dropped
+	s.dropped = map[types.LogLevel]uint64{}
+	return report
+}
+
+// roll : 주기가 지났으면 카운트를 초기화하고 버려진 엔트리 수를 반환하는 메서드 (s.mu 를 잡은 상태에서 호출)
+func (s *sampler) roll() map[types.LogLevel]uint64 {
+	now := s.now()
+	if s.start.IsZero() {
+		s.start = now
+		return nil
+	}
+	if now.Sub(s.start) < s.config.Interval {
+		return nil
+	}
+
+	s.start = now
+	s.counts = map[string]uint64{}
+	if len(s.dropped) == 0 {
+		return nil
+	}
+	report := s.dropped
+	s.dropped = map[types.LogLevel]uint64{}
+	return report
+}
+
+// entryKey : 레벨, 메시지, 키 필드 값으로 같은 로그를 식별하는 키를 만드는 함수
+func entryKey(level types.LogLevel, entry *options.Entry, keyFields []string) string {
+	var sb strings.Builder
+	sb.WriteString(string(level))
+	sb.WriteByte(0)
+	sb.WriteString(entry.Message)
+	if len(keyFields) == 0 || (entry.Fields == nil && len(entry.Attrs) == 0) {
+		return sb.String()
+	}
+
+	var fields map[string]interface{}
+	if entry.Fields != nil {
+		fields = entry.Fields.ToFields()
+	}
+	for _, name := range keyFields {
+		sb.WriteByte(0)
+		value, ok := fields[name]
+		if !ok {
+			value = attrValue(entry.Attrs, name)
+		}
+		fmt.Fprint(&sb, value)
+	}
+	return sb.String()
+}
+
+// attrValue : 타입이 지정된 필드 중 키에 해당하는 마지막 값을 반환하는 함수
+func attrValue(attrs []options.Attr, key string) interface{} {
+	for i := len(attrs) - 1; i >= 0; i-- {
+		if attrs[i].Key == key {
+			return attrs[i].Value()
+		}
+	}
+	return nil
+}
+
+// sampledLogger : 샘플링을 적용한 뒤 백엔드 로거로 전달하는 데코레이터
+//   - 샘플링은 백엔드와 무관하게 동일하게 동작한다.
+type sampledLogger struct {
+	Logger
+	loggerType types.LoggerType
+	sampler    *sampler
+}
+
+func newSampledLogger(logger Logger, loggerType types.LoggerType, config options.Sampling) Logger {
+	return &sampledLogger{Logger: logger, loggerType: loggerType, sampler: newSampler(config)}
+}
+
+// WithContext : 컨텍스트에 로거를 등록하는 메서드
+func (l *sampledLogger) WithContext(ctx context.Context) context.Context {
+	key, _ := contextKey(l.loggerType)
+	return context.WithValue(ctx, key, l)
+}
+
+// Clone : 샘플링 상태를 공유하는 새 로거를 반환하는 메서드
+func (l *sampledLogger) Clone() Logger {
+	return &sampledLogger{Logger: l.Logger.Clone(), loggerType: l.loggerType, sampler: l.sampler}
+}
+
+// Debug : 디버그 로그를 출력하는 메서드
+func (l *sampledLogger) Debug(opts ...options.EntryOption) {
+	l.log(types.Debug, opts)
+}
+
+// Info : 정보 로그를 출력하는 메서드
+func (l *sampledLogger) Info(opts ...options.EntryOption) {
+	l.log(types.Info, opts)
+}
+
+// Warn : 경고 로그를 출력하는 메서드
+func (l *sampledLogger) Warn(opts ...options.EntryOption) {
+	l.log(types.Warn, opts)
+}
+
+// Error : 에러 로그를 출력하는 메서드
+func (l *sampledLogger) Error(opts ...options.EntryOption) {
+	l.log(types.Error, opts)
+}
+
+// Fatal : 치명적인 에러 로그를 출력하는 메서드
+func (l *sampledLogger) Fatal(opts ...options.EntryOption) {
+	l.log(types.Fatal, opts)
+}
+
+// Close : 아직 보고되지 않은 버려진 엔트리 수를 기록한 뒤 감싼 로거를 닫는 메서드 (logger.Close 참고)
+func (l *sampledLogger) Close() error {
+	for level, dropped := range l.sampler.flush() {
+		l.report(level, dropped)
+	}
+	return Close(l.Logger)
+}
+
+func (l *sampledLogger) log(level types.LogLevel, opts []options.EntryOption) {
+	allow, report := l.sampler.sample(level, options.NewEntry(opts...))
+	for reportLevel, dropped := range report {
+		l.report(reportLevel, dropped)
+	}
+	if allow {
+		Log(l.Logger, level, opts...)
+	}
+}
+
+// report : 버려진 엔트리 수를 콜백과 요약 로그로 알리는 메서드
+//
+// 요약 로그는 공통 필드만 가진 로거로 기록하며, fatal 엔트리의 요약도 프로세스를 종료하지 않도록 error 로 기록한다.
+func (l *sampledLogger) report(level types.LogLevel, dropped uint64) {
+	if l.sampler.config.OnDropped != nil {
+		l.sampler.config.OnDropped(level, dropped)
+	}
+	if l.sampler.config.DisableReport {
+		return
+	}
+	if level == types.Fatal {
+		level = types.Error
+	}
+	// 현재 로그의 엔트리 필드가 요약에 섞이지 않고, 요약 필드가 이후 로그에 남지 않도록 공통 필드만 복사한 로거로 기록
+	Log(cloneCommon(l.Logger), level,
+		options.WithMessage("log entries sampled out"),
+		options.WithFields(options.Fields{types.SampledOutField: dropped}),
+	)
+}
@@ -0,0 +1,149 @@
+package logger
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// ECSVersion : types.ECS 스키마로 기록하는 ecs.version 값
+const ECSVersion = "8.11.0"
+
+// ecsFields : 라이브러리 필드 이름과 ECS 필드 경로의 기본 매핑
+//
+// 매핑되지 않은 필드는 이름을 그대로 사용한다.
+var ecsFields = map[string]string{
+	types.TimeField:            "@timestamp",
+	types.LevelField:           "log.level",
+	types.MessageField:         "message",
+	types.CallerField:          "log.origin.file.name",
+	types.ComponentField:       "log.logger",
+	types.ErrorField:           "error.message",
+	types.PanicField:           "error.message",
+	types.StackField:           "error.stack_trace",
+	types.TraceIDField:         "trace.id",
+	types.SpanIDField:          "span.id",
+	types.RequestIDField:       "http.request.id",
+	types.MethodField:          "http.request.method",
+	types.RefererField:         "http.request.referrer",
+	types.RequestBodyField:     "http.request.body.content",
+	types.StatusCodeField:      "http.response.status_code",
+	types.BytesWrittenField:    "http.response.body.bytes",
+	types.ResponseBodyField:    "http.response.body.content",
+	types.URIField:             "url.original",
+	types.PathField:            "url.path",
+	types.HostField:            "url.domain",
+	types.UserAgentField:       "user_agent.original",
+	types.RemoteAddrField:      "client.address",
+	types.SQLQueryField:        "db.statement",
+	types.RepeatCountField:     "event.repeat_count",
+	types.GRPCServiceField:     "rpc.service",
+	types.GRPCMethodField:      "rpc.method",
+	types.GRPCCodeField:        "rpc.grpc.status_code",
+	types.PeerField:            "source.address",
+	types.RequestHeadersField:  "http.request.headers",
+	types.ResponseHeadersField: "http.response.headers",
+}
+
+// schemaTransformer : 백엔드가 만든 로그 필드를 스키마에 맞게 바꾸는 인터페이스
+type schemaTransformer interface {
+	// apply : 스키마에 맞게 바꾼 새 필드를 반환하는 메서드
+	apply(fields map[string]interface{}) map[string]interface{}
+}
+
+// newSchemaTransformer : 로그 설정의 스키마에 해당하는 schemaTransformer 를 생성하는 함수
+//
+// 스키마가 없거나 지원하지 않는 스키마인 경우 nil 을 반환한다.
+func newSchemaTransformer(settings options.LogSetting) schemaTransformer {
+	switch settings.Schema {
+	case types.ECS:
+		return newSchemaMapping(ecsFields, settings.SchemaMapping, map[string]interface{}{"ecs.version": ECSVersion})
+	case types.GCP:
+		return newGCPProfile(settings.GCPProject, settings.SchemaMapping)
+	default:
+		return nil
+	}
+}
+
+// schemaMapping : 로그 필드를 스키마의 필드 경로로 바꾸는 구조체
+type schemaMapping struct {
+	fields map[string]string
+	stamps map[string]interface{}
+}
+
+// newSchemaMapping : 기본 매핑에 사용자 매핑을 덮어쓴 schemaMapping 을 생성하는 함수
+//   - defaults(map[string]string): 스키마의 기본 매핑
+//   - overrides(map[string]string): 사용자 매핑
+//   - stamps(map[string]interface{}): 모든 로그에 기록할 경로와 값 (예: ecs.version)
+func newSchemaMapping(defaults, overrides map[string]string, stamps map[string]interface{}) *schemaMapping {
+	fields := make(map[string]string, len(defaults)+len(overrides))
+	for field, path := range defaults {
+		fields[field] = path
+	}
+	for field, path := range overrides {
+		fields[field] = path
+	}
+	return &schemaMapping{fields: fields, stamps: stamps}
+}
+
+// apply : 필드 이름을 스키마 경로로 바꾸고, 점(.)으로 구분한 경로에 따라 중첩된 맵으로 옮긴 새 필드를 반환하는 메서드
+//
+// 여러 필드가 같은 경로로 매핑된 경우 이름순으로 나중 필드의 값이 기록된다.
+// 경로의 중간에 맵이 아닌 값이 있으면 점으로 구분한 키 그대로 기록한다.
+func (m *schemaMapping) apply(fields map[string]interface{}) map[string]interface{} {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]interface{}, len(fields)+len(m.stamps))
+	for _, key := range keys {
+		path := key
+		if mapped, ok := m.fields[key]; ok && mapped != "" {
+			path = mapped
+		}
+		setPath(out, path, fields[key])
+	}
+	for path, value := range m.stamps {
+		setPath(out, path, value)
+	}
+	return out
+}
+
+// setPath : 점(.)으로 구분한 경로에 값을 기록하는 함수
+func setPath(dst map[string]interface{}, path string, value interface{}) {
+	segments := strings.Split(path, ".")
+	parent := dst
+	for _, segment := range segments[:len(segments)-1] {
+		switch child := parent[segment].(type) {
+		case map[string]interface{}:
+			parent = child
+		case nil:
+			next := map[string]interface{}{}
+			parent[segment] = next
+			parent = next
+		default:
+			// 맵이 아닌 값과 충돌하는 경우
+			dst[path] = value
+			return
+		}
+	}
+
+	setValue(parent, segments[len(segments)-1], value)
+}
+
+// setValue : 키에 값을 기록하는 함수, 기존 값과 새 값이 모두 맵이면 합친다.
+func setValue(dst map[string]interface{}, key string, value interface{}) {
+	existing, existingMap := dst[key].(map[string]interface{})
+	incoming, incomingMap := value.(map[string]interface{})
+	if !existingMap || !incomingMap {
+		dst[key] = value
+		return
+	}
+	for k, v := range incoming {
+		setValue(existing, k, v)
+	}
+}
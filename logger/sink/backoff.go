@@ -0,0 +1,47 @@
+package sink
+
+import "time"
+
+const (
+	// defaultMinBackoff : 재시도 대기 시간의 기본 최소값
+	defaultMinBackoff = 100 * time.Millisecond
+	// defaultMaxBackoff : 재시도 대기 시간의 기본 최대값
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// backoff : 지수 백오프 계산기
+//   - min(time.Duration): 최초 대기 시간
+//   - max(time.Duration): 최대 대기 시간
+type backoff struct {
+	min     time.Duration
+	max     time.Duration
+	current time.Duration
+}
+
+func newBackoff(min, max time.Duration) *backoff {
+	if min <= 0 {
+		min = defaultMinBackoff
+	}
+	if max < min {
+		max = min
+	}
+	return &backoff{min: min, max: max}
+}
+
+// next : 다음 대기 시간을 반환하고, 이후 대기 시간을 두 배로 늘리는 메서드
+func (b *backoff) next() time.Duration {
+	if b.current == 0 {
+		b.current = b.min
+		return b.current
+	}
+	b.current *= 2
+	if b.current > b.max {
+		b.current = b.max
+	}
+	return b.current
+}
+
+// reset : 대기 시간을 최초 상태로 되돌리는 메서드
+func (b *backoff) reset() {
+	b.current = 0
+}
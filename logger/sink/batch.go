@@ -0,0 +1,142 @@
+package sink
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// record : 배치에 담기는 로그 라인
+//   - line([]byte): 개행 문자를 제외한 로그 라인
+//   - time(time.Time): 싱크에 기록된 시각
+type record struct {
+	line []byte
+	time time.Time
+}
+
+// batcher : 로그 라인을 모아 크기/주기 단위로 flush 함수에 전달하는 버퍼
+//
+// flush 는 하나의 고루틴에서 순서대로 호출된다.
+type batcher struct {
+	settings setting
+	flush    func(batch []record)
+
+	mu      sync.Mutex
+	pending []record
+	bytes   int
+	closed  bool
+
+	dropped atomic.Uint64
+	kick    chan struct{}
+	closing chan struct{}
+	done    chan struct{}
+}
+
+func newBatcher(settings setting, flush func(batch []record)) *batcher {
+	b := &batcher{
+		settings: settings,
+		flush:    flush,
+		kick:     make(chan struct{}, 1),
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// add : 로그 라인을 복사하여 배치에 추가하는 메서드
+func (b *batcher) add(p []byte) error {
+	line := make([]byte, len(p))
+	copy(line, p)
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		line = line[:n-1]
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+	if b.settings.queueSize > 0 && len(b.pending) >= b.settings.queueSize {
+		b.dropped.Add(1)
+		return nil
+	}
+	b.pending = append(b.pending, record{line: line, time: time.Now()})
+	b.bytes += len(line)
+	if len(b.pending) >= b.settings.batchEntries || b.bytes >= b.settings.batchBytes {
+		select {
+		case b.kick <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// close : 남은 라인을 모두 전달하고 배치 루프를 종료하는 메서드
+func (b *batcher) close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.closing)
+	<-b.done
+}
+
+func (b *batcher) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.settings.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.closing:
+			for b.drain() {
+			}
+			return
+		case <-ticker.C:
+			for b.drain() {
+			}
+		case <-b.kick:
+			for b.full() && b.drain() {
+			}
+		}
+	}
+}
+
+// full : 대기 중인 라인이 배치 크기에 도달했는지 확인하는 메서드
+func (b *batcher) full() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending) >= b.settings.batchEntries || b.bytes >= b.settings.batchBytes
+}
+
+// drain : 배치 하나를 꺼내 flush 하는 메서드, 배치가 없으면 false 를 반환
+func (b *batcher) drain() bool {
+	b.mu.Lock()
+	n, size := 0, 0
+	for n < len(b.pending) && n < b.settings.batchEntries {
+		if n > 0 && size+len(b.pending[n].line) > b.settings.batchBytes {
+			break
+		}
+		size += len(b.pending[n].line)
+		n++
+	}
+	if n == 0 {
+		b.mu.Unlock()
+		return false
+	}
+	batch := make([]record, n)
+	copy(batch, b.pending)
+	b.pending = b.pending[n:]
+	b.bytes -= size
+	b.mu.Unlock()
+
+	b.flush(batch)
+	return true
+}
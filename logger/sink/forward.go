@@ -0,0 +1,394 @@
+package sink
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// ForwardMode : Fluent forward 프로토콜의 전송 모드
+//
+// Example:
+//
+//	// 엔트리마다 하나의 메시지 ([tag, time, record, option])
+//	mode := sink.ForwardMessage
+//	// 같은 태그의 엔트리를 배열로 묶어 전송 ([tag, [[time, record], ...], option])
+//	mode := sink.ForwardForward
+//	// 같은 태그의 엔트리를 msgpack 스트림으로 묶어 전송 ([tag, bin, option])
+//	mode := sink.ForwardPacked
+type ForwardMode string
+
+const (
+	// ForwardMessage : Message 모드
+	ForwardMessage ForwardMode = "message"
+	// ForwardForward : Forward 모드
+	ForwardForward ForwardMode = "forward"
+	// ForwardPacked : PackedForward 모드
+	ForwardPacked ForwardMode = "packed"
+)
+
+// forwardSetting : Fluent forward 싱크 설정
+type forwardSetting struct {
+	mode     ForwardMode
+	tag      string
+	tagField string
+	ack      bool
+}
+
+// WithForwardMode Fluent forward 전송 모드를 설정하는 옵션 (default: ForwardForward)
+func WithForwardMode(mode ForwardMode) Option {
+	return func(setting *setting) {
+		setting.forward.mode = mode
+	}
+}
+
+// WithTag Fluent forward 태그를 설정하는 옵션
+//   - tag(string): 기본 태그
+//   - field(string): 태그로 사용할 필드 (공통 필드 등), 엔트리에 값이 없으면 기본 태그를 사용
+//
+// Example:
+//
+//	// service 공통 필드 값을 태그로 사용
+//	log.RegisterCommonField("service", "app.payment")
+//	sink.WithTag("app", "service")
+func WithTag(tag, field string) Option {
+	return func(setting *setting) {
+		setting.forward.tag = tag
+		setting.forward.tagField = field
+	}
+}
+
+// WithAck 전송 후 서버의 ack 응답을 기다리는지 설정하는 옵션 (default: false)
+//   - ack 응답을 받지 못하면 재전송하므로 at-least-once 전송이 보장됨
+func WithAck(enabled bool) Option {
+	return func(setting *setting) {
+		setting.forward.ack = enabled
+	}
+}
+
+// Forward : Fluentd / Fluent Bit 의 forward 입력으로 로그를 전송하는 싱크
+//
+// 엔트리의 EventTime 은 로그의 time 필드(WithTimeFormat 으로 읽음)이며, 읽을 수 없으면 싱크에 기록된 시각이다.
+//
+// Example:
+//
+//	s, err := sink.NewForward("tcp", "127.0.0.1:24224",
+//		sink.WithForwardMode(sink.ForwardPacked),
+//		sink.WithTag("app", "service"),
+//		sink.WithAck(true),
+//	)
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer s.Close()
+//	log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+type Forward struct {
+	network  string
+	address  string
+	settings setting
+	batcher  *batcher
+	conn     net.Conn
+
+	mu      sync.Mutex
+	lastErr error
+	failed  atomic.Uint64
+	closing chan struct{}
+	once    sync.Once
+	err     error
+}
+
+// NewForward : Fluent forward 싱크 생성자
+//   - network(string): "tcp" 또는 "unix"
+//   - address(string): forward 입력 주소 (host:port 또는 소켓 경로)
+//   - opts(...Option): 싱크 설정 옵션 (WithForwardMode, WithTag, WithAck, WithTimeFormat, WithBatch, WithFlushInterval, WithBackoff, WithRetries 등)
+func NewForward(network, address string, opts ...Option) (*Forward, error) {
+	settings := newSetting(opts)
+	switch settings.forward.mode {
+	case ForwardMessage, ForwardForward, ForwardPacked:
+	default:
+		return nil, fmt.Errorf("sink: unsupported forward mode %q", settings.forward.mode)
+	}
+	if settings.batchEntries <= 0 || settings.batchBytes <= 0 || settings.flushInterval <= 0 {
+		return nil, fmt.Errorf("sink: batch size and flush interval must be positive")
+	}
+
+	f := &Forward{network: network, address: address, settings: settings, closing: make(chan struct{})}
+	f.batcher = newBatcher(settings, f.send)
+	return f, nil
+}
+
+// Write : 로그 라인을 배치에 추가하는 메서드 (io.Writer 구현)
+func (f *Forward) Write(p []byte) (int, error) {
+	if err := f.batcher.add(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close : 남은 배치를 전송하고 연결을 닫는 메서드
+//
+// 여러 고루틴에서 호출해도 한 번만 닫으며, 먼저 호출한 Close 가 끝날 때까지 기다린다.
+func (f *Forward) Close() error {
+	f.once.Do(func() {
+		close(f.closing)
+		f.batcher.close()
+		if f.conn != nil {
+			f.err = f.conn.Close()
+		}
+	})
+	return f.err
+}
+
+// Health : 마지막 전송이 실패했다면 그 에러를 반환하는 메서드
+func (f *Forward) Health() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lastErr
+}
+
+// Dropped : 큐가 가득 찼거나 재시도 끝에 전송하지 못해 버려진 로그 라인 수를 반환하는 메서드
+func (f *Forward) Dropped() uint64 {
+	return f.batcher.dropped.Load() + f.failed.Load()
+}
+
+// forwardEntry : 태그가 정해진 로그 엔트리
+type forwardEntry struct {
+	time   time.Time
+	record map[string]interface{}
+}
+
+// send : 배치를 태그별로 묶어 설정된 모드로 전송하는 메서드
+func (f *Forward) send(batch []record) {
+	var (
+		tags   []string
+		groups = map[string][]forwardEntry{}
+	)
+	for _, r := range batch {
+		fields, err := decodeLine(r.line)
+		if err != nil {
+			fields = map[string]interface{}{types.MessageField: string(r.line)}
+		}
+		tag := f.tag(fields)
+		if _, ok := groups[tag]; !ok {
+			tags = append(tags, tag)
+		}
+		at := entryTime(fields, f.settings.timeFormat, r.time)
+		groups[tag] = append(groups[tag], forwardEntry{time: at, record: fields})
+	}
+
+	for _, tag := range tags {
+		entries := groups[tag]
+		if f.settings.forward.mode == ForwardMessage {
+			for _, entry := range entries {
+				f.deliver(1, func(chunk string) ([]byte, error) {
+					return encodeMessage(tag, entry, chunk)
+				})
+			}
+			continue
+		}
+		f.deliver(len(entries), func(chunk string) ([]byte, error) {
+			return encodeForward(f.settings.forward.mode, tag, entries, chunk)
+		})
+	}
+}
+
+// tag : 엔트리의 태그 필드 값을 태그로 사용하고, 없으면 기본 태그를 반환하는 메서드
+func (f *Forward) tag(fields map[string]interface{}) string {
+	if f.settings.forward.tagField != "" {
+		if tag, ok := fields[f.settings.forward.tagField].(string); ok && tag != "" {
+			return tag
+		}
+	}
+	return f.settings.forward.tag
+}
+
+// deliver : 메시지를 전송하고, 실패 시 재연결하여 재시도하는 메서드
+//   - count(int): 메시지에 담긴 엔트리 수
+//   - encode(func): ack 청크 ID 를 받아 메시지를 인코딩하는 함수
+func (f *Forward) deliver(count int, encode func(chunk string) ([]byte, error)) {
+	var chunk string
+	if f.settings.forward.ack {
+		chunk = newChunkID()
+	}
+	message, err := encode(chunk)
+	if err != nil {
+		f.fail(count, err)
+		return
+	}
+
+	retry := newBackoff(f.settings.minBackoff, f.settings.maxBackoff)
+	for attempt := 0; ; attempt++ {
+		if err = f.write(message, chunk); err == nil {
+			f.mu.Lock()
+			f.lastErr = nil
+			f.mu.Unlock()
+			return
+		}
+		if f.conn != nil {
+			_ = f.conn.Close()
+			f.conn = nil
+		}
+		if attempt >= f.settings.maxRetries {
+			f.fail(count, err)
+			return
+		}
+		select {
+		case <-f.closing:
+			f.fail(count, err)
+			return
+		case <-time.After(retry.next()):
+		}
+	}
+}
+
+// write : 메시지를 한 번 전송하고, ack 옵션이 있으면 응답을 확인하는 메서드
+func (f *Forward) write(message []byte, chunk string) error {
+	if f.conn == nil {
+		conn, err := net.DialTimeout(f.network, f.address, f.settings.dialTimeout)
+		if err != nil {
+			return err
+		}
+		f.conn = conn
+	}
+
+	if f.settings.writeTimeout > 0 {
+		_ = f.conn.SetDeadline(time.Now().Add(f.settings.writeTimeout))
+	}
+	if _, err := f.conn.Write(message); err != nil {
+		return err
+	}
+	if chunk == "" {
+		return nil
+	}
+
+	var resp struct {
+		Ack string `msgpack:"ack"`
+	}
+	if err := msgpack.NewDecoder(f.conn).Decode(&resp); err != nil {
+		return fmt.Errorf("sink: read forward ack: %w", err)
+	}
+	if resp.Ack != chunk {
+		return fmt.Errorf("sink: forward ack mismatch: want %q, got %q", chunk, resp.Ack)
+	}
+	return nil
+}
+
+// fail : 전송하지 못한 엔트리 수와 에러를 기록하는 메서드
+func (f *Forward) fail(count int, err error) {
+	f.failed.Add(uint64(count))
+	f.mu.Lock()
+	f.lastErr = err
+	f.mu.Unlock()
+}
+
+// encodeMessage : Message 모드 메시지 ([tag, time, record, option])를 인코딩하는 함수
+func encodeMessage(tag string, entry forwardEntry, chunk string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetSortMapKeys(true)
+
+	size := 3
+	if chunk != "" {
+		size = 4
+	}
+	if err := enc.EncodeArrayLen(size); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeString(tag); err != nil {
+		return nil, err
+	}
+	if err := encodeEntry(enc, entry, false); err != nil {
+		return nil, err
+	}
+	if chunk != "" {
+		if err := enc.Encode(map[string]interface{}{"chunk": chunk}); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeForward : Forward/PackedForward 모드 메시지를 인코딩하는 함수
+func encodeForward(mode ForwardMode, tag string, entries []forwardEntry, chunk string) ([]byte, error) {
+	var (
+		entriesBuf bytes.Buffer
+		entriesEnc = msgpack.NewEncoder(&entriesBuf)
+	)
+	entriesEnc.SetSortMapKeys(true)
+	if mode == ForwardForward {
+		if err := entriesEnc.EncodeArrayLen(len(entries)); err != nil {
+			return nil, err
+		}
+	}
+	for _, entry := range entries {
+		if err := encodeEntry(entriesEnc, entry, true); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	if err := enc.EncodeArrayLen(3); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeString(tag); err != nil {
+		return nil, err
+	}
+	if mode == ForwardForward {
+		buf.Write(entriesBuf.Bytes())
+	} else if err := enc.EncodeBytes(entriesBuf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	option := map[string]interface{}{"size": len(entries)}
+	if chunk != "" {
+		option["chunk"] = chunk
+	}
+	enc.SetSortMapKeys(true)
+	if err := enc.Encode(option); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeEntry : [time, record] 쌍을 인코딩하는 함수
+//   - wrap(bool): true 인 경우 배열 헤더를 함께 인코딩 (Forward/PackedForward 모드)
+func encodeEntry(enc *msgpack.Encoder, entry forwardEntry, wrap bool) error {
+	if wrap {
+		if err := enc.EncodeArrayLen(2); err != nil {
+			return err
+		}
+	}
+	if err := encodeEventTime(enc, entry.time); err != nil {
+		return err
+	}
+	return enc.Encode(entry.record)
+}
+
+// encodeEventTime : Fluent forward 의 EventTime 확장 타입(ext 0)으로 시각을 인코딩하는 함수
+func encodeEventTime(enc *msgpack.Encoder, t time.Time) error {
+	if err := enc.EncodeExtHeader(0, 8); err != nil {
+		return err
+	}
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:4], uint32(t.Unix()))
+	binary.BigEndian.PutUint32(b[4:], uint32(t.Nanosecond()))
+	_, err := enc.Writer().Write(b[:])
+	return err
+}
+
+// newChunkID : ack 확인을 위한 청크 ID 를 생성하는 함수
+func newChunkID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return base64.StdEncoding.EncodeToString(b[:])
+}
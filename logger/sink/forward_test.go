@@ -0,0 +1,255 @@
+package sink_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/sink"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+func init() {
+	msgpack.RegisterExt(0, (*eventTime)(nil))
+}
+
+func TestForward(t *testing.T) {
+	for _, mode := range []sink.ForwardMode{sink.ForwardMessage, sink.ForwardForward, sink.ForwardPacked} {
+		mode := mode
+		t.Run(string(mode)+" 모드로 태그별 엔트리가 전송되는지 테스트", func(t *testing.T) {
+			// given
+			server := newForwardServer(t)
+			s, err := sink.NewForward("tcp", server.Addr(),
+				sink.WithForwardMode(mode),
+				sink.WithTag("app", "service"),
+				sink.WithAck(true),
+				sink.WithFlushInterval(time.Hour),
+			)
+			require.NoError(t, err)
+			payment := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+			payment.RegisterCommonField("service", "app.payment")
+			plain := logger.NewWrapper(types.Logrus, options.WithOutput(s))
+
+			// when
+			payment.Info(options.WithMessage("paid"))
+			plain.Info(options.WithMessage("plain"))
+			payment.Info(options.WithMessage("refunded"))
+			require.NoError(t, s.Close())
+
+			// then
+			entries := server.Entries()
+			require.Len(t, entries, 3)
+			assert.Equal(t, "app.payment", entries[0].tag)
+			assert.Equal(t, "paid", entries[0].record[types.MessageField])
+			assert.Equal(t, "app.payment", entries[1].tag)
+			assert.Equal(t, "refunded", entries[1].record[types.MessageField])
+			assert.Equal(t, "app", entries[2].tag, "태그 필드가 없으면 기본 태그를 사용해야 합니다.")
+			assert.Equal(t, "plain", entries[2].record[types.MessageField])
+			assert.WithinDuration(t, time.Now(), entries[0].time, time.Minute, "EventTime 이 전송되어야 합니다.")
+			assert.NoError(t, s.Health())
+		})
+	}
+
+	t.Run("로그의 time 필드가 EventTime 으로 전송되는지 테스트", func(t *testing.T) {
+		// given
+		server := newForwardServer(t)
+		s, err := sink.NewForward("tcp", server.Addr(), sink.WithAck(true), sink.WithFlushInterval(time.Hour))
+		require.NoError(t, err)
+		at := time.Date(2024, 3, 1, 9, 30, 15, 0, time.Local)
+
+		// when
+		_, _ = s.Write([]byte(`{"message":"buffered","time":"` + at.Format("2006-01-02 15:04:05") + `"}`))
+		_, _ = s.Write([]byte(`{"message":"unknown","time":"yesterday"}`))
+		require.NoError(t, s.Close())
+
+		// then
+		entries := server.Entries()
+		require.Len(t, entries, 2)
+		assert.True(t, at.Equal(entries[0].time), "싱크에 기록된 시각이 아닌 로그의 시각이어야 합니다.")
+		assert.WithinDuration(t, time.Now(), entries[1].time, time.Minute, "읽을 수 없는 시각은 싱크에 기록된 시각이어야 합니다.")
+	})
+
+	t.Run("ack 응답이 없으면 재전송하는지 테스트", func(t *testing.T) {
+		// given
+		server := newForwardServer(t)
+		server.skipAcks(1)
+		s, err := sink.NewForward("tcp", server.Addr(),
+			sink.WithAck(true),
+			sink.WithWriteTimeout(100*time.Millisecond),
+			sink.WithBackoff(time.Millisecond, 5*time.Millisecond),
+			sink.WithFlushInterval(10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		defer s.Close()
+
+		// when
+		_, _ = s.Write([]byte(`{"message":"retry","count":3}`))
+
+		// then
+		require.Eventually(t, func() bool { return len(server.Entries()) == 2 }, 2*time.Second, 5*time.Millisecond,
+			"ack 를 받지 못한 메시지는 재전송되어야 합니다.")
+		entries := server.Entries()
+		assert.Equal(t, int64(3), toInt64(entries[1].record["count"]), "숫자 타입이 유지되어야 합니다.")
+		assert.Equal(t, uint64(0), s.Dropped())
+	})
+}
+
+// forwardEntry : 테스트 서버가 수신한 엔트리
+type forwardEntry struct {
+	tag    string
+	time   time.Time
+	record map[string]interface{}
+}
+
+// forwardServer : Fluent forward 입력을 흉내내는 테스트 서버
+type forwardServer struct {
+	ln      net.Listener
+	mu      sync.Mutex
+	entries []forwardEntry
+	skip    int
+}
+
+func newForwardServer(t *testing.T) *forwardServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &forwardServer{ln: ln}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(t, conn)
+		}
+	}()
+	return server
+}
+
+func (s *forwardServer) Addr() string {
+	return s.ln.Addr().String()
+}
+
+func (s *forwardServer) skipAcks(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skip = n
+}
+
+func (s *forwardServer) Entries() []forwardEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]forwardEntry(nil), s.entries...)
+}
+
+func (s *forwardServer) serve(t *testing.T, conn net.Conn) {
+	defer conn.Close()
+	dec := msgpack.NewDecoder(conn)
+	for {
+		var message []interface{}
+		if err := dec.Decode(&message); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				t.Logf("forward server: %v", err)
+			}
+			return
+		}
+
+		tag := message[0].(string)
+		var (
+			entries []forwardEntry
+			option  map[string]interface{}
+		)
+		switch payload := message[1].(type) {
+		case *eventTime: // Message 모드
+			entries = append(entries, forwardEntry{tag: tag, time: payload.Time, record: toMap(message[2])})
+			if len(message) > 3 {
+				option = toMap(message[3])
+			}
+		case []interface{}: // Forward 모드
+			for _, item := range payload {
+				pair := item.([]interface{})
+				entries = append(entries, forwardEntry{tag: tag, time: pair[0].(*eventTime).Time, record: toMap(pair[1])})
+			}
+			option = toMap(message[2])
+		case []byte: // PackedForward 모드
+			stream := msgpack.NewDecoder(bytes.NewReader(payload))
+			for {
+				var pair []interface{}
+				if err := stream.Decode(&pair); err != nil {
+					break
+				}
+				entries = append(entries, forwardEntry{tag: tag, time: pair[0].(*eventTime).Time, record: toMap(pair[1])})
+			}
+			option = toMap(message[2])
+		}
+
+		s.mu.Lock()
+		s.entries = append(s.entries, entries...)
+		skip := s.skip > 0
+		if skip {
+			s.skip--
+		}
+		s.mu.Unlock()
+
+		if chunk, ok := option["chunk"].(string); ok && !skip {
+			ack, _ := msgpack.Marshal(map[string]string{"ack": chunk})
+			_, _ = conn.Write(ack)
+		}
+	}
+}
+
+// eventTime : Fluent forward 의 EventTime 확장 타입
+type eventTime struct {
+	time.Time
+}
+
+func (e *eventTime) UnmarshalMsgpack(b []byte) error {
+	e.Time = time.Unix(int64(binary.BigEndian.Uint32(b[:4])), int64(binary.BigEndian.Uint32(b[4:])))
+	return nil
+}
+
+func (e *eventTime) MarshalMsgpack() ([]byte, error) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint32(b[:4], uint32(e.Unix()))
+	binary.BigEndian.PutUint32(b[4:], uint32(e.Nanosecond()))
+	return b, nil
+}
+
+func toMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case int8:
+		return int64(n)
+	case int16:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	case uint8:
+		return int64(n)
+	case uint16:
+		return int64(n)
+	case uint32:
+		return int64(n)
+	case uint64:
+		return int64(n)
+	default:
+		return -1
+	}
+}
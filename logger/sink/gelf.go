@@ -0,0 +1,401 @@
+package sink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// GELFCompression : GELF UDP 메시지 압축 방식
+type GELFCompression string
+
+const (
+	// GELFGzip : gzip 압축
+	GELFGzip GELFCompression = "gzip"
+	// GELFZlib : zlib 압축
+	GELFZlib GELFCompression = "zlib"
+	// GELFNone : 압축하지 않음
+	GELFNone GELFCompression = "none"
+)
+
+const (
+	// gelfVersion : GELF 스펙 버전
+	gelfVersion = "1.1"
+	// gelfMaxChunks : GELF UDP 메시지 하나의 최대 청크 수
+	gelfMaxChunks = 128
+	// gelfChunkHeaderSize : 청크 헤더 크기 (매직 2 + 메시지 ID 8 + 순번 1 + 개수 1)
+	gelfChunkHeaderSize = 12
+)
+
+// errGELFTooLarge : 청크로 나누어도 전송할 수 없는 크기의 메시지
+var errGELFTooLarge = fmt.Errorf("sink: gelf message exceeds %d chunks", gelfMaxChunks)
+
+// gelfChunkMagic : GELF UDP 청크의 매직 바이트
+var gelfChunkMagic = []byte{0x1e, 0x0f}
+
+// gelfFieldName : GELF 추가 필드 이름으로 허용되는 문자
+var gelfFieldName = regexp.MustCompile(`^[\w.\-]+$`)
+
+// gelfReserved : 추가 필드로 보낼 수 없는 예약된 이름 (밑줄을 붙이기 전 이름)
+var gelfReserved = map[string]bool{"id": true}
+
+// gelfSetting : GELF 싱크 설정
+type gelfSetting struct {
+	host        string
+	compression GELFCompression
+	chunkSize   int
+}
+
+// WithGELFHost GELF host 필드를 설정하는 옵션 (default: os.Hostname)
+func WithGELFHost(host string) Option {
+	return func(setting *setting) {
+		setting.gelf.host = host
+	}
+}
+
+// WithGELFCompression GELF UDP 메시지 압축 방식을 설정하는 옵션 (default: GELFGzip, TCP 는 압축하지 않음)
+func WithGELFCompression(compression GELFCompression) Option {
+	return func(setting *setting) {
+		setting.gelf.compression = compression
+	}
+}
+
+// WithGELFChunkSize GELF UDP 청크 하나의 최대 크기(헤더 포함)를 설정하는 옵션 (default: 1420 바이트)
+//
+// 압축한 메시지가 이 크기보다 크면 청크로 나누어 전송하며, 128 개를 넘으면 버려진다.
+func WithGELFChunkSize(size int) Option {
+	return func(setting *setting) {
+		setting.gelf.chunkSize = size
+	}
+}
+
+// GELF : Graylog 의 GELF 1.1 입력으로 로그를 전송하는 싱크
+//
+// 로그 필드는 다음과 같이 변환된다.
+//   - message: short_message (첫 줄), 여러 줄이거나 stack 필드가 있으면 전체를 full_message 로
+//   - level: syslog 심각도 숫자 (types.LogLevel.SyslogPriority)
+//   - time: timestamp (초 단위, 소수점 이하 밀리초), WithTimeFormat 으로 읽을 수 없으면 싱크에 기록된 시각
+//   - 나머지 공통 필드, 엔트리 필드: 밑줄을 붙인 추가 필드 (중첩된 맵은 점으로 연결한 이름)
+//
+// 예약된 이름(id)이나 허용되지 않는 문자가 있는 필드는 보내지 않으며, Rejected 로 그 수를 확인할 수 있다.
+// UDP 는 압축 후 청크로 나누어 전송하고, TCP 는 압축하지 않고 널 바이트로 구분하여 전송한다.
+//
+// Example:
+//
+//	s, err := sink.NewGELF("udp", "graylog:12201", sink.WithGELFCompression(sink.GELFZlib))
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer s.Close()
+//	log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+type GELF struct {
+	network  string
+	address  string
+	settings setting
+	batcher  *batcher
+	conn     net.Conn
+
+	mu       sync.Mutex
+	lastErr  error
+	failed   atomic.Uint64
+	rejected atomic.Uint64
+	closing  chan struct{}
+	once     sync.Once
+	err      error
+}
+
+// NewGELF : GELF 싱크 생성자
+//   - network(string): "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6"
+//   - address(string): GELF 입력 주소 (host:port)
+//   - opts(...Option): 싱크 설정 옵션 (WithGELFHost, WithGELFCompression, WithGELFChunkSize, WithFlushInterval, WithBackoff, WithRetries 등)
+func NewGELF(network, address string, opts ...Option) (*GELF, error) {
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("sink: unsupported network %q", network)
+	}
+
+	settings := newSetting(opts)
+	switch settings.gelf.compression {
+	case GELFGzip, GELFZlib, GELFNone:
+	default:
+		return nil, fmt.Errorf("sink: unsupported gelf compression %q", settings.gelf.compression)
+	}
+	if settings.gelf.chunkSize <= gelfChunkHeaderSize {
+		return nil, fmt.Errorf("sink: gelf chunk size must be larger than %d", gelfChunkHeaderSize)
+	}
+	if settings.gelf.host == "" {
+		settings.gelf.host, _ = os.Hostname()
+	}
+
+	g := &GELF{network: network, address: address, settings: settings, closing: make(chan struct{})}
+	g.batcher = newBatcher(settings, g.send)
+	return g, nil
+}
+
+// Write : 로그 라인을 전송 큐에 추가하는 메서드 (io.Writer 구현)
+func (g *GELF) Write(p []byte) (int, error) {
+	if err := g.batcher.add(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close : 남은 로그를 전송하고 연결을 닫는 메서드
+//
+// 여러 고루틴에서 호출해도 한 번만 닫으며, 먼저 호출한 Close 가 끝날 때까지 기다린다.
+func (g *GELF) Close() error {
+	g.once.Do(func() {
+		close(g.closing)
+		g.batcher.close()
+		if g.conn != nil {
+			g.err = g.conn.Close()
+		}
+	})
+	return g.err
+}
+
+// Health : 마지막 전송이 실패했다면 그 에러를 반환하는 메서드
+func (g *GELF) Health() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.lastErr
+}
+
+// Dropped : 큐가 가득 찼거나, 너무 크거나, 재시도 끝에 전송하지 못해 버려진 로그 라인 수를 반환하는 메서드
+func (g *GELF) Dropped() uint64 {
+	return g.batcher.dropped.Load() + g.failed.Load()
+}
+
+// Rejected : 예약된 이름이나 허용되지 않는 이름이어서 보내지 않은 필드 수를 반환하는 메서드
+func (g *GELF) Rejected() uint64 {
+	return g.rejected.Load()
+}
+
+// send : 배치의 로그 라인을 GELF 메시지로 변환하여 전송하는 메서드
+func (g *GELF) send(batch []record) {
+	for _, r := range batch {
+		fields, err := decodeLine(r.line)
+		if err != nil {
+			fields = map[string]interface{}{types.MessageField: string(r.line)}
+		}
+		message, err := json.Marshal(g.encode(fields, entryTime(fields, g.settings.timeFormat, r.time)))
+		if err != nil {
+			g.fail(err)
+			continue
+		}
+		if g.udp() {
+			g.deliver(func() error { return g.writeChunks(message) })
+		} else {
+			g.deliver(func() error { return g.write(append(message, 0)) })
+		}
+	}
+}
+
+// encode : 로그 필드를 GELF 메시지 필드로 변환하는 메서드
+func (g *GELF) encode(fields map[string]interface{}, at time.Time) map[string]interface{} {
+	message, _ := fields[types.MessageField].(string)
+	levelText, _ := fields[types.LevelField].(string)
+	level, _ := types.ParseLevel(levelText)
+
+	gelf := map[string]interface{}{
+		"version":   gelfVersion,
+		"host":      g.settings.gelf.host,
+		"timestamp": float64(at.UnixMilli()) / 1000,
+		"level":     level.SyslogPriority(),
+	}
+
+	short, full := message, ""
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		short, full = message[:i], message
+	}
+	if stack, ok := fields[types.StackField].(string); ok && stack != "" {
+		if full == "" {
+			full = message
+		}
+		full += "\n" + stack
+	}
+	if strings.TrimSpace(short) == "" {
+		// short_message 는 비어 있을 수 없다.
+		short = "-"
+	}
+	gelf["short_message"] = short
+	if full != "" {
+		gelf["full_message"] = full
+	}
+
+	additional := map[string]interface{}{}
+	for key, value := range fields {
+		switch key {
+		case types.MessageField, types.LevelField, types.TimeField, types.StackField:
+			continue
+		}
+		flattenGELF(additional, key, value)
+	}
+	for key, value := range additional {
+		if gelfReserved[key] || !gelfFieldName.MatchString(key) {
+			g.rejected.Add(1)
+			continue
+		}
+		gelf["_"+key] = value
+	}
+	return gelf
+}
+
+// flattenGELF : 추가 필드 값을 GELF 가 허용하는 문자열 또는 숫자로 변환하는 함수
+//
+// 중첩된 맵은 점(.)으로 연결한 이름으로 펼치고, 배열은 JSON 문자열로 변환한다.
+func flattenGELF(dst map[string]interface{}, key string, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+	case string, int64, float64:
+		dst[key] = v
+	case bool:
+		dst[key] = strconv.FormatBool(v)
+	case map[string]interface{}:
+		for k, item := range v {
+			flattenGELF(dst, key+"."+k, item)
+		}
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			dst[key] = fmt.Sprint(v)
+			return
+		}
+		dst[key] = string(encoded)
+	}
+}
+
+// udp : UDP 로 전송하는지 여부를 반환하는 메서드
+func (g *GELF) udp() bool {
+	return strings.HasPrefix(g.network, "udp")
+}
+
+// deliver : 메시지를 전송하고, 실패 시 재연결하여 재시도하는 메서드
+func (g *GELF) deliver(write func() error) {
+	retry := newBackoff(g.settings.minBackoff, g.settings.maxBackoff)
+	for attempt := 0; ; attempt++ {
+		err := write()
+		if err == nil {
+			g.mu.Lock()
+			g.lastErr = nil
+			g.mu.Unlock()
+			return
+		}
+		if err == errGELFTooLarge {
+			g.fail(err)
+			return
+		}
+		if g.conn != nil {
+			_ = g.conn.Close()
+			g.conn = nil
+		}
+		if attempt >= g.settings.maxRetries {
+			g.fail(err)
+			return
+		}
+		select {
+		case <-g.closing:
+			g.fail(err)
+			return
+		case <-time.After(retry.next()):
+		}
+	}
+}
+
+// write : 연결이 없으면 연결한 뒤 데이터를 한 번 전송하는 메서드
+func (g *GELF) write(data []byte) error {
+	if g.conn == nil {
+		conn, err := net.DialTimeout(g.network, g.address, g.settings.dialTimeout)
+		if err != nil {
+			return err
+		}
+		g.conn = conn
+	}
+	if g.settings.writeTimeout > 0 {
+		_ = g.conn.SetWriteDeadline(time.Now().Add(g.settings.writeTimeout))
+	}
+	_, err := g.conn.Write(data)
+	return err
+}
+
+// writeChunks : 메시지를 압축하고, 필요하면 청크로 나누어 UDP 로 전송하는 메서드
+func (g *GELF) writeChunks(message []byte) error {
+	payload, err := compressGELF(message, g.settings.gelf.compression)
+	if err != nil {
+		return err
+	}
+	if len(payload) <= g.settings.gelf.chunkSize {
+		return g.write(payload)
+	}
+
+	size := g.settings.gelf.chunkSize - gelfChunkHeaderSize
+	count := (len(payload) + size - 1) / size
+	if count > gelfMaxChunks {
+		return errGELFTooLarge
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	chunk := make([]byte, 0, g.settings.gelf.chunkSize)
+	for seq := 0; seq < count; seq++ {
+		end := (seq + 1) * size
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunk = append(chunk[:0], gelfChunkMagic...)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(seq), byte(count))
+		chunk = append(chunk, payload[seq*size:end]...)
+		if err := g.write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compressGELF : 메시지를 압축하는 함수
+func compressGELF(message []byte, compression GELFCompression) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+	switch compression {
+	case GELFGzip:
+		w = gzip.NewWriter(&buf)
+	case GELFZlib:
+		w = zlib.NewWriter(&buf)
+	default:
+		return message, nil
+	}
+	if _, err := w.Write(message); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fail : 전송하지 못한 로그 라인과 에러를 기록하는 메서드
+func (g *GELF) fail(err error) {
+	g.failed.Add(1)
+	g.mu.Lock()
+	g.lastErr = err
+	g.mu.Unlock()
+}
@@ -0,0 +1,221 @@
+package sink_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/sink"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+func TestGELF(t *testing.T) {
+	t.Run("UDP 로 gzip 압축한 메시지가 청크로 나누어 전송되는지 테스트", func(t *testing.T) {
+		// given
+		server := newGELFServer(t)
+		s, err := sink.NewGELF("udp", server.Addr(),
+			sink.WithGELFHost("api-1"),
+			sink.WithGELFChunkSize(200),
+			sink.WithFlushInterval(10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		defer s.Close()
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+		log.RegisterCommonField("service", "payment")
+		payload := randomHex(t, 2000)
+
+		// when
+		log.Error(
+			options.WithMessage("payment failed\ncard declined"),
+			options.WithFields(options.Fields{
+				"payload":        payload,
+				"http":           map[string]interface{}{"status": 502},
+				"id":             "reserved",
+				"bad name":       "invalid",
+				"retry":          true,
+				types.StackField: "goroutine 1 [running]:",
+			}),
+		)
+
+		// then
+		message := server.Next(t)
+		assert.Greater(t, server.chunks, 1, "청크로 나누어 전송되어야 합니다.")
+		assert.Equal(t, "1.1", message["version"])
+		assert.Equal(t, "api-1", message["host"])
+		assert.Equal(t, "payment failed", message["short_message"])
+		assert.Equal(t, "payment failed\ncard declined\ngoroutine 1 [running]:", message["full_message"])
+		assert.Equal(t, float64(3), message["level"], "error 는 syslog 심각도 3 이어야 합니다.")
+		assert.IsType(t, float64(0), message["timestamp"])
+		assert.Equal(t, "payment", message["_service"], "공통 필드가 추가 필드로 전송되어야 합니다.")
+		assert.Equal(t, payload, message["_payload"])
+		assert.Equal(t, float64(502), message["_http.status"], "중첩된 맵은 점으로 연결한 이름이어야 합니다.")
+		assert.Equal(t, "true", message["_retry"])
+		assert.NotContains(t, message, "_id", "예약된 이름은 전송되지 않아야 합니다.")
+		assert.NotContains(t, message, "_bad name")
+		assert.NotContains(t, message, "_stack")
+		assert.Equal(t, uint64(2), s.Rejected())
+	})
+
+	t.Run("UDP 로 zlib 압축한 작은 메시지가 청크 없이 전송되는지 테스트", func(t *testing.T) {
+		// given
+		server := newGELFServer(t)
+		s, err := sink.NewGELF("udp", server.Addr(), sink.WithGELFCompression(sink.GELFZlib), sink.WithFlushInterval(10*time.Millisecond))
+		require.NoError(t, err)
+		defer s.Close()
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+
+		// when
+		log.Info(options.WithMessage("hello"))
+
+		// then
+		message := server.Next(t)
+		assert.Equal(t, 1, server.chunks)
+		assert.Equal(t, "zlib", server.compression)
+		assert.Equal(t, "hello", message["short_message"])
+		assert.Equal(t, float64(6), message["level"])
+		assert.NotContains(t, message, "full_message")
+	})
+
+	t.Run("로그의 time 필드가 timestamp 로 전송되는지 테스트", func(t *testing.T) {
+		// given
+		server := newGELFServer(t)
+		s, err := sink.NewGELF("udp", server.Addr(),
+			sink.WithTimeFormat(time.RFC3339Nano),
+			sink.WithFlushInterval(10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		defer s.Close()
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s), options.WithTimeFormat(time.RFC3339Nano))
+		at := time.Date(2024, 3, 1, 9, 30, 15, 250*int(time.Millisecond), time.UTC)
+
+		// when
+		log.Info(options.WithMessage("buffered"), options.WithTime(at))
+
+		// then
+		message := server.Next(t)
+		assert.Equal(t, float64(at.UnixMilli())/1000, message["timestamp"], "싱크에 기록된 시각이 아닌 로그의 시각이어야 합니다.")
+	})
+
+	t.Run("TCP 로 널 바이트로 구분한 메시지가 전송되는지 테스트", func(t *testing.T) {
+		// given
+		ln := listen(t, "127.0.0.1:0")
+		s, err := sink.NewGELF("tcp", ln.Addr().String(), sink.WithFlushInterval(10*time.Millisecond))
+		require.NoError(t, err)
+		defer s.Close()
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+
+		// when
+		log.Clone().Warn(options.WithMessage("first"))
+		log.Clone().Info(options.WithMessage("second"))
+
+		// then
+		conn := accept(t, ln)
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		reader := bufio.NewReader(conn)
+		for _, want := range []string{"first", "second"} {
+			frame, err := reader.ReadBytes(0)
+			require.NoError(t, err)
+			var message map[string]interface{}
+			require.NoError(t, json.Unmarshal(frame[:len(frame)-1], &message))
+			assert.Equal(t, want, message["short_message"])
+		}
+	})
+}
+
+// gelfServer : GELF UDP 청크를 재조립하는 테스트용 서버
+type gelfServer struct {
+	conn        net.PacketConn
+	chunks      int
+	compression string
+}
+
+func newGELFServer(t *testing.T) *gelfServer {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return &gelfServer{conn: conn}
+}
+
+func (s *gelfServer) Addr() string {
+	return s.conn.LocalAddr().String()
+}
+
+// Next : 다음 메시지를 (청크인 경우 모두 받아 재조립한 뒤) 압축을 풀어 반환하는 메서드
+func (s *gelfServer) Next(t *testing.T) map[string]interface{} {
+	t.Helper()
+	_ = s.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	var (
+		payload []byte
+		parts   map[byte][]byte
+		id      []byte
+		total   int
+	)
+	s.chunks = 0
+	buf := make([]byte, 65536)
+	for {
+		n, _, err := s.conn.ReadFrom(buf)
+		require.NoError(t, err)
+		packet := append([]byte(nil), buf[:n]...)
+		s.chunks++
+
+		if !bytes.HasPrefix(packet, []byte{0x1e, 0x0f}) {
+			payload = packet
+			break
+		}
+		require.GreaterOrEqual(t, len(packet), 12)
+		if parts == nil {
+			parts, id, total = map[byte][]byte{}, packet[2:10], int(packet[11])
+		}
+		require.Equal(t, id, packet[2:10], "같은 메시지 ID 여야 합니다.")
+		parts[packet[10]] = packet[12:]
+		if len(parts) == total {
+			for seq := 0; seq < total; seq++ {
+				payload = append(payload, parts[byte(seq)]...)
+			}
+			break
+		}
+	}
+
+	var reader io.Reader = bytes.NewReader(payload)
+	switch {
+	case bytes.HasPrefix(payload, []byte{0x1f, 0x8b}):
+		s.compression = "gzip"
+		gz, err := gzip.NewReader(reader)
+		require.NoError(t, err)
+		reader = gz
+	case len(payload) > 0 && payload[0] == 0x78:
+		s.compression = "zlib"
+		zr, err := zlib.NewReader(reader)
+		require.NoError(t, err)
+		reader = zr
+	default:
+		s.compression = "none"
+	}
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	var message map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &message))
+	return message
+}
+
+func randomHex(t *testing.T, n int) string {
+	t.Helper()
+	b := make([]byte, n/2)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,397 @@
+package sink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// HTTPFormat : HTTP 싱크의 요청 본문 포맷
+//
+// Example:
+//
+//	// JSON 배열
+//	format := sink.HTTPJSON
+//	// Loki push API
+//	format := sink.HTTPLoki
+//	// Elasticsearch _bulk API
+//	format := sink.HTTPElasticsearch
+type HTTPFormat string
+
+const (
+	// HTTPJSON : 로그 엔트리의 JSON 배열 ([{...},{...}])
+	HTTPJSON HTTPFormat = "json"
+	// HTTPLoki : Loki push API 포맷 ({"streams":[{"stream":{...},"values":[[ts, line]]}]})
+	HTTPLoki HTTPFormat = "loki"
+	// HTTPElasticsearch : Elasticsearch _bulk API 의 NDJSON 포맷
+	HTTPElasticsearch HTTPFormat = "elasticsearch"
+)
+
+// WithHTTPClient HTTP 싱크가 사용할 클라이언트를 설정하는 옵션 (default: http.DefaultClient)
+func WithHTTPClient(client *http.Client) Option {
+	return func(setting *setting) {
+		setting.client = client
+	}
+}
+
+// WithHeader HTTP 싱크 요청에 헤더를 추가하는 옵션
+//
+// Example:
+//
+//	// 인증 헤더 추가
+//	sink.WithHeader("Authorization", "Bearer "+token)
+func WithHeader(key, value string) Option {
+	return func(setting *setting) {
+		setting.header.Add(key, value)
+	}
+}
+
+// WithHTTPFormat HTTP 싱크의 요청 본문 포맷을 설정하는 옵션 (default: HTTPJSON)
+func WithHTTPFormat(format HTTPFormat) Option {
+	return func(setting *setting) {
+		setting.httpFormat = format
+	}
+}
+
+// WithGzip 요청 본문의 gzip 압축 여부를 설정하는 옵션 (default: true)
+func WithGzip(enabled bool) Option {
+	return func(setting *setting) {
+		setting.gzip = enabled
+	}
+}
+
+// WithLokiLabels Loki 스트림 라벨을 설정하는 옵션
+//   - static(map[string]string): 모든 스트림에 붙는 고정 라벨
+//   - fields(...string): 로그 엔트리에서 값을 가져와 라벨로 사용할 필드 (공통 필드 등)
+//
+// Loki 는 라벨이 없는 스트림을 거부하므로, 라벨이 하나도 없는 스트림에는 {job="structured-logger"} 를 붙인다.
+//
+// Example:
+//
+//	// {app="payment", env="prod", level="..."} 스트림으로 전송
+//	sink.WithLokiLabels(map[string]string{"app": "payment", "env": "prod"}, "level")
+func WithLokiLabels(static map[string]string, fields ...string) Option {
+	return func(setting *setting) {
+		setting.labels = static
+		setting.labelFields = fields
+	}
+}
+
+// WithElasticIndex Elasticsearch _bulk 요청의 대상 인덱스를 설정하는 옵션
+//   - 지정하지 않은 경우 URL 의 인덱스(/<index>/_bulk)를 사용
+func WithElasticIndex(index string) Option {
+	return func(setting *setting) {
+		setting.index = index
+	}
+}
+
+// HTTP : 로그를 배치로 묶어 HTTP 엔드포인트로 전송하는 싱크
+//
+// 배치는 크기(WithBatch) 또는 주기(WithFlushInterval) 단위로 전송되며,
+// 5xx 와 429 응답은 지수 백오프로 재시도한다 (429 의 Retry-After 헤더 우선).
+//
+// Example:
+//
+//	// Loki 로 전송
+//	s, err := sink.NewHTTP("http://loki:3100/loki/api/v1/push",
+//		sink.WithHTTPFormat(sink.HTTPLoki),
+//		sink.WithLokiLabels(map[string]string{"app": "payment"}, "level"),
+//	)
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer s.Close()
+//	log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+type HTTP struct {
+	url      string
+	settings setting
+	batcher  *batcher
+
+	mu      sync.Mutex
+	lastErr error
+	failed  atomic.Uint64
+	closing chan struct{}
+	once    sync.Once
+}
+
+// NewHTTP : HTTP 싱크 생성자
+//   - url(string): 전송 대상 URL
+//   - opts(...Option): 싱크 설정 옵션 (WithHTTPFormat, WithLokiLabels, WithTimeFormat, WithBatch, WithFlushInterval, WithBackoff, WithRetries 등)
+func NewHTTP(url string, opts ...Option) (*HTTP, error) {
+	settings := newSetting(opts)
+	switch settings.httpFormat {
+	case HTTPJSON, HTTPLoki, HTTPElasticsearch:
+	default:
+		return nil, fmt.Errorf("sink: unsupported http format %q", settings.httpFormat)
+	}
+	if settings.batchEntries <= 0 || settings.batchBytes <= 0 || settings.flushInterval <= 0 {
+		return nil, fmt.Errorf("sink: batch size and flush interval must be positive")
+	}
+
+	h := &HTTP{url: url, settings: settings, closing: make(chan struct{})}
+	h.batcher = newBatcher(settings, h.send)
+	return h, nil
+}
+
+// Write : 로그 라인을 배치에 추가하는 메서드 (io.Writer 구현)
+func (h *HTTP) Write(p []byte) (int, error) {
+	if err := h.batcher.add(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close : 남은 배치를 전송하고 싱크를 닫는 메서드
+//
+// 여러 고루틴에서 호출해도 한 번만 닫으며, 먼저 호출한 Close 가 끝날 때까지 기다린다.
+func (h *HTTP) Close() error {
+	h.once.Do(func() {
+		// 남은 배치는 한 번만 전송을 시도하고, 재시도 대기 없이 종료
+		close(h.closing)
+		h.batcher.close()
+	})
+	return nil
+}
+
+// Health : 마지막 배치 전송이 실패했다면 그 에러를 반환하는 메서드
+func (h *HTTP) Health() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.lastErr
+}
+
+// Dropped : 큐가 가득 찼거나 재시도 끝에 전송하지 못해 버려진 로그 라인 수를 반환하는 메서드
+func (h *HTTP) Dropped() uint64 {
+	return h.batcher.dropped.Load() + h.failed.Load()
+}
+
+// send : 배치를 요청 본문으로 인코딩하여 재시도와 함께 전송하는 메서드
+func (h *HTTP) send(batch []record) {
+	body, contentType, err := h.encode(batch)
+	if err == nil {
+		err = h.post(body, contentType)
+	}
+
+	h.mu.Lock()
+	h.lastErr = err
+	h.mu.Unlock()
+	if err != nil {
+		h.failed.Add(uint64(len(batch)))
+	}
+}
+
+// post : 재시도 가능한 응답(5xx, 429)이나 네트워크 에러에 대해 백오프로 재시도하는 메서드
+func (h *HTTP) post(body []byte, contentType string) error {
+	retry := newBackoff(h.settings.minBackoff, h.settings.maxBackoff)
+	for attempt := 0; ; attempt++ {
+		wait, err := h.do(body, contentType)
+		if err == nil {
+			return nil
+		}
+		if wait < 0 || attempt >= h.settings.maxRetries {
+			return err
+		}
+		if delay := retry.next(); wait < delay {
+			wait = delay
+		}
+		select {
+		case <-h.closing:
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// do : 요청을 한 번 전송하는 메서드
+//
+// 재시도할 수 없는 에러인 경우 음수 대기 시간을, 재시도 가능한 경우 서버가 요청한 대기 시간(없으면 0)을 반환한다.
+func (h *HTTP) do(body []byte, contentType string) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return -1, err
+	}
+	for key, values := range h.settings.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", contentType)
+	if h.settings.gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	resp, err := h.settings.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode < 300:
+		return 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		wait := time.Duration(0)
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			wait = time.Duration(seconds) * time.Second
+		}
+		return wait, fmt.Errorf("sink: %s responded %s", h.url, resp.Status)
+	case resp.StatusCode >= 500:
+		return 0, fmt.Errorf("sink: %s responded %s", h.url, resp.Status)
+	default:
+		return -1, fmt.Errorf("sink: %s responded %s", h.url, resp.Status)
+	}
+}
+
+// encode : 배치를 설정된 포맷의 요청 본문으로 변환하는 메서드
+func (h *HTTP) encode(batch []record) ([]byte, string, error) {
+	var (
+		buf         bytes.Buffer
+		contentType string
+		err         error
+	)
+	switch h.settings.httpFormat {
+	case HTTPLoki:
+		contentType = "application/json"
+		err = h.encodeLoki(&buf, batch)
+	case HTTPElasticsearch:
+		contentType = "application/x-ndjson"
+		h.encodeBulk(&buf, batch)
+	default:
+		contentType = "application/json"
+		encodeArray(&buf, batch)
+	}
+	if err != nil || !h.settings.gzip {
+		return buf.Bytes(), contentType, err
+	}
+
+	compressed, err := gzipBody(buf.Bytes())
+	if err != nil {
+		return nil, "", err
+	}
+	return compressed, contentType, nil
+}
+
+// gzipBody : 요청 본문을 gzip 으로 압축하는 함수
+func gzipBody(body []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+// encodeArray : [line, line, ...] 형태의 JSON 배열로 인코딩
+func encodeArray(buf *bytes.Buffer, batch []record) {
+	buf.WriteByte('[')
+	for i, r := range batch {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(r.line)
+	}
+	buf.WriteByte(']')
+}
+
+// encodeBulk : Elasticsearch _bulk API 의 action/document 쌍으로 인코딩
+func (h *HTTP) encodeBulk(buf *bytes.Buffer, batch []record) {
+	action := []byte(`{"index":{}}`)
+	if h.settings.index != "" {
+		action, _ = json.Marshal(map[string]interface{}{"index": map[string]string{"_index": h.settings.index}})
+	}
+	for _, r := range batch {
+		buf.Write(action)
+		buf.WriteByte('\n')
+		buf.Write(r.line)
+		buf.WriteByte('\n')
+	}
+}
+
+// lokiDefaultLabel, lokiDefaultJob : 라벨이 없는 스트림에 붙이는 기본 라벨
+const (
+	lokiDefaultLabel = "job"
+	lokiDefaultJob   = "structured-logger"
+)
+
+// lokiStream : Loki push API 의 스트림
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// encodeLoki : 라벨 조합별로 스트림을 묶어 Loki push API 포맷으로 인코딩
+//
+// 엔트리의 시각은 로그의 time 필드(WithTimeFormat 으로 읽음)이며, 읽을 수 없으면 싱크에 기록된 시각이다.
+func (h *HTTP) encodeLoki(buf *bytes.Buffer, batch []record) error {
+	var (
+		streams []*lokiStream
+		index   = map[string]*lokiStream{}
+	)
+	for _, r := range batch {
+		// 읽을 수 없는 라인은 고정 라벨과 기록된 시각으로 전송
+		fields, _ := decodeLine(r.line)
+		labels := h.lokiLabels(fields)
+		key := labelKey(labels)
+		stream, ok := index[key]
+		if !ok {
+			stream = &lokiStream{Stream: labels}
+			index[key] = stream
+			streams = append(streams, stream)
+		}
+		at := entryTime(fields, h.settings.timeFormat, r.time)
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(at.UnixNano(), 10), string(r.line)})
+	}
+
+	return json.NewEncoder(buf).Encode(map[string]interface{}{"streams": streams})
+}
+
+// lokiLabels : 고정 라벨과 로그 필드 값으로 라벨을 구성하는 메서드
+func (h *HTTP) lokiLabels(fields map[string]interface{}) map[string]string {
+	labels := make(map[string]string, len(h.settings.labels)+len(h.settings.labelFields)+1)
+	for k, v := range h.settings.labels {
+		labels[k] = v
+	}
+
+	for _, name := range h.settings.labelFields {
+		if v, ok := fields[name]; ok && v != nil {
+			labels[name] = fmt.Sprint(v)
+		}
+	}
+	if len(labels) == 0 {
+		labels[lokiDefaultLabel] = lokiDefaultJob
+	}
+	return labels
+}
+
+// labelKey : 라벨 조합을 식별하는 키를 만드는 함수
+func labelKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(labels[k])
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
@@ -0,0 +1,270 @@
+package sink_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/sink"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func TestHTTP(t *testing.T) {
+	t.Run("JSON 배열 포맷으로 gzip 압축되어 전송되는지 테스트", func(t *testing.T) {
+		// given
+		collector := newCollector(t)
+		s, err := sink.NewHTTP(collector.URL, sink.WithBatch(2, 1<<20), sink.WithFlushInterval(time.Hour))
+		require.NoError(t, err)
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+
+		// when
+		log.Info(options.WithMessage("first"))
+		log.Info(options.WithMessage("second"))
+		require.NoError(t, s.Close())
+
+		// then
+		bodies := collector.Bodies()
+		require.Len(t, bodies, 1, "배치 크기만큼 모아서 한 번에 전송되어야 합니다.")
+		assert.Equal(t, "gzip", collector.Header().Get("Content-Encoding"))
+		var entries []map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(bodies[0]), &entries))
+		require.Len(t, entries, 2)
+		assert.Equal(t, "first", entries[0][types.MessageField])
+		assert.Equal(t, "second", entries[1][types.MessageField])
+	})
+
+	t.Run("Loki 포맷에서 선택한 필드로 스트림 라벨이 구성되는지 테스트", func(t *testing.T) {
+		// given
+		collector := newCollector(t)
+		s, err := sink.NewHTTP(collector.URL,
+			sink.WithHTTPFormat(sink.HTTPLoki),
+			sink.WithLokiLabels(map[string]string{"app": "test"}, "rid"),
+			sink.WithGzip(false),
+		)
+		require.NoError(t, err)
+
+		// when
+		_, _ = s.Write([]byte(`{"rid":"a","message":"1"}` + "\n"))
+		_, _ = s.Write([]byte(`{"rid":"b","message":"2"}` + "\n"))
+		_, _ = s.Write([]byte(`{"rid":"a","message":"3"}` + "\n"))
+		require.NoError(t, s.Close())
+
+		// then
+		var push struct {
+			Streams []struct {
+				Stream map[string]string `json:"stream"`
+				Values [][2]string       `json:"values"`
+			} `json:"streams"`
+		}
+		bodies := collector.Bodies()
+		require.Len(t, bodies, 1)
+		require.NoError(t, json.Unmarshal([]byte(bodies[0]), &push))
+		require.Len(t, push.Streams, 2, "라벨 조합별로 스트림이 나뉘어야 합니다.")
+		assert.Equal(t, map[string]string{"app": "test", "rid": "a"}, push.Streams[0].Stream)
+		assert.Len(t, push.Streams[0].Values, 2)
+		assert.Equal(t, `{"rid":"a","message":"3"}`, push.Streams[0].Values[1][1])
+		assert.Equal(t, map[string]string{"app": "test", "rid": "b"}, push.Streams[1].Stream)
+	})
+
+	t.Run("Loki 포맷에서 라벨이 없으면 기본 job 라벨을 붙이는지 테스트", func(t *testing.T) {
+		// given
+		collector := newCollector(t)
+		s, err := sink.NewHTTP(collector.URL, sink.WithHTTPFormat(sink.HTTPLoki), sink.WithGzip(false))
+		require.NoError(t, err)
+
+		// when
+		_, _ = s.Write([]byte(`{"message":"1"}` + "\n"))
+		require.NoError(t, s.Close())
+
+		// then
+		bodies := collector.Bodies()
+		require.Len(t, bodies, 1)
+		assert.Contains(t, bodies[0], `"stream":{"job":"structured-logger"}`)
+	})
+
+	t.Run("Loki 포맷에서 로그의 time 필드가 엔트리 시각으로 전송되는지 테스트", func(t *testing.T) {
+		// given
+		collector := newCollector(t)
+		s, err := sink.NewHTTP(collector.URL,
+			sink.WithHTTPFormat(sink.HTTPLoki),
+			sink.WithTimeFormat(time.RFC3339Nano),
+			sink.WithGzip(false),
+		)
+		require.NoError(t, err)
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s), options.WithTimeFormat(time.RFC3339Nano))
+		at := time.Date(2024, 3, 1, 9, 30, 15, 250*int(time.Millisecond), time.UTC)
+
+		// when
+		log.Info(options.WithMessage("buffered"), options.WithTime(at))
+		_, _ = s.Write([]byte(`{"message":"no time"}` + "\n"))
+		require.NoError(t, s.Close())
+
+		// then
+		var push struct {
+			Streams []struct {
+				Values [][2]string `json:"values"`
+			} `json:"streams"`
+		}
+		bodies := collector.Bodies()
+		require.Len(t, bodies, 1)
+		require.NoError(t, json.Unmarshal([]byte(bodies[0]), &push))
+		require.Len(t, push.Streams, 1)
+		require.Len(t, push.Streams[0].Values, 2)
+		assert.Equal(t, strconv.FormatInt(at.UnixNano(), 10), push.Streams[0].Values[0][0], "싱크에 기록된 시각이 아닌 로그의 시각이어야 합니다.")
+		assert.NotEmpty(t, push.Streams[0].Values[1][0], "time 필드가 없으면 싱크에 기록된 시각을 사용해야 합니다.")
+	})
+
+	t.Run("여러 고루틴에서 동시에 닫아도 한 번만 닫히는지 테스트", func(t *testing.T) {
+		// given
+		collector := newCollector(t)
+		s, err := sink.NewHTTP(collector.URL, sink.WithGzip(false))
+		require.NoError(t, err)
+		_, _ = s.Write([]byte(`{"message":"close"}` + "\n"))
+
+		// when
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, s.Close())
+			}()
+		}
+		wg.Wait()
+
+		// then
+		assert.Len(t, collector.Bodies(), 1, "남은 배치는 한 번만 전송되어야 합니다.")
+	})
+
+	t.Run("Elasticsearch bulk 포맷으로 전송되는지 테스트", func(t *testing.T) {
+		// given
+		collector := newCollector(t)
+		s, err := sink.NewHTTP(collector.URL,
+			sink.WithHTTPFormat(sink.HTTPElasticsearch),
+			sink.WithElasticIndex("logs"),
+		)
+		require.NoError(t, err)
+
+		// when
+		_, _ = s.Write([]byte(`{"message":"bulk"}` + "\n"))
+		require.NoError(t, s.Close())
+
+		// then
+		bodies := collector.Bodies()
+		require.Len(t, bodies, 1)
+		assert.Equal(t, "application/x-ndjson", collector.Header().Get("Content-Type"))
+		assert.Equal(t, "{\"index\":{\"_index\":\"logs\"}}\n{\"message\":\"bulk\"}\n", bodies[0])
+	})
+
+	t.Run("5xx, 429 응답은 재시도하고 4xx 응답은 재시도하지 않는지 테스트", func(t *testing.T) {
+		// given
+		collector := newCollector(t)
+		collector.SetStatus(func(call int32) int {
+			switch call {
+			case 1:
+				return http.StatusServiceUnavailable
+			case 2:
+				return http.StatusTooManyRequests
+			default:
+				return http.StatusOK
+			}
+		})
+		s, err := sink.NewHTTP(collector.URL, sink.WithBackoff(time.Millisecond, 5*time.Millisecond), sink.WithFlushInterval(10*time.Millisecond))
+		require.NoError(t, err)
+		defer s.Close()
+
+		// when
+		_, _ = s.Write([]byte(`{"message":"retry"}`))
+
+		// then
+		require.Eventually(t, func() bool { return collector.calls.Load() == 3 }, 2*time.Second, 5*time.Millisecond)
+		assert.NoError(t, s.Health())
+		assert.Equal(t, uint64(0), s.Dropped())
+
+		// when: 재시도 할 수 없는 응답
+		collector.SetStatus(func(int32) int { return http.StatusBadRequest })
+		_, _ = s.Write([]byte(`{"message":"bad"}`))
+
+		// then
+		require.Eventually(t, func() bool { return s.Dropped() == 1 }, 2*time.Second, 5*time.Millisecond)
+		assert.Equal(t, int32(4), collector.calls.Load(), "4xx 응답은 재시도하지 않아야 합니다.")
+		assert.Error(t, s.Health())
+	})
+}
+
+// collector : HTTP 싱크 요청을 받아 본문을 보관하는 테스트 서버
+type collector struct {
+	*httptest.Server
+	mu     sync.Mutex
+	bodies []string
+	header http.Header
+	status func(call int32) int
+	calls  atomic.Int32
+}
+
+func newCollector(t *testing.T) *collector {
+	c := &collector{}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := c.calls.Add(1)
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			reader = zr
+		}
+		body, _ := io.ReadAll(bufio.NewReader(reader))
+
+		c.mu.Lock()
+		status := http.StatusNoContent
+		if c.status != nil {
+			status = c.status(call)
+		}
+		if status < 300 {
+			c.bodies = append(c.bodies, string(body))
+		}
+		c.header = r.Header.Clone()
+		c.mu.Unlock()
+
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *collector) SetStatus(status func(call int32) int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status = status
+}
+
+func (c *collector) Bodies() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.bodies...)
+}
+
+func (c *collector) Header() http.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.header
+}
@@ -0,0 +1,231 @@
+package sink
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// defaultJournalSocket : systemd-journald 의 native 프로토콜 소켓 경로
+const defaultJournalSocket = "/run/systemd/journal/socket"
+
+// journalFieldPrefix : journald 가 의미를 부여하는 필드 이름과 겹치는 로그 필드에 붙이는 접두사
+const journalFieldPrefix = "LOG_"
+
+// journalReserved : 싱크가 직접 기록하거나 journald 가 의미를 부여하는 필드 이름 (systemd.journal-fields(7))
+var journalReserved = map[string]bool{
+	"MESSAGE": true, "MESSAGE_ID": true, "PRIORITY": true,
+	"CODE_FILE": true, "CODE_LINE": true, "CODE_FUNC": true, "ERRNO": true,
+	"SYSLOG_IDENTIFIER": true, "SYSLOG_FACILITY": true, "SYSLOG_PID": true, "SYSLOG_TIMESTAMP": true, "SYSLOG_RAW": true,
+	"INVOCATION_ID": true, "USER_INVOCATION_ID": true, "DOCUMENTATION": true, "TID": true, "UNIT": true, "USER_UNIT": true,
+}
+
+// WithJournalSocket journald 소켓 경로를 설정하는 옵션 (default: /run/systemd/journal/socket)
+func WithJournalSocket(path string) Option {
+	return func(setting *setting) {
+		setting.journalSocket = path
+	}
+}
+
+// WithSyslogIdentifier journald 의 SYSLOG_IDENTIFIER 필드를 설정하는 옵션 (default: 실행 파일 이름)
+func WithSyslogIdentifier(identifier string) Option {
+	return func(setting *setting) {
+		setting.syslogIdentifier = identifier
+	}
+}
+
+// Journald : systemd-journald 의 native 프로토콜로 구조화된 필드를 기록하는 싱크
+//
+// 로그 필드는 대문자 journal 필드(rid -> RID)로 변환되며, message 는 MESSAGE,
+// level 은 PRIORITY, caller(options.WithCaller) 는 CODE_FILE/CODE_LINE 으로 기록된다.
+// 변환한 이름이 journald 가 의미를 부여하는 필드(PRIORITY, CODE_FILE, SYSLOG_IDENTIFIER 등)와 같으면
+// 덮어쓰지 않도록 LOG_ 접두사를 붙인다 (priority -> LOG_PRIORITY).
+// 데이터그램으로 보낼 수 없을 만큼 큰 엔트리는 memfd 로 전달한다 (linux).
+//
+// Example:
+//
+//	s, err := sink.NewJournald()
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer s.Close()
+//	log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s), options.WithCaller(true))
+//	// journalctl -o verbose 로 RID, PRIORITY, CODE_FILE 등의 필드를 확인할 수 있음
+type Journald struct {
+	settings setting
+	addr     *net.UnixAddr
+
+	mu   sync.Mutex
+	conn *net.UnixConn
+}
+
+// NewJournald : journald 싱크 생성자
+//   - opts(...Option): 싱크 설정 옵션 (WithJournalSocket, WithSyslogIdentifier)
+func NewJournald(opts ...Option) (*Journald, error) {
+	settings := newSetting(opts)
+	if settings.journalSocket == "" {
+		settings.journalSocket = defaultJournalSocket
+	}
+	if settings.syslogIdentifier == "" {
+		settings.syslogIdentifier = filepath.Base(os.Args[0])
+	}
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("sink: open journal socket: %w", err)
+	}
+	return &Journald{
+		settings: settings,
+		addr:     &net.UnixAddr{Name: settings.journalSocket, Net: "unixgram"},
+		conn:     conn,
+	}, nil
+}
+
+// Write : JSON 로그 라인을 journal 필드로 변환하여 기록하는 메서드 (io.Writer 구현)
+func (j *Journald) Write(p []byte) (int, error) {
+	line := bytes.TrimRight(p, "\n")
+	fields, err := decodeLine(line)
+	if err != nil {
+		fields = map[string]interface{}{types.MessageField: string(line)}
+	}
+
+	message := j.encode(fields)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.conn == nil {
+		return 0, ErrClosed
+	}
+
+	_, _, err = j.conn.WriteMsgUnix(message, nil, j.addr)
+	if isMessageTooLarge(err) {
+		err = sendMemfd(j.conn, j.addr, message)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("sink: write journal: %w", err)
+	}
+	return len(p), nil
+}
+
+// Close : journald 소켓을 닫는 메서드
+func (j *Journald) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.conn == nil {
+		return nil
+	}
+	err := j.conn.Close()
+	j.conn = nil
+	return err
+}
+
+// encode : 로그 필드를 journald native 프로토콜 메시지로 인코딩하는 메서드
+func (j *Journald) encode(fields map[string]interface{}) []byte {
+	var buf bytes.Buffer
+
+	message, _ := fields[types.MessageField].(string)
+	writeJournalField(&buf, "MESSAGE", message)
+
+	levelText, _ := fields[types.LevelField].(string)
+	level, _ := types.ParseLevel(levelText)
+	writeJournalField(&buf, "PRIORITY", strconv.Itoa(level.SyslogPriority()))
+	writeJournalField(&buf, "SYSLOG_IDENTIFIER", j.settings.syslogIdentifier)
+
+	if location, ok := fields[types.CallerField].(string); ok {
+		if i := strings.LastIndexByte(location, ':'); i > 0 {
+			writeJournalField(&buf, "CODE_FILE", location[:i])
+			writeJournalField(&buf, "CODE_LINE", location[i+1:])
+		} else {
+			writeJournalField(&buf, "CODE_FILE", location)
+		}
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		switch k {
+		case types.MessageField, types.LevelField, types.CallerField, types.TimeField:
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		name := journalFieldName(k)
+		if name == "" {
+			continue
+		}
+		writeJournalField(&buf, name, journalValue(fields[k]))
+	}
+	return buf.Bytes()
+}
+
+// writeJournalField : 필드 하나를 인코딩하는 함수
+//   - 값에 개행 문자가 있으면 "NAME\n<길이(uint64 LE)><값>\n" 형태의 바이너리 인코딩을 사용
+func writeJournalField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if !strings.ContainsRune(value, '\n') {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+	buf.Write(size[:])
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalFieldName : 로그 필드 키를 journal 필드 이름 규칙([A-Z0-9_], 밑줄/숫자로 시작 불가, 64자 이하)에 맞게 변환하는 함수
+//
+// 예약된 이름(journalReserved)과 같으면 접두사를 붙인다.
+func journalFieldName(key string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(key) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteByte('_')
+		}
+	}
+	name := strings.TrimLeft(sb.String(), "_0123456789")
+	if journalReserved[name] {
+		name = journalFieldPrefix + name
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// journalValue : 필드 값을 문자열로 변환하는 함수, 중첩된 값은 JSON 으로 인코딩
+func journalValue(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(value)
+		return string(b)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// isMessageTooLarge : 데이터그램 크기 제한으로 전송에 실패했는지 확인하는 함수
+func isMessageTooLarge(err error) bool {
+	return errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS)
+}
@@ -0,0 +1,31 @@
+//go:build linux
+
+package sink
+
+import (
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// sendMemfd : 데이터그램으로 보낼 수 없는 큰 엔트리를 봉인된 memfd 로 전달하는 함수
+func sendMemfd(conn *net.UnixConn, addr *net.UnixAddr, message []byte) error {
+	fd, err := unix.MemfdCreate("journal-message", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return err
+	}
+	file := os.NewFile(uintptr(fd), "journal-message")
+	defer file.Close()
+
+	if _, err = file.Write(message); err != nil {
+		return err
+	}
+	seals := unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	if _, err = unix.FcntlInt(file.Fd(), unix.F_ADD_SEALS, seals); err != nil {
+		return err
+	}
+
+	_, _, err = conn.WriteMsgUnix(nil, unix.UnixRights(int(file.Fd())), addr)
+	return err
+}
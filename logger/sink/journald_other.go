@@ -0,0 +1,13 @@
+//go:build !linux
+
+package sink
+
+import (
+	"errors"
+	"net"
+)
+
+// sendMemfd : memfd 는 linux 에서만 지원되므로, 큰 엔트리는 기록할 수 없음
+func sendMemfd(*net.UnixConn, *net.UnixAddr, []byte) error {
+	return errors.New("sink: journal entry too large")
+}
@@ -0,0 +1,142 @@
+//go:build linux
+
+package sink_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/sink"
+	"github.com/wjddn3711/structured-logger/logger/types"
+	"golang.org/x/sys/unix"
+)
+
+func TestJournald(t *testing.T) {
+	t.Run("로그 필드가 journal 필드로 변환되어 기록되는지 테스트", func(t *testing.T) {
+		// given
+		journal, path := listenJournal(t)
+		s, err := sink.NewJournald(sink.WithJournalSocket(path), sink.WithSyslogIdentifier("test"))
+		require.NoError(t, err)
+		defer s.Close()
+		log := logger.NewWrapper(types.Logrus, options.WithOutput(s), options.WithCaller(true))
+		log.RegisterCommonField("rid", "1234")
+
+		// when
+		log.Warn(options.WithMessage("multi\nline"), options.WithFields(Example{StatusCode: 500}))
+
+		// then
+		fields := readJournal(t, journal)
+		assert.Equal(t, "multi\nline", fields["MESSAGE"], "개행 문자가 있는 값도 그대로 기록되어야 합니다.")
+		assert.Equal(t, "4", fields["PRIORITY"], "warn 레벨은 PRIORITY 4 로 기록되어야 합니다.")
+		assert.Equal(t, "test", fields["SYSLOG_IDENTIFIER"])
+		assert.Equal(t, "1234", fields["RID"], "공통 필드는 대문자 journal 필드로 기록되어야 합니다.")
+		assert.Equal(t, "500", fields["STATUS_CODE"])
+		assert.True(t, strings.HasSuffix(fields["CODE_FILE"], "journald_test.go"), "호출 위치가 CODE_FILE 로 기록되어야 합니다.")
+		assert.NotEmpty(t, fields["CODE_LINE"])
+	})
+
+	t.Run("예약된 journal 필드와 겹치는 필드는 접두사를 붙여 기록되는지 테스트", func(t *testing.T) {
+		// given
+		journal, path := listenJournal(t)
+		s, err := sink.NewJournald(sink.WithJournalSocket(path), sink.WithSyslogIdentifier("test"))
+		require.NoError(t, err)
+		defer s.Close()
+
+		// when
+		_, err = s.Write([]byte(`{"level":"error","message":"failed","priority":"high","syslog_identifier":"spoofed","code_file":"main.go"}`))
+		require.NoError(t, err)
+
+		// then
+		fields := readJournal(t, journal)
+		assert.Equal(t, "3", fields["PRIORITY"], "예약된 필드는 덮어쓰지 않아야 합니다.")
+		assert.Equal(t, "test", fields["SYSLOG_IDENTIFIER"])
+		assert.NotContains(t, fields, "CODE_FILE")
+		assert.Equal(t, "high", fields["LOG_PRIORITY"])
+		assert.Equal(t, "spoofed", fields["LOG_SYSLOG_IDENTIFIER"])
+		assert.Equal(t, "main.go", fields["LOG_CODE_FILE"])
+	})
+
+	t.Run("데이터그램보다 큰 엔트리가 memfd 로 전달되는지 테스트", func(t *testing.T) {
+		// given
+		journal, path := listenJournal(t)
+		s, err := sink.NewJournald(sink.WithJournalSocket(path))
+		require.NoError(t, err)
+		defer s.Close()
+		message := strings.Repeat("x", 4<<20)
+
+		// when
+		_, err = s.Write([]byte(`{"level":"error","message":"` + message + `"}`))
+		require.NoError(t, err)
+
+		// then
+		fields := readJournal(t, journal)
+		assert.Equal(t, message, fields["MESSAGE"])
+		assert.Equal(t, "3", fields["PRIORITY"])
+	})
+}
+
+type Example struct {
+	StatusCode int `json:"status_code,omitempty"`
+}
+
+func (e Example) ToFields() map[string]interface{} {
+	return map[string]interface{}{"status_code": e.StatusCode}
+}
+
+func listenJournal(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, path
+}
+
+// readJournal : 데이터그램 또는 memfd 로 전달된 journal 엔트리를 읽어 필드로 변환하는 함수
+func readJournal(t *testing.T, conn *net.UnixConn) map[string]string {
+	t.Helper()
+	buf := make([]byte, 1<<20)
+	oob := make([]byte, unix.CmsgSpace(4))
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	require.NoError(t, err)
+
+	payload := buf[:n]
+	if oobn > 0 {
+		messages, err := unix.ParseSocketControlMessage(oob[:oobn])
+		require.NoError(t, err)
+		fds, err := unix.ParseUnixRights(&messages[0])
+		require.NoError(t, err)
+		file := os.NewFile(uintptr(fds[0]), "memfd")
+		defer file.Close()
+		payload, err = io.ReadAll(io.NewSectionReader(file, 0, 1<<30))
+		require.NoError(t, err)
+	}
+
+	fields := map[string]string{}
+	for len(payload) > 0 {
+		i := bytes.IndexByte(payload, '\n')
+		require.GreaterOrEqual(t, i, 0)
+		line := payload[:i]
+		if eq := bytes.IndexByte(line, '='); eq >= 0 {
+			fields[string(line[:eq])] = string(line[eq+1:])
+			payload = payload[i+1:]
+			continue
+		}
+		size := binary.LittleEndian.Uint64(payload[i+1 : i+9])
+		fields[string(line)] = string(payload[i+9 : i+9+int(size)])
+		payload = payload[i+9+int(size)+1:]
+	}
+	return fields
+}
@@ -0,0 +1,326 @@
+package sink
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClosed : 닫힌 싱크에 기록하려는 경우의 에러
+var ErrClosed = errors.New("sink: closed")
+
+// State : 네트워크 싱크의 연결 상태
+type State int32
+
+const (
+	// StateConnecting : 최초 연결을 시도하는 중
+	StateConnecting State = iota
+	// StateConnected : 연결되어 로그를 바로 전송하는 중
+	StateConnected
+	// StateDisconnected : 연결이 끊겨 재연결을 시도하는 중 (로그는 스풀에 보관)
+	StateDisconnected
+	// StateClosed : 싱크가 닫힘
+	StateClosed
+)
+
+// String : 상태를 문자열로 변환하는 메서드
+func (s State) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateDisconnected:
+		return "disconnected"
+	case StateClosed:
+		return "closed"
+	default:
+		return fmt.Sprintf("state(%d)", int32(s))
+	}
+}
+
+// Network : 줄 단위 JSON 로그를 TCP/UDP 로 전송하는 싱크
+//
+// 연결이 끊기면 지수 백오프로 재연결을 시도하며, 그동안의 로그는 스풀 파일에 보관했다가
+// 재연결 시 순서대로 재전송한다.
+//
+// Example:
+//
+//	// Fluent Bit / Vector 의 tcp 입력으로 로그 전송
+//	s, err := sink.NewNetwork("tcp", "127.0.0.1:5170", sink.WithSpool("log.spool", 64<<20))
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer s.Close()
+//	log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+type Network struct {
+	network  string
+	address  string
+	settings setting
+
+	mu      sync.Mutex
+	conn    net.Conn
+	spool   *spool
+	lastErr error
+
+	state   atomic.Int32
+	dropped atomic.Uint64
+	wake    chan struct{}
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewNetwork : 네트워크 싱크 생성자
+//   - network(string): "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6"
+//   - address(string): 전송 대상 주소 (host:port)
+//   - opts(...Option): 싱크 설정 옵션 (WithDialTimeout, WithWriteTimeout, WithBackoff, WithSpool)
+//
+// 연결은 백그라운드에서 이루어지므로, 대상이 내려가 있어도 생성에 실패하지 않는다.
+func NewNetwork(network, address string, opts ...Option) (*Network, error) {
+	switch network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+	default:
+		return nil, fmt.Errorf("sink: unsupported network %q", network)
+	}
+
+	settings := newSetting(opts)
+
+	n := &Network{
+		network:  network,
+		address:  address,
+		settings: settings,
+		wake:     make(chan struct{}, 1),
+		closing:  make(chan struct{}),
+	}
+	if settings.spoolPath != "" {
+		s, err := openSpool(settings.spoolPath, settings.spoolMaxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("sink: open spool: %w", err)
+		}
+		n.spool = s
+	}
+	n.state.Store(int32(StateConnecting))
+
+	n.wg.Add(1)
+	go n.run()
+	n.wake <- struct{}{}
+
+	return n, nil
+}
+
+// Write : 로그 라인을 전송하는 메서드 (io.Writer 구현)
+//
+// 연결이 끊겨 있거나 스풀에 재전송할 라인이 남아있는 경우 스풀에 보관한다.
+// 스풀이 없거나 가득 찬 경우 로그는 버려지며, 로거가 멈추지 않도록 에러를 반환하지 않는다.
+func (n *Network) Write(p []byte) (int, error) {
+	line := frame(p)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.State() == StateClosed {
+		return 0, ErrClosed
+	}
+
+	if n.conn != nil && n.spool.empty() {
+		err := n.send(n.conn, line)
+		if err == nil {
+			return len(p), nil
+		}
+		n.disconnect(n.conn, err)
+	}
+
+	if n.spool == nil {
+		n.dropped.Add(1)
+		return len(p), nil
+	}
+	if err := n.spool.append(line); err != nil {
+		n.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// Close : 연결을 닫고 재연결을 중단하는 메서드
+//
+// 스풀에 남은 라인은 파일에 유지되어, 같은 스풀 경로로 다시 생성하면 재전송된다.
+func (n *Network) Close() error {
+	n.mu.Lock()
+	if n.State() == StateClosed {
+		n.mu.Unlock()
+		return nil
+	}
+	n.state.Store(int32(StateClosed))
+	close(n.closing)
+	var err error
+	if n.conn != nil {
+		err = n.conn.Close()
+		n.conn = nil
+	}
+	n.mu.Unlock()
+
+	n.wg.Wait()
+
+	if serr := n.spool.close(); err == nil {
+		err = serr
+	}
+	return err
+}
+
+// State : 현재 연결 상태를 반환하는 메서드
+func (n *Network) State() State {
+	return State(n.state.Load())
+}
+
+// Health : 준비 상태 확인(readiness check)을 위한 메서드
+//
+// 연결되어 있으면 nil 을, 그렇지 않으면 상태와 마지막 연결 에러를 담은 에러를 반환한다.
+func (n *Network) Health() error {
+	state := n.State()
+	if state == StateConnected {
+		return nil
+	}
+
+	n.mu.Lock()
+	lastErr := n.lastErr
+	n.mu.Unlock()
+	if lastErr != nil {
+		return fmt.Errorf("sink: %s %s is %s: %w", n.network, n.address, state, lastErr)
+	}
+	return fmt.Errorf("sink: %s %s is %s", n.network, n.address, state)
+}
+
+// Dropped : 스풀이 없거나 가득 차서 버려진 로그 라인 수를 반환하는 메서드
+func (n *Network) Dropped() uint64 {
+	return n.dropped.Load()
+}
+
+// Spooled : 스풀에 보관 중인 바이트 수를 반환하는 메서드
+func (n *Network) Spooled() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.spool == nil {
+		return 0
+	}
+	return n.spool.size
+}
+
+// run : 연결이 끊길 때마다 재연결과 스풀 재전송을 수행하는 루프
+func (n *Network) run() {
+	defer n.wg.Done()
+
+	retry := newBackoff(n.settings.minBackoff, n.settings.maxBackoff)
+	for {
+		select {
+		case <-n.closing:
+			return
+		case <-n.wake:
+		}
+
+		for !n.connect() {
+			select {
+			case <-n.closing:
+				return
+			case <-time.After(retry.next()):
+			}
+		}
+		retry.reset()
+	}
+}
+
+// connect : 연결을 맺고 스풀을 재전송하는 메서드, 성공 여부를 반환
+func (n *Network) connect() bool {
+	dialer := net.Dialer{Timeout: n.settings.dialTimeout}
+	conn, err := dialer.Dial(n.network, n.address)
+	if err != nil {
+		n.mu.Lock()
+		n.lastErr = err
+		n.mu.Unlock()
+		return false
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.State() == StateClosed {
+		_ = conn.Close()
+		return true
+	}
+	if err = n.spool.replay(writerFunc(func(line []byte) (int, error) {
+		return len(line), n.send(conn, line)
+	})); err != nil {
+		n.lastErr = err
+		_ = conn.Close()
+		return false
+	}
+
+	n.conn, n.lastErr = conn, nil
+	n.state.Store(int32(StateConnected))
+	if _, ok := conn.(*net.TCPConn); ok {
+		n.wg.Add(1)
+		go n.watch(conn)
+	}
+	return true
+}
+
+// watch : 상대방이 TCP 연결을 닫은 것을 감지하는 메서드
+//
+// 수집 에이전트는 데이터를 보내지 않으므로, 읽기가 끝나면 연결이 끊긴 것으로 간주한다.
+func (n *Network) watch(conn net.Conn) {
+	defer n.wg.Done()
+
+	_, err := io.Copy(io.Discard, conn)
+	if err == nil {
+		err = io.EOF
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conn == conn {
+		n.disconnect(conn, err)
+	}
+}
+
+// send : 쓰기 타임아웃을 적용하여 라인을 전송하는 메서드
+func (n *Network) send(conn net.Conn, line []byte) error {
+	if n.settings.writeTimeout > 0 {
+		_ = conn.SetWriteDeadline(time.Now().Add(n.settings.writeTimeout))
+	}
+	_, err := conn.Write(line)
+	return err
+}
+
+// disconnect : 연결을 정리하고 재연결을 요청하는 메서드 (n.mu 를 잡은 상태에서 호출)
+func (n *Network) disconnect(conn net.Conn, err error) {
+	_ = conn.Close()
+	n.conn, n.lastErr = nil, err
+	if n.State() == StateClosed {
+		return
+	}
+	n.state.Store(int32(StateDisconnected))
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+// frame : 로그 라인이 개행 문자로 끝나도록 보장하는 함수
+func frame(p []byte) []byte {
+	if len(p) > 0 && p[len(p)-1] == '\n' {
+		return p
+	}
+	line := make([]byte, len(p)+1)
+	copy(line, p)
+	line[len(p)] = '\n'
+	return line
+}
+
+// writerFunc : 함수를 io.Writer 로 사용하기 위한 어댑터
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
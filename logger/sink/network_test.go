@@ -0,0 +1,160 @@
+package sink_test
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/sink"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+func TestNetwork(t *testing.T) {
+	t.Run("연결된 상태에서 로그가 줄 단위로 전송되는지 테스트", func(t *testing.T) {
+		// given
+		ln := listen(t, "127.0.0.1:0")
+		s, err := sink.NewNetwork("tcp", ln.Addr().String(), sink.WithBackoff(10*time.Millisecond, 50*time.Millisecond))
+		require.NoError(t, err)
+		defer s.Close()
+		conn := accept(t, ln)
+		waitState(t, s, sink.StateConnected)
+
+		// when
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+		log.Info(options.WithMessage("hello"))
+
+		// then
+		line := readLine(t, conn)
+		assert.Contains(t, line, `"message":"hello"`, "로그가 전송되어야 합니다.")
+		assert.NoError(t, s.Health(), "연결된 싱크는 정상 상태여야 합니다.")
+	})
+
+	t.Run("연결이 끊긴 동안의 로그가 재연결 후 순서대로 재전송되는지 테스트", func(t *testing.T) {
+		// given
+		ln := listen(t, "127.0.0.1:0")
+		addr := ln.Addr().String()
+		s, err := sink.NewNetwork("tcp", addr,
+			sink.WithBackoff(10*time.Millisecond, 50*time.Millisecond),
+			sink.WithSpool(filepath.Join(t.TempDir(), "log.spool"), 1<<20),
+		)
+		require.NoError(t, err)
+		defer s.Close()
+		conn := accept(t, ln)
+		waitState(t, s, sink.StateConnected)
+
+		_, _ = s.Write([]byte("first"))
+		assert.Equal(t, "first", readLine(t, conn))
+
+		// when
+		_ = conn.Close()
+		_ = ln.Close()
+		waitState(t, s, sink.StateDisconnected)
+		assert.Error(t, s.Health(), "연결이 끊긴 싱크는 비정상 상태여야 합니다.")
+
+		_, _ = s.Write([]byte("second\n"))
+		_, _ = s.Write([]byte("third\n"))
+		assert.Greater(t, s.Spooled(), int64(0), "연결이 끊긴 동안의 로그는 스풀에 보관되어야 합니다.")
+
+		ln = listen(t, addr)
+		conn = accept(t, ln)
+		waitState(t, s, sink.StateConnected)
+		_, _ = s.Write([]byte("fourth\n"))
+
+		// then
+		reader := bufio.NewReader(conn)
+		for _, want := range []string{"second", "third", "fourth"} {
+			assert.Equal(t, want, readLineFrom(t, conn, reader), "스풀의 로그가 순서대로 재전송되어야 합니다.")
+		}
+		assert.Equal(t, int64(0), s.Spooled(), "재전송 후 스풀은 비어있어야 합니다.")
+	})
+
+	t.Run("스풀이 가득 찬 경우 로그가 버려지고 집계되는지 테스트", func(t *testing.T) {
+		// given
+		s, err := sink.NewNetwork("tcp", "127.0.0.1:1",
+			sink.WithBackoff(time.Hour, time.Hour),
+			sink.WithSpool(filepath.Join(t.TempDir(), "log.spool"), 10),
+		)
+		require.NoError(t, err)
+		defer s.Close()
+
+		// when
+		_, _ = s.Write([]byte("12345678\n"))
+		_, _ = s.Write([]byte("12345678\n"))
+
+		// then
+		assert.Equal(t, uint64(1), s.Dropped(), "스풀 용량을 넘는 로그는 버려져야 합니다.")
+		assert.Equal(t, int64(9), s.Spooled())
+	})
+
+	t.Run("UDP 로 로그가 전송되는지 테스트", func(t *testing.T) {
+		// given
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer pc.Close()
+		s, err := sink.NewNetwork("udp", pc.LocalAddr().String())
+		require.NoError(t, err)
+		defer s.Close()
+		waitState(t, s, sink.StateConnected)
+
+		// when
+		_, _ = s.Write([]byte(`{"message":"udp"}`))
+
+		// then
+		buf := make([]byte, 1024)
+		_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := pc.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "{\"message\":\"udp\"}\n", string(buf[:n]))
+	})
+
+	t.Run("닫힌 싱크에 기록 시 에러 반환 테스트", func(t *testing.T) {
+		s, err := sink.NewNetwork("tcp", "127.0.0.1:1", sink.WithBackoff(time.Hour, time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+
+		_, err = s.Write([]byte("closed\n"))
+		assert.ErrorIs(t, err, sink.ErrClosed)
+		assert.Equal(t, sink.StateClosed, s.State())
+	})
+}
+
+func listen(t *testing.T, addr string) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	return ln
+}
+
+func accept(t *testing.T, ln net.Listener) net.Conn {
+	t.Helper()
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func readLine(t *testing.T, conn net.Conn) string {
+	t.Helper()
+	return readLineFrom(t, conn, bufio.NewReader(conn))
+}
+
+func readLineFrom(t *testing.T, conn net.Conn, reader *bufio.Reader) string {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	return line[:len(line)-1]
+}
+
+func waitState(t *testing.T, s *sink.Network, want sink.State) {
+	t.Helper()
+	require.Eventually(t, func() bool { return s.State() == want }, 2*time.Second, 5*time.Millisecond,
+		"싱크 상태가 %s 이어야 합니다.", want)
+}
@@ -0,0 +1,164 @@
+package sink
+
+import (
+	"net/http"
+	"time"
+)
+
+// setting : 싱크 설정
+//   - 싱크마다 필요한 항목만 사용하며, 나머지는 무시된다.
+type setting struct {
+	dialTimeout   time.Duration
+	writeTimeout  time.Duration
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	maxRetries    int
+	spoolPath     string
+	spoolMaxBytes int64
+
+	batchEntries  int
+	batchBytes    int
+	flushInterval time.Duration
+	queueSize     int
+
+	client      *http.Client
+	header      http.Header
+	httpFormat  HTTPFormat
+	gzip        bool
+	labelFields []string
+	labels      map[string]string
+	index       string
+
+	forward forwardSetting
+	gelf    gelfSetting
+	otlp    otlpSetting
+
+	journalSocket    string
+	syslogIdentifier string
+
+	timeFormat string
+}
+
+func newSetting(opts []Option) setting {
+	s := setting{
+		dialTimeout:   5 * time.Second,
+		writeTimeout:  5 * time.Second,
+		minBackoff:    defaultMinBackoff,
+		maxBackoff:    defaultMaxBackoff,
+		maxRetries:    5,
+		batchEntries:  1000,
+		batchBytes:    1 << 20,
+		flushInterval: time.Second,
+		queueSize:     10000,
+		client:        http.DefaultClient,
+		header:        http.Header{},
+		httpFormat:    HTTPJSON,
+		gzip:          true,
+		forward:       forwardSetting{mode: ForwardForward, tag: "app"},
+		gelf:          gelfSetting{compression: GELFGzip, chunkSize: 1420},
+		timeFormat:    defaultTimeFormat,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// Option 싱크 설정을 위한 옵션 타입
+//   - WithDialTimeout: 연결 타임아웃 (default: 5s)
+//   - WithWriteTimeout: 쓰기 타임아웃 (default: 5s)
+//   - WithBackoff: 재연결/재시도 대기 시간 범위 (default: 100ms ~ 30s)
+//   - WithRetries: 배치 전송 최대 재시도 횟수 (default: 5)
+//   - WithSpool: 연결이 끊긴 동안 로그를 보관할 스풀 파일 (default: 없음, 로그 유실)
+//   - WithBatch: 배치 최대 엔트리 수와 바이트 수 (default: 1000, 1MiB)
+//   - WithFlushInterval: 배치 전송 주기 (default: 1s)
+//   - WithQueueSize: 전송 대기 엔트리 최대 수 (default: 10000)
+//   - WithTimeFormat: 로그 라인의 time 필드를 읽을 시간 포맷 (default: "2006-01-02 15:04:05")
+//   - WithHTTPClient, WithHeader, WithHTTPFormat, WithGzip, WithLokiLabels, WithElasticIndex: HTTP 싱크 설정
+//   - WithForwardMode, WithTag, WithAck: Fluent forward 싱크 설정
+//   - WithJournalSocket, WithSyslogIdentifier: journald 싱크 설정
+//   - WithGELFHost, WithGELFCompression, WithGELFChunkSize: GELF 싱크 설정
+//   - WithOTLPService, WithOTLPResource, WithOTLPInsecure, WithGRPCDialOptions: OTLP 싱크 설정
+type Option func(*setting)
+
+// WithDialTimeout 연결 타임아웃을 설정하는 옵션
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(setting *setting) {
+		setting.dialTimeout = timeout
+	}
+}
+
+// WithWriteTimeout 쓰기 타임아웃을 설정하는 옵션
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(setting *setting) {
+		setting.writeTimeout = timeout
+	}
+}
+
+// WithBackoff 재연결/재시도 시 지수 백오프 대기 시간 범위를 설정하는 옵션
+//   - min(time.Duration): 최초 대기 시간
+//   - max(time.Duration): 최대 대기 시간
+func WithBackoff(min, max time.Duration) Option {
+	return func(setting *setting) {
+		setting.minBackoff = min
+		setting.maxBackoff = max
+	}
+}
+
+// WithRetries 배치 전송 실패 시 최대 재시도 횟수를 설정하는 옵션
+func WithRetries(retries int) Option {
+	return func(setting *setting) {
+		setting.maxRetries = retries
+	}
+}
+
+// WithSpool 연결이 끊긴 동안 로그를 보관할 스풀 파일을 설정하는 옵션
+//   - path(string): 스풀 파일 경로
+//   - maxBytes(int64): 스풀 파일의 최대 크기, 초과한 로그는 버려지고 Dropped 에 집계됨 (0 이하인 경우 제한 없음)
+//
+// Example:
+//
+//	s, err := sink.NewNetwork("tcp", "127.0.0.1:5170", sink.WithSpool("/var/spool/app/log.spool", 64<<20))
+func WithSpool(path string, maxBytes int64) Option {
+	return func(setting *setting) {
+		setting.spoolPath = path
+		setting.spoolMaxBytes = maxBytes
+	}
+}
+
+// WithBatch 배치의 최대 크기를 설정하는 옵션, 둘 중 하나라도 도달하면 즉시 전송
+//   - entries(int): 배치 최대 엔트리 수
+//   - bytes(int): 배치 최대 바이트 수
+func WithBatch(entries, bytes int) Option {
+	return func(setting *setting) {
+		setting.batchEntries = entries
+		setting.batchBytes = bytes
+	}
+}
+
+// WithFlushInterval 배치가 가득 차지 않아도 전송하는 주기를 설정하는 옵션
+func WithFlushInterval(interval time.Duration) Option {
+	return func(setting *setting) {
+		setting.flushInterval = interval
+	}
+}
+
+// WithQueueSize 전송 대기 중인 엔트리의 최대 수를 설정하는 옵션, 초과한 로그는 버려지고 Dropped 에 집계됨
+func WithQueueSize(size int) Option {
+	return func(setting *setting) {
+		setting.queueSize = size
+	}
+}
+
+// WithTimeFormat 로그 라인의 time 필드를 읽을 시간 포맷을 설정하는 옵션
+//   - timeFormat(string): 로거에 설정한 시간 포맷 (options.WithTimeFormat), 빈 문자열이면 기본값
+//
+// 시간대가 없는 포맷은 로컬 시간대로 읽으며, RFC3339 형식은 포맷과 관계없이 읽는다.
+// 읽을 수 없는 경우 싱크에 기록된 시각을 사용한다.
+func WithTimeFormat(timeFormat string) Option {
+	return func(setting *setting) {
+		if timeFormat != "" {
+			setting.timeFormat = timeFormat
+		}
+	}
+}
@@ -0,0 +1,450 @@
+package sink
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// OTLPProtocol : OTLP 전송 프로토콜
+//
+// Example:
+//
+//	// OTLP/HTTP (protobuf)
+//	protocol := sink.OTLPHTTP
+//	// OTLP/gRPC
+//	protocol := sink.OTLPGRPC
+type OTLPProtocol string
+
+const (
+	// OTLPHTTP : protobuf 본문을 HTTP POST 로 전송 (기본 경로 /v1/logs)
+	OTLPHTTP OTLPProtocol = "http/protobuf"
+	// OTLPGRPC : LogsService/Export gRPC 호출로 전송
+	OTLPGRPC OTLPProtocol = "grpc"
+)
+
+const (
+	// otlpLogsPath : OTLP/HTTP 로그 수집 경로
+	otlpLogsPath = "/v1/logs"
+	// otlpScopeName : LogRecord 의 instrumentation scope 이름
+	otlpScopeName = "github.com/wjddn3711/structured-logger"
+)
+
+// otlpSetting : OTLP 싱크 설정
+type otlpSetting struct {
+	serviceName    string
+	serviceVersion string
+	resource       map[string]interface{}
+	insecure       bool
+	dialOptions    []grpc.DialOption
+}
+
+// WithOTLPService OTLP 리소스의 service.name, service.version 속성을 설정하는 옵션
+//   - name(string): 서비스 이름, 지정 하지 않을 경우 OTEL_SERVICE_NAME 환경 변수 또는 "unknown_service:<실행 파일 이름>"
+//   - version(string): 서비스 버전, 빈 문자열이면 기록하지 않음
+func WithOTLPService(name, version string) Option {
+	return func(setting *setting) {
+		setting.otlp.serviceName = name
+		setting.otlp.serviceVersion = version
+	}
+}
+
+// WithOTLPResource OTLP 리소스 속성을 추가하는 옵션
+//
+// Example:
+//
+//	sink.WithOTLPResource(map[string]interface{}{"deployment.environment": "prod", "host.name": hostname})
+func WithOTLPResource(attributes map[string]interface{}) Option {
+	return func(setting *setting) {
+		if setting.otlp.resource == nil {
+			setting.otlp.resource = map[string]interface{}{}
+		}
+		for key, value := range attributes {
+			setting.otlp.resource[key] = value
+		}
+	}
+}
+
+// WithOTLPInsecure OTLP/gRPC 연결에 TLS 를 사용하지 않는 옵션 (default: TLS 사용)
+//
+// OTLP/HTTP 는 URL 의 스킴(http, https)을 따른다.
+func WithOTLPInsecure() Option {
+	return func(setting *setting) {
+		setting.otlp.insecure = true
+	}
+}
+
+// WithGRPCDialOptions OTLP/gRPC 연결에 사용할 grpc.DialOption 을 추가하는 옵션
+//
+// Example:
+//
+//	// 인증서 지정
+//	sink.WithGRPCDialOptions(grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(pool, "")))
+func WithGRPCDialOptions(opts ...grpc.DialOption) Option {
+	return func(setting *setting) {
+		setting.otlp.dialOptions = append(setting.otlp.dialOptions, opts...)
+	}
+}
+
+// OTLP : OpenTelemetry Collector 로 로그를 OTLP LogRecord 로 변환하여 전송하는 싱크
+//
+// 로그 필드는 다음과 같이 변환된다.
+//   - level: SeverityNumber (types.LogLevel.OTelSeverity) 와 SeverityText
+//   - message: Body (문자열)
+//   - time: TimeUnixNano (WithTimeFormat 으로 읽을 수 없으면 싱크에 기록된 시각), 싱크에 기록된 시각은 ObservedTimeUnixNano
+//   - trace_id, span_id: TraceId, SpanId (16진수 문자열)
+//   - 나머지 공통 필드, 엔트리 필드: Attributes (중첩된 맵은 KeyValueList, 배열은 ArrayValue)
+//
+// 배치는 크기(WithBatch) 또는 주기(WithFlushInterval) 단위로 ExportLogsServiceRequest 하나로 전송되며,
+// 재시도 가능한 응답(HTTP 429, 5xx / gRPC Unavailable 등)은 지수 백오프로 재시도한다.
+// 헤더(WithHeader)는 HTTP 헤더 또는 gRPC 메타데이터로, 압축(WithGzip)은 두 프로토콜 모두에 적용된다.
+//
+// Example:
+//
+//	s, err := sink.NewOTLP(sink.OTLPGRPC, "otel-collector:4317",
+//		sink.WithOTLPInsecure(),
+//		sink.WithOTLPService("payment-api", "1.4.2"),
+//		sink.WithOTLPResource(map[string]interface{}{"deployment.environment": "prod"}),
+//	)
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer s.Close()
+//	log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+type OTLP struct {
+	protocol OTLPProtocol
+	settings setting
+	batcher  *batcher
+	resource *resourcepb.Resource
+
+	http   *HTTP
+	conn   *grpc.ClientConn
+	client collogspb.LogsServiceClient
+
+	mu       sync.Mutex
+	lastErr  error
+	failed   atomic.Uint64
+	rejected atomic.Uint64
+	closing  chan struct{}
+	once     sync.Once
+	err      error
+}
+
+// NewOTLP : OTLP 싱크 생성자
+//   - protocol(OTLPProtocol): OTLPHTTP 또는 OTLPGRPC
+//   - endpoint(string): OTLPHTTP 는 URL (경로가 없으면 /v1/logs), OTLPGRPC 는 host:port
+//   - opts(...Option): 싱크 설정 옵션 (WithOTLPService, WithOTLPResource, WithOTLPInsecure, WithHeader, WithGzip, WithBatch 등)
+func NewOTLP(protocol OTLPProtocol, endpoint string, opts ...Option) (*OTLP, error) {
+	settings := newSetting(opts)
+	if settings.batchEntries <= 0 || settings.batchBytes <= 0 || settings.flushInterval <= 0 {
+		return nil, fmt.Errorf("sink: batch size and flush interval must be positive")
+	}
+
+	o := &OTLP{protocol: protocol, settings: settings, resource: otlpResource(settings.otlp), closing: make(chan struct{})}
+	switch protocol {
+	case OTLPHTTP:
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("sink: invalid otlp http endpoint %q", endpoint)
+		}
+		if u.Path == "" || u.Path == "/" {
+			u.Path = otlpLogsPath
+		}
+		o.http = &HTTP{url: u.String(), settings: settings, closing: o.closing}
+	case OTLPGRPC:
+		creds := credentials.NewTLS(nil)
+		if settings.otlp.insecure {
+			creds = insecure.NewCredentials()
+		}
+		dialOptions := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, settings.otlp.dialOptions...)
+		conn, err := grpc.Dial(endpoint, dialOptions...)
+		if err != nil {
+			return nil, err
+		}
+		o.conn, o.client = conn, collogspb.NewLogsServiceClient(conn)
+	default:
+		return nil, fmt.Errorf("sink: unsupported otlp protocol %q", protocol)
+	}
+
+	o.batcher = newBatcher(settings, o.send)
+	return o, nil
+}
+
+// Write : 로그 라인을 배치에 추가하는 메서드 (io.Writer 구현)
+func (o *OTLP) Write(p []byte) (int, error) {
+	if err := o.batcher.add(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close : 남은 배치를 전송하고 연결을 닫는 메서드
+//
+// 여러 고루틴에서 호출해도 한 번만 닫으며, 먼저 호출한 Close 가 끝날 때까지 기다린다.
+func (o *OTLP) Close() error {
+	o.once.Do(func() {
+		// 남은 배치는 한 번만 전송을 시도하고, 재시도 대기 없이 종료
+		close(o.closing)
+		o.batcher.close()
+		if o.conn != nil {
+			o.err = o.conn.Close()
+		}
+	})
+	return o.err
+}
+
+// Health : 마지막 배치 전송이 실패했다면 그 에러를 반환하는 메서드
+func (o *OTLP) Health() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.lastErr
+}
+
+// Dropped : 큐가 가득 찼거나 재시도 끝에 전송하지 못해 버려진 로그 라인 수를 반환하는 메서드
+func (o *OTLP) Dropped() uint64 {
+	return o.batcher.dropped.Load() + o.failed.Load()
+}
+
+// Rejected : 수집기가 부분 성공(partial success)으로 거부한 LogRecord 수를 반환하는 메서드 (OTLPGRPC)
+func (o *OTLP) Rejected() uint64 {
+	return o.rejected.Load()
+}
+
+// send : 배치를 ExportLogsServiceRequest 로 변환하여 전송하는 메서드
+func (o *OTLP) send(batch []record) {
+	request := &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: o.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: otlpScopeName},
+				LogRecords: otlpRecords(batch, o.settings.timeFormat),
+			}},
+		}},
+	}
+
+	var err error
+	if o.protocol == OTLPHTTP {
+		err = o.post(request)
+	} else {
+		err = o.export(request)
+	}
+
+	o.mu.Lock()
+	o.lastErr = err
+	o.mu.Unlock()
+	if err != nil {
+		o.failed.Add(uint64(len(batch)))
+	}
+}
+
+// post : 요청을 protobuf 로 인코딩하여 OTLP/HTTP 로 전송하는 메서드
+func (o *OTLP) post(request *collogspb.ExportLogsServiceRequest) error {
+	body, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+	if o.settings.gzip {
+		if body, err = gzipBody(body); err != nil {
+			return err
+		}
+	}
+	return o.http.post(body, "application/x-protobuf")
+}
+
+// export : 재시도 가능한 상태 코드에 대해 백오프로 재시도하며 OTLP/gRPC 로 전송하는 메서드
+func (o *OTLP) export(request *collogspb.ExportLogsServiceRequest) error {
+	var callOptions []grpc.CallOption
+	if o.settings.gzip {
+		callOptions = append(callOptions, grpc.UseCompressor(gzip.Name))
+	}
+	ctx := context.Background()
+	if len(o.settings.header) > 0 {
+		md := metadata.MD{}
+		for key, values := range o.settings.header {
+			md.Append(strings.ToLower(key), values...)
+		}
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	retry := newBackoff(o.settings.minBackoff, o.settings.maxBackoff)
+	for attempt := 0; ; attempt++ {
+		callCtx, cancel := context.WithTimeout(ctx, o.settings.writeTimeout)
+		response, err := o.client.Export(callCtx, request, callOptions...)
+		cancel()
+		if err == nil {
+			if partial := response.GetPartialSuccess(); partial != nil && partial.GetRejectedLogRecords() > 0 {
+				o.rejected.Add(uint64(partial.GetRejectedLogRecords()))
+			}
+			return nil
+		}
+		if !otlpRetryable(status.Code(err)) || attempt >= o.settings.maxRetries {
+			return err
+		}
+		select {
+		case <-o.closing:
+			return err
+		case <-time.After(retry.next()):
+		}
+	}
+}
+
+// otlpRetryable : OTLP 스펙에서 재시도 가능한 gRPC 상태 코드인지 반환하는 함수
+func otlpRetryable(code codes.Code) bool {
+	switch code {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted,
+		codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// otlpResource : 서비스 정보와 리소스 속성으로 OTLP Resource 를 만드는 함수
+func otlpResource(settings otlpSetting) *resourcepb.Resource {
+	attributes := map[string]interface{}{}
+	for key, value := range settings.resource {
+		attributes[key] = value
+	}
+
+	name := settings.serviceName
+	if name == "" {
+		name = os.Getenv("OTEL_SERVICE_NAME")
+	}
+	if name == "" {
+		name = "unknown_service:" + filepath.Base(os.Args[0])
+	}
+	attributes["service.name"] = name
+	if settings.serviceVersion != "" {
+		attributes["service.version"] = settings.serviceVersion
+	}
+	return &resourcepb.Resource{Attributes: otlpAttributes(attributes)}
+}
+
+// otlpRecords : 로그 라인을 LogRecord 로 변환하는 함수
+func otlpRecords(batch []record, timeFormat string) []*logspb.LogRecord {
+	records := make([]*logspb.LogRecord, 0, len(batch))
+	for _, r := range batch {
+		fields, err := decodeLine(r.line)
+		if err != nil {
+			fields = map[string]interface{}{types.MessageField: string(r.line)}
+		}
+		records = append(records, otlpRecord(fields, entryTime(fields, timeFormat, r.time), r.time))
+	}
+	return records
+}
+
+// otlpRecord : 로그 필드를 LogRecord 로 변환하는 함수
+//   - at(time.Time): 로그의 시각 (entryTime)
+//   - observed(time.Time): 싱크에 기록된 시각
+func otlpRecord(fields map[string]interface{}, at, observed time.Time) *logspb.LogRecord {
+	record := &logspb.LogRecord{
+		TimeUnixNano:         uint64(at.UnixNano()),
+		ObservedTimeUnixNano: uint64(observed.UnixNano()),
+	}
+
+	if text, ok := fields[types.LevelField].(string); ok {
+		if level, err := types.ParseLevel(text); err == nil {
+			record.SeverityNumber = logspb.SeverityNumber(level.OTelSeverity())
+			record.SeverityText = strings.ToUpper(string(level))
+			delete(fields, types.LevelField)
+		}
+	}
+	if message, ok := fields[types.MessageField].(string); ok {
+		record.Body = otlpValue(message)
+		delete(fields, types.MessageField)
+	}
+	if _, ok := fields[types.TimeField].(string); ok {
+		delete(fields, types.TimeField)
+	}
+	if id, ok := otlpID(fields[types.TraceIDField], 16); ok {
+		record.TraceId = id
+		delete(fields, types.TraceIDField)
+	}
+	if id, ok := otlpID(fields[types.SpanIDField], 8); ok {
+		record.SpanId = id
+		delete(fields, types.SpanIDField)
+	}
+
+	record.Attributes = otlpAttributes(fields)
+	return record
+}
+
+// otlpID : 16진수 문자열을 정해진 길이의 trace/span ID 로 변환하는 함수
+func otlpID(value interface{}, size int) ([]byte, bool) {
+	text, ok := value.(string)
+	if !ok || len(text) != size*2 {
+		return nil, false
+	}
+	id, err := hex.DecodeString(text)
+	if err != nil {
+		return nil, false
+	}
+	return id, true
+}
+
+// otlpAttributes : 필드를 이름순으로 정렬한 KeyValue 목록으로 변환하는 함수
+func otlpAttributes(fields map[string]interface{}) []*commonpb.KeyValue {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]*commonpb.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		attributes = append(attributes, &commonpb.KeyValue{Key: key, Value: otlpValue(fields[key])})
+	}
+	return attributes
+}
+
+// otlpValue : 필드 값을 AnyValue 로 변환하는 함수 (null 은 값이 없는 AnyValue)
+func otlpValue(value interface{}) *commonpb.AnyValue {
+	switch v := value.(type) {
+	case nil:
+		return &commonpb.AnyValue{}
+	case string:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case int:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case int64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}
+	case float64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v}}
+	case []byte:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v}}
+	case []interface{}:
+		values := make([]*commonpb.AnyValue, 0, len(v))
+		for _, item := range v {
+			values = append(values, otlpValue(item))
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+	case map[string]interface{}:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: otlpAttributes(v)}}}
+	default:
+		return otlpValue(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,262 @@
+package sink_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/sink"
+	"github.com/wjddn3711/structured-logger/logger/types"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOTLP(t *testing.T) {
+	t.Run("OTLP/HTTP 로 배치를 protobuf 로 전송하는지 테스트", func(t *testing.T) {
+		// given
+		collector := newOTLPCollector()
+		server := httptest.NewServer(collector)
+		defer server.Close()
+		s, err := sink.NewOTLP(sink.OTLPHTTP, server.URL,
+			sink.WithOTLPService("payment-api", "1.4.2"),
+			sink.WithOTLPResource(map[string]interface{}{"deployment.environment": "prod"}),
+			sink.WithHeader("Authorization", "Bearer token"),
+			sink.WithFlushInterval(time.Hour),
+		)
+		require.NoError(t, err)
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(s))
+		log.RegisterCommonField("service", "payment")
+
+		// when
+		log.Clone().Warn(
+			options.WithMessage("payment failed"),
+			options.WithFields(options.Fields{
+				types.TraceIDField:    "4bf92f3577b34da6a3ce929d0e0e4736",
+				types.SpanIDField:     "00f067aa0ba902b7",
+				types.StatusCodeField: 502,
+				"ratio":               0.5,
+				"retry":               true,
+				"http":                map[string]interface{}{"method": "POST"},
+				"tags":                []string{"a", "b"},
+			}),
+		)
+		paidAt := time.Date(2024, 3, 1, 9, 30, 15, 0, time.Local)
+		log.Clone().Info(options.WithMessage("paid"), options.WithTime(paidAt))
+		require.NoError(t, s.Close())
+
+		// then
+		requests := collector.Requests()
+		require.Len(t, requests, 1, "배치가 요청 하나로 전송되어야 합니다.")
+		assert.Equal(t, "/v1/logs", collector.path)
+		assert.Equal(t, "application/x-protobuf", collector.header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", collector.header.Get("Authorization"))
+
+		resourceLogs := requests[0].GetResourceLogs()
+		require.Len(t, resourceLogs, 1)
+		assert.Equal(t, map[string]interface{}{
+			"service.name":           "payment-api",
+			"service.version":        "1.4.2",
+			"deployment.environment": "prod",
+		}, attributes(resourceLogs[0].GetResource().GetAttributes()))
+
+		records := resourceLogs[0].GetScopeLogs()[0].GetLogRecords()
+		require.Len(t, records, 2)
+		record := records[0]
+		assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_WARN, record.GetSeverityNumber())
+		assert.Equal(t, "WARN", record.GetSeverityText())
+		assert.Equal(t, "payment failed", record.GetBody().GetStringValue())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", hex.EncodeToString(record.GetTraceId()))
+		assert.Equal(t, "00f067aa0ba902b7", hex.EncodeToString(record.GetSpanId()))
+		assert.NotZero(t, record.GetTimeUnixNano())
+		assert.NotZero(t, record.GetObservedTimeUnixNano())
+		assert.Equal(t, map[string]interface{}{
+			"service":     "payment",
+			"status_code": int64(502),
+			"ratio":       0.5,
+			"retry":       true,
+			"http":        map[string]interface{}{"method": "POST"},
+			"tags":        []interface{}{"a", "b"},
+		}, attributes(record.GetAttributes()), "레벨, 메시지, 시간, trace 필드를 제외한 필드가 속성이어야 합니다.")
+		assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, records[1].GetSeverityNumber())
+		assert.Equal(t, "paid", records[1].GetBody().GetStringValue())
+		assert.Equal(t, uint64(paidAt.UnixNano()), records[1].GetTimeUnixNano(), "로거의 시간 포맷으로 기록된 time 필드를 읽어야 합니다.")
+		assert.NotEqual(t, records[1].GetTimeUnixNano(), records[1].GetObservedTimeUnixNano())
+	})
+
+	t.Run("OTLP/gRPC 로 재시도하며 전송하고 부분 성공을 집계하는지 테스트", func(t *testing.T) {
+		// given
+		collector := newOTLPCollector()
+		collector.failures = 1
+		collector.rejected = 1
+		ln := listen(t, "127.0.0.1:0")
+		server := grpc.NewServer()
+		collogspb.RegisterLogsServiceServer(server, collector)
+		go func() { _ = server.Serve(ln) }()
+		defer server.Stop()
+
+		s, err := sink.NewOTLP(sink.OTLPGRPC, ln.Addr().String(),
+			sink.WithOTLPInsecure(),
+			sink.WithOTLPService("payment-api", ""),
+			sink.WithHeader("X-Tenant", "kr"),
+			sink.WithBackoff(10*time.Millisecond, 10*time.Millisecond),
+			sink.WithFlushInterval(10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		defer s.Close()
+		log := logger.NewWrapper(types.Logrus, options.WithOutput(s))
+
+		// when
+		log.Error(options.WithMessage("failed"), options.WithFields(options.Fields{types.TraceIDField: "not-hex"}))
+
+		// then
+		require.Eventually(t, func() bool { return len(collector.Requests()) == 1 }, 2*time.Second, 5*time.Millisecond)
+		records := collector.Requests()[0].GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_ERROR, records[0].GetSeverityNumber())
+		assert.Equal(t, "failed", records[0].GetBody().GetStringValue())
+		assert.Empty(t, records[0].GetTraceId())
+		assert.Equal(t, "not-hex", attributes(records[0].GetAttributes())[types.TraceIDField], "잘못된 trace ID 는 속성으로 남아야 합니다.")
+		assert.Equal(t, []string{"kr"}, collector.metadata.Get("x-tenant"))
+		assert.Equal(t, 2, collector.Calls(), "Unavailable 응답은 재시도되어야 합니다.")
+		assert.Eventually(t, func() bool { return s.Rejected() == uint64(1) }, time.Second, 5*time.Millisecond)
+		assert.NoError(t, s.Health())
+		assert.Zero(t, s.Dropped())
+	})
+
+	t.Run("지원하지 않는 프로토콜과 잘못된 주소가 에러를 반환하는지 테스트", func(t *testing.T) {
+		// when
+		_, protocolErr := sink.NewOTLP("http/json", "http://localhost:4318")
+		_, endpointErr := sink.NewOTLP(sink.OTLPHTTP, "localhost:4318")
+
+		// then
+		assert.Error(t, protocolErr)
+		assert.Error(t, endpointErr)
+	})
+}
+
+// otlpCollector : OTLP/HTTP 와 OTLP/gRPC 로그 요청을 받아 보관하는 테스트용 수집기
+type otlpCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	mu       sync.Mutex
+	requests []*collogspb.ExportLogsServiceRequest
+	calls    int
+	failures int
+	rejected int64
+	path     string
+	header   http.Header
+	metadata metadata.MD
+}
+
+func newOTLPCollector() *otlpCollector {
+	return &otlpCollector{}
+}
+
+// ServeHTTP : OTLP/HTTP protobuf 요청을 받는 메서드
+func (c *otlpCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = gz
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request := &collogspb.ExportLogsServiceRequest{}
+	if err := proto.Unmarshal(data, request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	c.path, c.header = r.URL.Path, r.Header.Clone()
+	c.requests = append(c.requests, request)
+	c.mu.Unlock()
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+// Export : OTLP/gRPC 요청을 받는 메서드, failures 만큼 Unavailable 로 응답한다.
+func (c *otlpCollector) Export(ctx context.Context, request *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.failures > 0 {
+		c.failures--
+		return nil, status.Error(codes.Unavailable, "collector is starting")
+	}
+	c.metadata, _ = metadata.FromIncomingContext(ctx)
+	c.requests = append(c.requests, request)
+
+	response := &collogspb.ExportLogsServiceResponse{}
+	if c.rejected > 0 {
+		response.PartialSuccess = &collogspb.ExportLogsPartialSuccess{RejectedLogRecords: c.rejected}
+	}
+	return response, nil
+}
+
+func (c *otlpCollector) Requests() []*collogspb.ExportLogsServiceRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*collogspb.ExportLogsServiceRequest(nil), c.requests...)
+}
+
+func (c *otlpCollector) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+// attributes : KeyValue 목록을 비교하기 쉬운 맵으로 변환하는 함수
+func attributes(kvs []*commonpb.KeyValue) map[string]interface{} {
+	out := make(map[string]interface{}, len(kvs))
+	for _, kv := range kvs {
+		out[kv.GetKey()] = anyValue(kv.GetValue())
+	}
+	return out
+}
+
+func anyValue(v *commonpb.AnyValue) interface{} {
+	switch value := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return value.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return value.BoolValue
+	case *commonpb.AnyValue_IntValue:
+		return value.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return value.DoubleValue
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]interface{}, 0, len(value.ArrayValue.GetValues()))
+		for _, item := range value.ArrayValue.GetValues() {
+			values = append(values, anyValue(item))
+		}
+		return values
+	case *commonpb.AnyValue_KvlistValue:
+		return attributes(value.KvlistValue.GetValues())
+	default:
+		return nil
+	}
+}
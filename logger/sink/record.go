@@ -0,0 +1,71 @@
+package sink
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// defaultTimeFormat : 로거의 기본 시간 포맷 (options.WithTimeFormat 의 기본값)
+const defaultTimeFormat = "2006-01-02 15:04:05"
+
+// decodeLine : JSON 로그 라인을 필드 맵으로 변환하는 함수
+//
+// 숫자는 정수인 경우 int64 로, 그렇지 않은 경우 float64 로 변환하여 타입을 유지한다.
+func decodeLine(line []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	for k, v := range fields {
+		fields[k] = normalize(v)
+	}
+	return fields, nil
+}
+
+// normalize : json.Number 를 int64/float64 로 재귀적으로 변환하는 함수
+func normalize(v interface{}) interface{} {
+	switch value := v.(type) {
+	case stdjson.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case map[string]interface{}:
+		for k, item := range value {
+			value[k] = normalize(item)
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = normalize(item)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+// entryTime : 로그 라인의 time 필드를 시각으로 변환하는 함수
+//   - fields(map[string]interface{}): 로그 필드
+//   - timeFormat(string): 로거에 설정한 시간 포맷 (시간대가 없으면 로컬 시간대)
+//   - fallback(time.Time): time 필드가 없거나 읽을 수 없는 경우 사용할 시각 (싱크에 기록된 시각)
+func entryTime(fields map[string]interface{}, timeFormat string, fallback time.Time) time.Time {
+	text, ok := fields[types.TimeField].(string)
+	if !ok {
+		return fallback
+	}
+	if t, err := time.ParseInLocation(timeFormat, text, time.Local); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+		return t
+	}
+	return fallback
+}
@@ -0,0 +1,134 @@
+package sink
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrSpoolFull : 스풀 파일이 최대 크기에 도달한 경우의 에러
+var ErrSpoolFull = errors.New("sink: spool is full")
+
+// spool : 전송하지 못한 로그 라인을 순서대로 보관하는 디스크 버퍼
+//   - path(string): 스풀 파일 경로
+//   - maxBytes(int64): 스풀 파일의 최대 크기 (0 이하인 경우 제한 없음)
+type spool struct {
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+}
+
+func openSpool(path string, maxBytes int64) (*spool, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	s := &spool{path: path, maxBytes: maxBytes}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *spool) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	s.file, s.size = file, info.Size()
+	return nil
+}
+
+// empty : 스풀에 남은 라인이 없는지 확인하는 메서드
+func (s *spool) empty() bool {
+	return s == nil || s.size == 0
+}
+
+// append : 스풀 끝에 라인을 추가하는 메서드
+func (s *spool) append(line []byte) error {
+	if s.maxBytes > 0 && s.size+int64(len(line)) > s.maxBytes {
+		return ErrSpoolFull
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+// replay : 스풀에 쌓인 라인을 순서대로 w에 기록하는 메서드
+//
+// 모든 라인을 기록하면 스풀을 비우고, 도중에 실패하면 아직 기록하지 못한 라인만 남긴다.
+func (s *spool) replay(w io.Writer) error {
+	if s.empty() {
+		return nil
+	}
+
+	var offset int64
+	reader := bufio.NewReader(io.NewSectionReader(s.file, 0, s.size))
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if _, werr := w.Write(line); werr != nil {
+				if cerr := s.compact(offset); cerr != nil {
+					return cerr
+				}
+				return werr
+			}
+			offset += int64(len(line))
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.file.Truncate(0); err != nil {
+		return err
+	}
+	s.size = 0
+	return nil
+}
+
+// compact : offset 이전의 라인을 스풀에서 제거하는 메서드
+func (s *spool) compact(offset int64) error {
+	if offset == 0 {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(tmp, io.NewSectionReader(s.file, offset, s.size-offset)); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = s.file.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	return s.open()
+}
+
+// close : 스풀 파일을 닫는 메서드 (남은 라인은 다음 실행 시 재전송됨)
+func (s *spool) close() error {
+	if s == nil {
+		return nil
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,181 @@
+package sqllog
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// conn : 쿼리 실행을 기록하는 driver.Conn 래퍼
+//
+// 실제 연결이 지원하지 않는 선택 인터페이스는 database/sql 이 기본 동작을 하도록 driver.ErrSkip 을 반환한다.
+type conn struct {
+	driver.Conn
+	setting *setting
+}
+
+// Prepare : 쿼리를 준비하는 메서드
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext : 쿼리를 준비하고 실행을 기록하는 statement 를 반환하는 메서드
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		st  driver.Stmt
+		err error
+	)
+	if pc, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		st, err = pc.PrepareContext(ctx, query)
+	} else {
+		st, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{Stmt: st, query: query, conn: c, setting: c.setting}, nil
+}
+
+// BeginTx : 트랜잭션을 시작하는 메서드
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if bc, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return bc.BeginTx(ctx, opts)
+	}
+	if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) {
+		return nil, errors.New("sqllog: driver does not support non-default isolation level")
+	}
+	if opts.ReadOnly {
+		return nil, errors.New("sqllog: driver does not support read-only transactions")
+	}
+	return c.Conn.Begin()
+}
+
+// ExecContext : 쿼리를 실행하고 기록하는 메서드
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ec, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := ec.ExecContext(ctx, query, args)
+	c.setting.log(ctx, query, args, start, result, err)
+	return result, err
+}
+
+// QueryContext : 쿼리를 실행하고 기록하는 메서드
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	qc, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := qc.QueryContext(ctx, query, args)
+	c.setting.log(ctx, query, args, start, nil, err)
+	return rows, err
+}
+
+// Ping : 연결 상태를 확인하는 메서드
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession : 연결을 재사용하기 전에 세션을 초기화하는 메서드
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid : 연결을 재사용할 수 있는지 반환하는 메서드
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue : 파라미터 값을 검사하는 메서드
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nc, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// stmt : 실행을 기록하는 driver.Stmt 래퍼
+type stmt struct {
+	driver.Stmt
+	query   string
+	conn    *conn
+	setting *setting
+}
+
+// ExecContext : 준비된 쿼리를 실행하고 기록하는 메서드
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var (
+		result driver.Result
+		err    error
+	)
+	if ec, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = ec.ExecContext(ctx, args)
+	} else if values, verr := namedValues(args); verr != nil {
+		err = verr
+	} else {
+		result, err = s.Stmt.Exec(values)
+	}
+	s.setting.log(ctx, s.query, args, start, result, err)
+	return result, err
+}
+
+// QueryContext : 준비된 쿼리를 실행하고 기록하는 메서드
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var (
+		rows driver.Rows
+		err  error
+	)
+	if qc, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = qc.QueryContext(ctx, args)
+	} else if values, verr := namedValues(args); verr != nil {
+		err = verr
+	} else {
+		rows, err = s.Stmt.Query(values)
+	}
+	s.setting.log(ctx, s.query, args, start, nil, err)
+	return rows, err
+}
+
+// CheckNamedValue : 파라미터 값을 검사하는 메서드
+//
+// database/sql 은 statement 의 검사기가 있으면 연결의 검사기를 사용하지 않으므로,
+// 실제 statement, 실제 statement 의 ColumnConverter, 실제 연결 순서로 검사기를 찾는다.
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) (err error) {
+	switch checker := s.Stmt.(type) {
+	case driver.NamedValueChecker:
+		return checker.CheckNamedValue(nv)
+	case driver.ColumnConverter:
+		nv.Value, err = checker.ColumnConverter(nv.Ordinal - 1).ConvertValue(nv.Value)
+		return err
+	default:
+		return s.conn.CheckNamedValue(nv)
+	}
+}
+
+// namedValues : 이름 있는 파라미터를 지원하지 않는 드라이버를 위해 값만 꺼내는 함수
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("sqllog: driver does not support the use of Named Parameters")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
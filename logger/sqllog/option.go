@@ -0,0 +1,94 @@
+package sqllog
+
+import (
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/masking"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// setting : 쿼리 로깅 설정
+type setting struct {
+	loggerType    types.LoggerType
+	fallback      logger.Logger
+	level         types.LogLevel
+	slowThreshold time.Duration
+	logArgs       bool
+	argMasking    masking.Rules
+	message       string
+}
+
+func newSetting(loggerType types.LoggerType, opts []Option) setting {
+	s := setting{
+		loggerType: loggerType,
+		level:      types.Debug,
+		message:    "sql query",
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if s.fallback == nil {
+		// 쿼리마다 새 로거를 만들지 않도록 한 번만 생성한다.
+		s.fallback = logger.NewWrapper(loggerType)
+	}
+	return s
+}
+
+// Option 쿼리 로깅 설정을 위한 옵션 타입
+//   - WithLogger: 컨텍스트에 로거가 없을 때 사용할 로거를 설정하는 옵션
+//   - WithLevel: 쿼리 로그 레벨을 설정하는 옵션
+//   - WithSlowThreshold: 느린 쿼리 기준 시간을 설정하는 옵션
+//   - WithArgs: 쿼리 파라미터 기록을 설정하는 옵션
+//   - WithMessage: 쿼리 로그 메시지를 설정하는 옵션
+type Option func(*setting)
+
+// WithLogger 컨텍스트에 로거가 없을 때 사용할 로거를 설정하는 옵션 (default: logger.NewWrapper 로 생성한 로거)
+//
+// ExecContext/QueryContext 가 아닌 Exec/Query 로 실행한 쿼리는 이 로거로 기록된다.
+func WithLogger(log logger.Logger) Option {
+	return func(s *setting) {
+		s.fallback = log
+	}
+}
+
+// WithLevel 성공한 쿼리의 로그 레벨을 설정하는 옵션 (default: debug)
+//   - 실패한 쿼리는 error, 느린 쿼리는 warn 으로 기록된다.
+func WithLevel(level types.LogLevel) Option {
+	return func(s *setting) {
+		s.level = level
+	}
+}
+
+// WithSlowThreshold 느린 쿼리 기준 시간을 설정하는 옵션 (default: 0, 사용하지 않음)
+//   - 기준 시간 이상 걸린 쿼리는 slow 필드와 함께 warn 레벨로 기록된다.
+//
+// Example:
+//
+//	sqllog.WithSlowThreshold(200 * time.Millisecond)
+func WithSlowThreshold(threshold time.Duration) Option {
+	return func(s *setting) {
+		s.slowThreshold = threshold
+	}
+}
+
+// WithArgs 쿼리 파라미터를 기록하도록 설정하는 옵션 (default: 기록하지 않음)
+//   - rules(masking.Rules): 파라미터 이름(sql.Named) 또는 순서("1", "2", ...) 별 마스킹 규칙
+//
+// Example:
+//
+//	// 두 번째 파라미터와 phone 파라미터를 마스킹
+//	sqllog.WithArgs(masking.Rules{"2": masking.Password, "phone": masking.Mobile})
+func WithArgs(rules masking.Rules) Option {
+	return func(s *setting) {
+		s.logArgs = true
+		s.argMasking = rules
+	}
+}
+
+// WithMessage 쿼리 로그 메시지를 설정하는 옵션 (default: "sql query")
+func WithMessage(message string) Option {
+	return func(s *setting) {
+		s.message = message
+	}
+}
@@ -0,0 +1,195 @@
+// Package sqllog : 쿼리를 요청 컨텍스트의 로거로 기록하는 database/sql 드라이버 래퍼
+//
+// 쿼리마다 SQL, 처리 시간, 변경된 행 수, 에러를 기록하며, 파라미터는 기본적으로 기록하지 않는다.
+//
+// Example:
+//
+//	connector, err := pq.NewConnector(dsn)
+//	if err != nil {
+//		panic(err)
+//	}
+//	db := sql.OpenDB(sqllog.WrapConnector(connector, types.ZeroLog,
+//		sqllog.WithSlowThreshold(200*time.Millisecond),
+//		sqllog.WithArgs(masking.Rules{"phone": masking.Mobile}),
+//	))
+//	// HTTPMiddleware 가 등록한 요청 단위 로거로 기록되어 요청 ID 로 연관 지을 수 있다.
+//	rows, err := db.QueryContext(r.Context(), "SELECT * FROM users WHERE id = $1", id)
+//	// output: {"level":"debug","rid":"...","sql":"SELECT * FROM users WHERE id = $1","elapsed":3,"message":"sql query"}
+package sqllog
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/masking"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+// Wrap : 쿼리 로그를 기록하는 driver.Driver 래퍼 생성자
+//   - d(driver.Driver): 실제 드라이버
+//   - loggerType(types.LoggerType): 쿼리 컨텍스트에서 가져올 로거 타입
+//   - opts(...Option): 쿼리 로깅 설정 옵션
+//
+// Example:
+//
+//	sql.Register("postgres-log", sqllog.Wrap(&pq.Driver{}, types.ZeroLog))
+//	db, err := sql.Open("postgres-log", dsn)
+func Wrap(d driver.Driver, loggerType types.LoggerType, opts ...Option) driver.Driver {
+	s := newSetting(loggerType, opts)
+	return &wrappedDriver{Driver: d, setting: &s}
+}
+
+// WrapConnector : 쿼리 로그를 기록하는 driver.Connector 래퍼 생성자
+//   - c(driver.Connector): 실제 커넥터
+//   - loggerType(types.LoggerType): 쿼리 컨텍스트에서 가져올 로거 타입
+//   - opts(...Option): 쿼리 로깅 설정 옵션
+func WrapConnector(c driver.Connector, loggerType types.LoggerType, opts ...Option) driver.Connector {
+	s := newSetting(loggerType, opts)
+	return &connector{Connector: c, driver: &wrappedDriver{Driver: c.Driver(), setting: &s}}
+}
+
+// wrappedDriver : 연결을 래핑하는 driver.Driver
+type wrappedDriver struct {
+	driver.Driver
+	setting *setting
+}
+
+// Open : 연결을 열고 래핑하는 메서드
+func (d *wrappedDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: c, setting: d.setting}, nil
+}
+
+// OpenConnector : 커넥터를 열고 래핑하는 메서드 (driver.DriverContext 구현)
+func (d *wrappedDriver) OpenConnector(name string) (driver.Connector, error) {
+	dc, ok := d.Driver.(driver.DriverContext)
+	if !ok {
+		return &connector{Connector: dsnConnector{name: name, driver: d.Driver}, driver: d}, nil
+	}
+	c, err := dc.OpenConnector(name)
+	if err != nil {
+		return nil, err
+	}
+	return &connector{Connector: c, driver: d}, nil
+}
+
+// connector : 연결을 래핑하는 driver.Connector
+type connector struct {
+	driver.Connector
+	driver *wrappedDriver
+}
+
+// Connect : 연결을 맺고 래핑하는 메서드
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: cn, setting: c.driver.setting}, nil
+}
+
+// Driver : 래핑된 드라이버를 반환하는 메서드
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// dsnConnector : driver.DriverContext 를 구현하지 않는 드라이버를 위한 커넥터
+type dsnConnector struct {
+	name   string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// log : 쿼리 결과를 레벨에 맞춰 기록하는 메서드
+//
+// 드라이버가 지원하지 않아 database/sql 이 다른 방식으로 다시 실행하는 경우(driver.ErrSkip)는 기록하지 않는다.
+func (s *setting) log(ctx context.Context, query string, args []driver.NamedValue, start time.Time, result driver.Result, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+	elapsed := time.Since(start)
+
+	fields := options.Fields{
+		types.SQLQueryField: query,
+		types.ElapsedField:  elapsed.Milliseconds(),
+	}
+	if s.logArgs && len(args) > 0 {
+		fields[types.SQLArgsField] = s.maskArgs(args)
+	}
+	if result != nil {
+		if rows, rerr := result.RowsAffected(); rerr == nil {
+			fields[types.RowsAffectedField] = rows
+		}
+	}
+
+	level := s.level
+	switch {
+	case err != nil:
+		level = types.Error
+		fields[types.ErrorField] = err.Error()
+	case s.slowThreshold > 0 && elapsed >= s.slowThreshold:
+		level = types.Warn
+		fields[types.SlowField] = true
+	}
+
+	// 쿼리 필드가 요청 로거에 남지 않도록 복사한 로거로 기록
+	logger.Log(s.logger(ctx).Clone(), level, options.WithMessage(s.message), options.WithFields(fields))
+}
+
+// logger : 쿼리 컨텍스트의 로거를 반환하는 메서드 (없으면 WithLogger 로 설정한 로거)
+func (s *setting) logger(ctx context.Context) logger.Logger {
+	if log, ok := logger.LookupContext(ctx, s.loggerType); ok {
+		return log
+	}
+	return s.fallback
+}
+
+// maskArgs : 규칙에 해당하는 파라미터를 마스킹하여 기록할 값으로 변환하는 메서드
+func (s *setting) maskArgs(args []driver.NamedValue) []interface{} {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		kind, ok := s.argMasking[arg.Name]
+		if !ok || arg.Name == "" {
+			kind, ok = s.argMasking[strconv.Itoa(arg.Ordinal)]
+		}
+
+		switch v := arg.Value.(type) {
+		case nil:
+			values[i] = nil
+		case []byte:
+			values[i] = maskArg(ok, kind, string(v))
+		case string:
+			values[i] = maskArg(ok, kind, v)
+		default:
+			if ok {
+				values[i] = masking.Mask(kind, fmt.Sprint(v))
+			} else {
+				values[i] = v
+			}
+		}
+	}
+	return values
+}
+
+func maskArg(masked bool, kind masking.Kind, value string) string {
+	if masked {
+		return masking.Mask(kind, value)
+	}
+	return value
+}
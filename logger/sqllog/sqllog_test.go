@@ -0,0 +1,277 @@
+package sqllog_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wjddn3711/structured-logger/logger"
+	"github.com/wjddn3711/structured-logger/logger/masking"
+	"github.com/wjddn3711/structured-logger/logger/options"
+	"github.com/wjddn3711/structured-logger/logger/sqllog"
+	"github.com/wjddn3711/structured-logger/logger/types"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func TestSQLLog(t *testing.T) {
+	t.Run("쿼리 컨텍스트의 로거로 쿼리, 처리 시간, 변경된 행 수가 기록되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+		log.RegisterCommonField(types.RequestIDField, "req-1")
+		ctx := log.WithContext(context.Background())
+		db := sql.OpenDB(sqllog.WrapConnector(fakeConnector{}, types.ZeroLog))
+		defer db.Close()
+
+		// when
+		_, err := db.ExecContext(ctx, "INSERT INTO users (name, phone) VALUES (?, ?)", "kim", "01012345678")
+		require.NoError(t, err)
+
+		// then
+		query := captureWriter.Map()
+		assert.Equal(t, "sql query", query[types.MessageField])
+		assert.Equal(t, "debug", query[types.LevelField])
+		assert.Equal(t, "req-1", query[types.RequestIDField], "컨텍스트 로거의 공통 필드가 기록되어야 합니다.")
+		assert.Equal(t, "INSERT INTO users (name, phone) VALUES (?, ?)", query[types.SQLQueryField])
+		assert.Equal(t, float64(1), query[types.RowsAffectedField])
+		assert.Contains(t, query, types.ElapsedField)
+		assert.NotContains(t, query, types.SQLArgsField, "파라미터는 기본적으로 기록되지 않아야 합니다.")
+	})
+
+	t.Run("파라미터가 규칙에 따라 마스킹되어 기록되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.Logrus, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+		db := sql.OpenDB(sqllog.WrapConnector(fakeConnector{}, types.Logrus,
+			sqllog.WithArgs(masking.Rules{"2": masking.Mobile, "password": masking.Password}),
+		))
+		defer db.Close()
+
+		// when
+		rows, err := db.QueryContext(log.WithContext(context.Background()),
+			"SELECT name FROM users WHERE name = ? AND phone = ? AND password = @password",
+			"kim", "01012345678", sql.Named("password", "secret"))
+		require.NoError(t, err)
+		var names []string
+		for rows.Next() {
+			var name string
+			require.NoError(t, rows.Scan(&name))
+			names = append(names, name)
+		}
+		require.NoError(t, rows.Close())
+
+		// then
+		assert.Equal(t, []string{"kim"}, names)
+		query := captureWriter.Map()
+		assert.Equal(t, []interface{}{"kim", "0101***5678", "************"}, query[types.SQLArgsField])
+		assert.NotContains(t, query, types.RowsAffectedField)
+	})
+
+	t.Run("실패한 쿼리는 error, 느린 쿼리는 warn 으로 기록되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter))
+		db := sql.OpenDB(sqllog.WrapConnector(fakeConnector{}, types.ZeroLog,
+			sqllog.WithLogger(log),
+			sqllog.WithSlowThreshold(10*time.Millisecond),
+		))
+		defer db.Close()
+
+		// when
+		_, failErr := db.Exec("FAIL")
+		_, slowErr := db.Exec("SLOW")
+		_, fastErr := db.Exec("INSERT INTO users (name) VALUES ('lee')")
+
+		// then
+		assert.Error(t, failErr)
+		assert.NoError(t, slowErr)
+		assert.NoError(t, fastErr)
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 2, "info 레벨 로거에는 debug 레벨의 쿼리 로그가 기록되지 않아야 합니다.")
+		assert.Equal(t, "error", lines[0][types.LevelField])
+		assert.Equal(t, "fake: failed", lines[0][types.ErrorField])
+		assert.Equal(t, "warn", lines[1][types.LevelField])
+		assert.Equal(t, true, lines[1][types.SlowField])
+	})
+
+	t.Run("쿼리 필드가 요청 컨텍스트의 로거에 남지 않는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithOutput(captureWriter))
+		ctx := log.WithContext(context.Background())
+		db := sql.OpenDB(sqllog.WrapConnector(fakeConnector{}, types.ZeroLog, sqllog.WithLevel(types.Info)))
+		defer db.Close()
+
+		// when
+		_, err := db.ExecContext(ctx, "FAIL")
+		logger.FromContext(ctx, types.ZeroLog).Info(options.WithMessage("handler done"))
+
+		// then
+		assert.Error(t, err)
+		lines := captureWriter.Lines()
+		require.Len(t, lines, 2)
+		assert.Equal(t, "FAIL", lines[0][types.SQLQueryField])
+		assert.Equal(t, "handler done", lines[1][types.MessageField])
+		assert.NotContains(t, lines[1], types.SQLQueryField, "쿼리 필드가 요청 로거에 남지 않아야 합니다.")
+		assert.NotContains(t, lines[1], types.ErrorField)
+		assert.NotContains(t, lines[1], types.ElapsedField)
+	})
+
+	t.Run("준비된 쿼리 실행이 기록되는지 테스트", func(t *testing.T) {
+		// given
+		captureWriter := &captureWriter{}
+		log := logger.NewWrapper(types.ZeroLog, options.WithLevel(types.Debug), options.WithOutput(captureWriter))
+		sql.Register("sqllog-fake", sqllog.Wrap(fakeDriver{}, types.ZeroLog, sqllog.WithLogger(log)))
+		db, err := sql.Open("sqllog-fake", "")
+		require.NoError(t, err)
+		defer db.Close()
+
+		// when
+		st, err := db.Prepare("INSERT INTO users (name) VALUES (?)")
+		require.NoError(t, err)
+		_, err = st.Exec("park")
+		require.NoError(t, err)
+		require.NoError(t, st.Close())
+
+		// then
+		query := captureWriter.Map()
+		assert.Equal(t, "INSERT INTO users (name) VALUES (?)", query[types.SQLQueryField])
+		assert.Equal(t, float64(1), query[types.RowsAffectedField])
+	})
+}
+
+// fakeDriver : 테스트용 메모리 드라이버
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn{}, nil
+}
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return fakeConn{}, nil
+}
+
+func (fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+// fakeConn : ExecerContext, QueryerContext 를 지원하는 연결
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{query: query}, nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake: transactions are not supported")
+}
+
+func (fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	return exec(query)
+}
+
+func (fakeConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{values: []driver.Value{args[0].Value}}, nil
+}
+
+// fakeStmt : context 를 지원하지 않는 statement
+type fakeStmt struct {
+	query string
+}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+func (s fakeStmt) NumInput() int {
+	return strings.Count(s.query, "?")
+}
+
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return exec(s.query)
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{}, nil
+}
+
+func exec(query string) (driver.Result, error) {
+	switch query {
+	case "FAIL":
+		return nil, errors.New("fake: failed")
+	case "SLOW":
+		time.Sleep(20 * time.Millisecond)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"name"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+type captureWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *captureWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *captureWriter) Lines() []map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var lines []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(w.buf.Bytes()), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var m map[string]interface{}
+		_ = json.Unmarshal(line, &m)
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func (w *captureWriter) Map() map[string]interface{} {
+	lines := w.Lines()
+	if len(lines) == 0 {
+		return nil
+	}
+	return lines[len(lines)-1]
+}
@@ -3,4 +3,95 @@ package types
 const (
 	// MessageField : 로그 메시지 필드
 	MessageField = "message"
+	// LevelField : 로그 레벨 필드
+	LevelField = "level"
+	// TimeField : 로그 시간 필드
+	TimeField = "time"
+	// CallerField : 로그 호출 위치 필드 (file:line)
+	CallerField = "caller"
+	// ErrorField : 에러 메시지 필드
+	ErrorField = "error"
+	// PanicField : 패닉 값 필드
+	PanicField = "panic"
+	// StackField : 고루틴 스택 트레이스 필드
+	StackField = "stack"
+	// SampledOutField : 샘플링으로 버려진 엔트리 수 필드
+	SampledOutField = "sampled_out"
+	// RepeatCountField : 반복 로그 억제로 기록되지 않은 횟수 필드
+	RepeatCountField = "repeat_count"
+	// FirstSeenField : 억제된 로그가 처음 발생한 시각 필드
+	FirstSeenField = "first_seen"
+	// LastSeenField : 억제된 로그가 마지막으로 발생한 시각 필드
+	LastSeenField = "last_seen"
+
+	// RequestIDField : 요청 ID 필드
+	RequestIDField = "rid"
+	// TraceIDField : 분산 추적 trace ID 필드
+	TraceIDField = "trace_id"
+	// SpanIDField : 분산 추적 span ID 필드
+	SpanIDField = "span_id"
+	// StartTimeField : 요청 시작 시각 필드
+	StartTimeField = "start_time"
+	// EndTimeField : 요청 종료 시각 필드
+	EndTimeField = "end_time"
+	// ElapsedField : 요청 처리 시간(ms) 필드
+	ElapsedField = "elapsed"
+	// StatusCodeField : 응답 상태 코드 필드
+	StatusCodeField = "status_code"
+	// MethodField : 요청 메서드 필드
+	MethodField = "method"
+	// URIField : 요청 URI 필드
+	URIField = "uri"
+	// RefererField : 요청 Referer 필드
+	RefererField = "referer"
+	// UserAgentField : 요청 User-Agent 필드
+	UserAgentField = "user_agent"
+	// RemoteAddrField : 요청 클라이언트 주소 필드
+	RemoteAddrField = "remote_addr"
+	// BytesWrittenField : 응답 본문 크기 필드
+	BytesWrittenField = "bytes_written"
+	// HostField : 요청 대상 호스트 필드
+	HostField = "host"
+	// PathField : 요청 경로 필드
+	PathField = "path"
+	// AttemptField : 요청 시도 횟수 필드 (1 부터 시작)
+	AttemptField = "attempt"
+	// RequestBodyField : 요청 본문 필드
+	RequestBodyField = "request_body"
+	// ResponseBodyField : 응답 본문 필드
+	ResponseBodyField = "response_body"
+	// RequestHeadersField : 요청 헤더 필드
+	RequestHeadersField = "request_headers"
+	// ResponseHeadersField : 응답 헤더 필드
+	ResponseHeadersField = "response_headers"
+
+	// GRPCServiceField : gRPC 서비스 이름 필드
+	GRPCServiceField = "grpc_service"
+	// GRPCMethodField : gRPC 메서드 이름 필드
+	GRPCMethodField = "grpc_method"
+	// GRPCCodeField : gRPC 상태 코드 필드
+	GRPCCodeField = "grpc_code"
+	// PeerField : 상대방 주소 필드
+	PeerField = "peer"
+	// MsgSentField : 스트림에서 보낸 메시지 수 필드
+	MsgSentField = "msg_sent"
+	// MsgReceivedField : 스트림에서 받은 메시지 수 필드
+	MsgReceivedField = "msg_received"
+
+	// SQLQueryField : SQL 쿼리 필드
+	SQLQueryField = "sql"
+	// SQLArgsField : SQL 쿼리 파라미터 필드
+	SQLArgsField = "sql_args"
+	// RowsAffectedField : 쿼리로 변경된 행 수 필드
+	RowsAffectedField = "rows_affected"
+	// SlowField : 느린 쿼리 여부 필드
+	SlowField = "slow"
+
+	// ComponentField : 컴포넌트 이름 필드 (logger.Named)
+	ComponentField = "component"
+	// ConfigField : 로깅 설정 파일 경로 필드
+	ConfigField = "config"
+
+	// EMFField : CloudWatch Embedded Metric Format 메타데이터 필드 (options.WithMetrics)
+	EMFField = "_aws"
 )
@@ -9,4 +9,8 @@ const (
 	ZerologKey LogContextKey = "zerolog-context-key"
 	// LogrusKey : logrus 로거 컨텍스트 키
 	LogrusKey LogContextKey = "logrus-context-key"
+	// RequestIDKey : 요청 ID 컨텍스트 키
+	RequestIDKey LogContextKey = "request-id-context-key"
+	// RetryAttemptsKey : 요청 시도 횟수 카운터 컨텍스트 키
+	RetryAttemptsKey LogContextKey = "retry-attempts-context-key"
 )
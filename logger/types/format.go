@@ -8,6 +8,10 @@ package types
 //	logFormat := types.JSON
 //	// 텍스트
 //	logFormat := types.Text
+//	// logfmt
+//	logFormat := types.Logfmt
+//	// CBOR
+//	logFormat := types.CBOR
 type LogFormat string
 
 const (
@@ -15,4 +19,12 @@ const (
 	JSON LogFormat = "json"
 	// Text : 텍스트
 	Text LogFormat = "text"
+	// Logfmt : key=value 형태의 logfmt (시간, 레벨, 메시지 순으로 시작)
+	Logfmt LogFormat = "logfmt"
+	// CBOR : 로그마다 CBOR(RFC 8949) 데이터 아이템 하나 (logger.DecodeBinary 로 JSON 변환)
+	//   - logrus 는 엔트리 필드를 바로 인코딩하며, zerolog 는 binary_log 빌드에서 zerolog 의 CBOR 인코더로 기록하고 기본 빌드에서는 JSON 출력을 맵 없이 바로 변환한다.
+	CBOR LogFormat = "cbor"
+	// MsgPack : 로그마다 MessagePack 맵 하나 (logger.DecodeBinary 로 JSON 변환)
+	//   - logrus 는 엔트리 필드를 바로 인코딩하며, zerolog 는 JSON 출력을 맵 없이 바로 변환한다.
+	MsgPack LogFormat = "msgpack"
 )
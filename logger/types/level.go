@@ -1,5 +1,10 @@
 package types
 
+import (
+	"fmt"
+	"strings"
+)
+
 type LogLevel string
 
 const (
@@ -9,3 +14,83 @@ const (
 	Error LogLevel = "error"
 	Fatal LogLevel = "fatal"
 )
+
+// ParseLevel : 로그에 기록된 레벨 문자열을 LogLevel 로 변환하는 함수
+//   - 백엔드마다 다른 표기(warning, panic 등)를 함께 처리
+//
+// Example:
+//
+//	level, err := types.ParseLevel("warning") // types.Warn
+func ParseLevel(text string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "trace", "debug":
+		return Debug, nil
+	case "info", "":
+		return Info, nil
+	case "warn", "warning":
+		return Warn, nil
+	case "error":
+		return Error, nil
+	case "fatal", "panic":
+		return Fatal, nil
+	default:
+		return Info, fmt.Errorf("types: unknown log level %q", text)
+	}
+}
+
+// Severity : 레벨의 심각도 순서를 반환하는 메서드 (debug: 0 ~ fatal: 4)
+//
+// Example:
+//
+//	// level 이 warn 이상인지 확인
+//	if level.Severity() >= types.Warn.Severity() {
+//		...
+//	}
+func (l LogLevel) Severity() int {
+	switch l {
+	case Debug:
+		return 0
+	case Warn:
+		return 2
+	case Error:
+		return 3
+	case Fatal:
+		return 4
+	default:
+		return 1
+	}
+}
+
+// SyslogPriority : 레벨에 해당하는 syslog 심각도(severity) 숫자를 반환하는 메서드
+//   - debug: 7, info: 6, warn: 4, error: 3, fatal: 2
+func (l LogLevel) SyslogPriority() int {
+	switch l {
+	case Debug:
+		return 7
+	case Warn:
+		return 4
+	case Error:
+		return 3
+	case Fatal:
+		return 2
+	default:
+		return 6
+	}
+}
+
+// OTelSeverity : 레벨에 해당하는 OpenTelemetry 로그 심각도 숫자를 반환하는 메서드
+//   - debug: 5, info: 9, warn: 13, error: 17, fatal: 21
+func (l LogLevel) OTelSeverity() int {
+	switch l {
+	case Debug:
+		return 5
+	case Warn:
+		return 13
+	case Error:
+		return 17
+	case Fatal:
+		return 21
+	default:
+		return 9
+	}
+}
@@ -0,0 +1,84 @@
+package types
+
+// MetricUnit : CloudWatch 메트릭 단위 타입
+//
+// Example:
+//
+//	// 밀리초
+//	unit := types.Milliseconds
+//	// 횟수
+//	unit := types.Count
+type MetricUnit string
+
+const (
+	// Seconds : 초
+	Seconds MetricUnit = "Seconds"
+	// Microseconds : 마이크로초
+	Microseconds MetricUnit = "Microseconds"
+	// Milliseconds : 밀리초
+	Milliseconds MetricUnit = "Milliseconds"
+	// Bytes : 바이트
+	Bytes MetricUnit = "Bytes"
+	// Kilobytes : 킬로바이트
+	Kilobytes MetricUnit = "Kilobytes"
+	// Megabytes : 메가바이트
+	Megabytes MetricUnit = "Megabytes"
+	// Gigabytes : 기가바이트
+	Gigabytes MetricUnit = "Gigabytes"
+	// Terabytes : 테라바이트
+	Terabytes MetricUnit = "Terabytes"
+	// Bits : 비트
+	Bits MetricUnit = "Bits"
+	// Kilobits : 킬로비트
+	Kilobits MetricUnit = "Kilobits"
+	// Megabits : 메가비트
+	Megabits MetricUnit = "Megabits"
+	// Gigabits : 기가비트
+	Gigabits MetricUnit = "Gigabits"
+	// Terabits : 테라비트
+	Terabits MetricUnit = "Terabits"
+	// Percent : 백분율
+	Percent MetricUnit = "Percent"
+	// Count : 횟수
+	Count MetricUnit = "Count"
+	// BytesPerSecond : 초당 바이트
+	BytesPerSecond MetricUnit = "Bytes/Second"
+	// KilobytesPerSecond : 초당 킬로바이트
+	KilobytesPerSecond MetricUnit = "Kilobytes/Second"
+	// MegabytesPerSecond : 초당 메가바이트
+	MegabytesPerSecond MetricUnit = "Megabytes/Second"
+	// GigabytesPerSecond : 초당 기가바이트
+	GigabytesPerSecond MetricUnit = "Gigabytes/Second"
+	// TerabytesPerSecond : 초당 테라바이트
+	TerabytesPerSecond MetricUnit = "Terabytes/Second"
+	// BitsPerSecond : 초당 비트
+	BitsPerSecond MetricUnit = "Bits/Second"
+	// KilobitsPerSecond : 초당 킬로비트
+	KilobitsPerSecond MetricUnit = "Kilobits/Second"
+	// MegabitsPerSecond : 초당 메가비트
+	MegabitsPerSecond MetricUnit = "Megabits/Second"
+	// GigabitsPerSecond : 초당 기가비트
+	GigabitsPerSecond MetricUnit = "Gigabits/Second"
+	// TerabitsPerSecond : 초당 테라비트
+	TerabitsPerSecond MetricUnit = "Terabits/Second"
+	// CountPerSecond : 초당 횟수
+	CountPerSecond MetricUnit = "Count/Second"
+	// None : 단위 없음
+	None MetricUnit = "None"
+)
+
+// Valid : CloudWatch 가 지원하는 단위인지 반환하는 메서드
+func (u MetricUnit) Valid() bool {
+	switch u {
+	case Seconds, Microseconds, Milliseconds,
+		Bytes, Kilobytes, Megabytes, Gigabytes, Terabytes,
+		Bits, Kilobits, Megabits, Gigabits, Terabits,
+		Percent, Count,
+		BytesPerSecond, KilobytesPerSecond, MegabytesPerSecond, GigabytesPerSecond, TerabytesPerSecond,
+		BitsPerSecond, KilobitsPerSecond, MegabitsPerSecond, GigabitsPerSecond, TerabitsPerSecond,
+		CountPerSecond, None:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,18 @@
+package types
+
+// Schema : 로그 필드 스키마 타입
+//
+// Example:
+//
+//	// Elastic Common Schema
+//	schema := types.ECS
+//	// Google Cloud Logging
+//	schema := types.GCP
+type Schema string
+
+const (
+	// ECS : Elastic Common Schema (@timestamp, log.level, trace.id 등)
+	ECS Schema = "ecs"
+	// GCP : Google Cloud Logging 구조화 로그 (severity, httpRequest, logging.googleapis.com/trace 등)
+	GCP Schema = "gcp"
+)
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/wjddn3711/structured-logger/logger/options"
@@ -13,28 +14,37 @@ type zerologLogger struct {
 	ctx    context.Context
 	event  zerolog.Context
 	entry  map[string]interface{}
+	attrs  []options.Attr
+	caller bool
+	// timeFormat : time 필드와 타입이 지정된 시각 필드(options.Time)의 포맷
+	timeFormat string
+	// metrics : 다음 로그 하나에만 기록할 EMF 메트릭 선언 (엔트리에 남기지 않음)
+	metrics *options.Metrics
+	// at, callerAt : 다음 로그 하나에만 적용할 시각과 호출 위치 (options.WithTime, options.WithCallerAt)
+	at       time.Time
+	callerAt string
 }
 
 func newZerologLogger(settings options.LogSetting) Logger {
-	logger := zerolog.New(settings.Output).With().Timestamp().Logger()
-
-	switch settings.Level {
-	case types.Debug:
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	case types.Info:
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
-	case types.Warn:
-		zerolog.SetGlobalLevel(zerolog.WarnLevel)
-	case types.Error:
-		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
-	default:
-		// default level: info
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	output := settings.Output
+	if settings.Format == types.Text {
+		output = zerolog.ConsoleWriter{Out: settings.Output, NoColor: true, TimeFormat: settings.TimeFormat}
+	} else if zerologBinary {
+		// binary_log 빌드의 zerolog 는 CBOR 로 기록하므로, 스키마 없는 CBOR 포맷이 아니면 다시 인코딩한다.
+		if settings.Format != types.CBOR || settings.Schema != "" {
+			writer := newEncodedWriter(settings.Output, formatEncoder(settings))
+			writer.decode = decodeCBORLine(settings.TimeFormat)
+			writer.transcode = nil
+			output = writer
+		}
+	} else if encoder := newLineEncoder(settings); encoder != nil {
+		output = newEncodedWriter(settings.Output, encoder)
 	}
+	// 레벨과 시간 포맷은 로거마다 적용하며, 전역 설정(zerolog.SetGlobalLevel, zerolog.TimeFieldFormat)은 바꾸지 않는다.
+	// 시간은 zerolog 의 Timestamp 대신 send 에서 로거의 포맷으로 기록한다.
+	logger := zerolog.New(output).Level(zerologLevel(settings.Level))
 
-	zerolog.TimeFieldFormat = settings.TimeFormat
-
-	return &zerologLogger{logger: logger}
+	return &zerologLogger{logger: logger, caller: settings.Caller, timeFormat: settings.TimeFormat}
 }
 
 // AddHook : 로거에 후크를 추가하는 메서드
@@ -45,6 +55,29 @@ func (l *zerologLogger) AddHook(hook interface{}) {
 	}
 }
 
+// Clone : 공통 필드와 등록된 엔트리를 복사한 새 로거를 반환하는 메서드
+func (l *zerologLogger) Clone() Logger {
+	clone := *l
+	if l.entry != nil {
+		clone.entry = make(map[string]interface{}, len(l.entry))
+		for k, v := range l.entry {
+			clone.entry[k] = v
+		}
+	}
+	if l.attrs != nil {
+		clone.attrs = append([]options.Attr(nil), l.attrs...)
+	}
+	return &clone
+}
+
+// cloneCommon : 공통 필드만 복사하고 엔트리는 비운 새 로거를 반환하는 메서드
+func (l *zerologLogger) cloneCommon() Logger {
+	clone := *l
+	clone.entry, clone.attrs, clone.metrics = nil, nil, nil
+	clone.at, clone.callerAt = time.Time{}, ""
+	return &clone
+}
+
 // ApplyOption : 로그 엔트리 옵션을 적용하는 메서드
 func (l *zerologLogger) ApplyOption(opts []options.EntryOption) {
 	entryOtp := &options.Entry{}
@@ -60,13 +93,30 @@ func (l *zerologLogger) ApplyOption(opts []options.EntryOption) {
 		}
 	}
 	if entryOtp.Fields != nil {
+		fields := entryOtp.Fields.ToFields()
 		if l.entry != nil {
-			for k, v := range entryOtp.Fields.ToFields() {
+			for k, v := range fields {
 				l.entry[k] = v
 			}
 		} else {
-			l.entry = entryOtp.Fields.ToFields()
+			l.entry = fields
 		}
+		// 나중에 적용한 필드가 같은 키의 타입이 지정된 필드를 대신한다.
+		l.attrs = withoutFields(l.attrs, fields)
+	}
+	if len(entryOtp.Attrs) > 0 {
+		l.attrs = mergeAttrs(l.attrs, entryOtp.Attrs)
+		// 나중에 적용한 타입이 지정된 필드가 같은 키의 엔트리 필드를 대신한다.
+		l.entry = l.overridden("", entryOtp.Attrs)
+	}
+	if entryOtp.Metrics != nil {
+		l.metrics = entryOtp.Metrics
+	}
+	if !entryOtp.Time.IsZero() {
+		l.at = entryOtp.Time
+	}
+	if entryOtp.Caller != "" {
+		l.callerAt = entryOtp.Caller
 	}
 }
 
@@ -87,35 +137,255 @@ func (l *zerologLogger) RegisterCommonFields(entry options.LogEntry) {
 
 // Debug : 디버그 로그를 출력하는 메서드
 func (l *zerologLogger) Debug(opts ...options.EntryOption) {
-	l.ApplyOption(opts)
-	temp := l.logger.With().Fields(l.entry).Logger()
-	temp.Debug().Send()
+	l.log(l.logger.Debug(), opts)
 }
 
 // Info : 정보 로그를 출력하는 메서드
 func (l *zerologLogger) Info(opts ...options.EntryOption) {
-	l.ApplyOption(opts)
-	temp := l.logger.With().Fields(l.entry).Logger()
-	temp.Info().Send()
+	l.log(l.logger.Info(), opts)
 }
 
 // Warn : 경고 로그를 출력하는 메서드
 func (l *zerologLogger) Warn(opts ...options.EntryOption) {
-	l.ApplyOption(opts)
-	temp := l.logger.With().Fields(l.entry).Logger()
-	temp.Warn().Send()
+	l.log(l.logger.Warn(), opts)
 }
 
 // Error : 에러 로그를 출력하는 메서드
 func (l *zerologLogger) Error(opts ...options.EntryOption) {
-	l.ApplyOption(opts)
-	temp := l.logger.With().Fields(l.entry).Logger()
-	temp.Error().Send()
+	l.log(l.logger.Error(), opts)
 }
 
 // Fatal : 치명적인 에러 로그를 출력하는 메서드
 func (l *zerologLogger) Fatal(opts ...options.EntryOption) {
+	l.log(l.logger.Fatal(), opts)
+}
+
+// log : 엔트리 옵션을 적용하여 이벤트를 출력하는 메서드
+//
+// 비활성 레벨(event 가 nil)이면 옵션을 적용하지 않고 바로 반환한다.
+// 엔트리는 임시 로거를 만들지 않고 이벤트에 바로 기록한다.
+func (l *zerologLogger) log(event *zerolog.Event, opts []options.EntryOption) {
+	if event == nil {
+		return
+	}
 	l.ApplyOption(opts)
-	temp := l.logger.With().Fields(l.entry).Logger()
-	temp.Fatal().Send()
+	if len(l.entry) > 0 {
+		event = event.Fields(l.entry)
+	}
+	if l.metrics != nil {
+		event = event.Interface(types.EMFField, l.metrics)
+		l.metrics = nil
+	}
+	l.send(zerologAttrs(event, l.attrs, l.timeFormat))
+}
+
+// logAttrs : 엔트리에 남기지 않고 메시지와 타입이 지정된 필드를 출력하는 메서드 (LogAttrs 참고)
+func (l *zerologLogger) logAttrs(level types.LogLevel, msg string, attrs []options.Attr) {
+	event := l.newEvent(level)
+	if event == nil {
+		return
+	}
+	if len(l.entry) > 0 {
+		event = event.Fields(l.overridden(msg, attrs))
+	}
+	for _, attr := range l.attrs {
+		if !hasAttr(attrs, attr.Key) {
+			event = zerologAttr(event, attr, l.timeFormat)
+		}
+	}
+	event = zerologAttrs(event, attrs, l.timeFormat)
+	if msg != "" {
+		event = event.Str(types.MessageField, msg)
+	}
+	l.send(event)
+}
+
+// zerologLevel : 로거 설정 레벨에 해당하는 zerolog 레벨을 반환하는 함수 (default: info)
+func zerologLevel(level types.LogLevel) zerolog.Level {
+	switch level {
+	case types.Debug:
+		return zerolog.DebugLevel
+	case types.Warn:
+		return zerolog.WarnLevel
+	case types.Error:
+		return zerolog.ErrorLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
+
+// newEvent : 레벨에 해당하는 이벤트를 반환하는 메서드 (비활성 레벨이면 nil)
+func (l *zerologLogger) newEvent(level types.LogLevel) *zerolog.Event {
+	switch level {
+	case types.Debug:
+		return l.logger.Debug()
+	case types.Warn:
+		return l.logger.Warn()
+	case types.Error:
+		return l.logger.Error()
+	case types.Fatal:
+		return l.logger.Fatal()
+	default:
+		return l.logger.Info()
+	}
+}
+
+// overridden : 메시지나 필드와 키가 겹치는 엔트리 필드를 뺀 엔트리를 반환하는 메서드
+//
+// 겹치는 키가 없으면 복사하지 않고 그대로 반환한다.
+func (l *zerologLogger) overridden(msg string, attrs []options.Attr) map[string]interface{} {
+	_, overlap := l.entry[types.MessageField]
+	overlap = overlap && msg != ""
+	for i := 0; !overlap && i < len(attrs); i++ {
+		_, overlap = l.entry[attrs[i].Key]
+	}
+	if !overlap {
+		return l.entry
+	}
+
+	entry := make(map[string]interface{}, len(l.entry))
+	for k, v := range l.entry {
+		if (k == types.MessageField && msg != "") || hasAttr(attrs, k) {
+			continue
+		}
+		entry[k] = v
+	}
+	return entry
+}
+
+// send : 시간, 호출 위치 등 공통 항목을 추가하여 이벤트를 출력하는 메서드
+//
+// ApplyOption 으로 받은 시각과 호출 위치는 이 이벤트에만 사용하고 지운다.
+func (l *zerologLogger) send(event *zerolog.Event) {
+	at, callerAt := l.at, l.callerAt
+	l.at, l.callerAt = time.Time{}, ""
+	if !event.Enabled() {
+		return
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	var buf [64]byte
+	event = event.RawJSON(zerolog.TimestampFieldName, appendJSONTime(buf[:0], at, l.timeFormat))
+	if l.caller {
+		if callerAt == "" {
+			callerAt = caller()
+		}
+		event = event.Str(types.CallerField, callerAt)
+	}
+	event.Send()
+}
+
+// zerologAttrs : 타입이 지정된 필드를 이벤트의 타입별 메서드로 추가하는 함수
+func zerologAttrs(event *zerolog.Event, attrs []options.Attr, timeFormat string) *zerolog.Event {
+	if event == nil {
+		return nil
+	}
+	for _, attr := range attrs {
+		event = zerologAttr(event, attr, timeFormat)
+	}
+	return event
+}
+
+// zerologAttr : 타입이 지정된 필드 하나를 이벤트의 타입별 메서드로 추가하는 함수
+func zerologAttr(event *zerolog.Event, attr options.Attr, timeFormat string) *zerolog.Event {
+	switch attr.Kind() {
+	case options.AttrString:
+		return event.Str(attr.Key, attr.Str())
+	case options.AttrInt64:
+		return event.Int64(attr.Key, attr.Int64())
+	case options.AttrFloat64:
+		return event.Float64(attr.Key, attr.Float64())
+	case options.AttrBool:
+		return event.Bool(attr.Key, attr.Bool())
+	case options.AttrDuration:
+		return event.Int64(attr.Key, attr.Duration().Milliseconds())
+	case options.AttrTime:
+		var buf [64]byte
+		return event.RawJSON(attr.Key, appendJSONTime(buf[:0], attr.Time(), timeFormat))
+	case options.AttrError:
+		if err := attr.Err(); err != nil {
+			return event.Str(attr.Key, err.Error())
+		}
+		return event
+	case options.AttrObject:
+		return event.Dict(attr.Key, zerologAttrs(zerolog.Dict(), attr.Attrs(), timeFormat))
+	case options.AttrArray:
+		return zerologArray(event, attr.Key, attr.Any(), timeFormat)
+	default:
+		return event.Interface(attr.Key, attr.Any())
+	}
+}
+
+// zerologArray : options.Array 로 만든 배열을 요소 타입별 메서드로 추가하는 함수
+func zerologArray(event *zerolog.Event, key string, values interface{}, timeFormat string) *zerolog.Event {
+	switch values := values.(type) {
+	case []string:
+		return event.Strs(key, values)
+	case []int:
+		return event.Ints(key, values)
+	case []int64:
+		return event.Ints64(key, values)
+	case []float64:
+		return event.Floats64(key, values)
+	case []bool:
+		return event.Bools(key, values)
+	case []time.Duration:
+		arr := zerolog.Arr()
+		for _, d := range values {
+			arr = arr.Int64(d.Milliseconds())
+		}
+		return event.Array(key, arr)
+	case []time.Time:
+		arr := zerolog.Arr()
+		for _, t := range values {
+			arr = arr.Str(t.Format(timeFormat))
+		}
+		return event.Array(key, arr)
+	default:
+		return event.Interface(key, values)
+	}
+}
+
+// mergeAttrs : 같은 키의 필드는 새 값으로 바꾸고, 새 키의 필드는 뒤에 추가하는 함수
+func mergeAttrs(attrs, added []options.Attr) []options.Attr {
+next:
+	for _, attr := range added {
+		for i := range attrs {
+			if attrs[i].Key == attr.Key {
+				attrs[i] = attr
+				continue next
+			}
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+// withoutFields : 키가 fields 에 있는 필드를 뺀 목록을 반환하는 함수 (attrs 를 재사용)
+func withoutFields(attrs []options.Attr, fields map[string]interface{}) []options.Attr {
+	kept := attrs[:0]
+	for _, attr := range attrs {
+		if _, ok := fields[attr.Key]; !ok {
+			kept = append(kept, attr)
+		}
+	}
+	return kept
+}
+
+// hasAttr : 필드 목록에 키가 있는지 반환하는 함수
+func hasAttr(attrs []options.Attr, key string) bool {
+	for i := range attrs {
+		if attrs[i].Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// appendJSONTime : 시각을 포맷에 맞춰 JSON 문자열로 덧붙이는 함수
+func appendJSONTime(dst []byte, t time.Time, format string) []byte {
+	dst = append(dst, '"')
+	dst = t.AppendFormat(dst, format)
+	return append(dst, '"')
 }
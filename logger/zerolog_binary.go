@@ -0,0 +1,15 @@
+//go:build binary_log
+
+package logger
+
+import "time"
+
+// zerologBinary : zerolog 가 binary_log 빌드 태그로 빌드되어 CBOR 로 기록하는지 여부
+//
+// 이 경우 types.CBOR 포맷은 다시 인코딩하지 않고 zerolog 의 출력을 그대로 기록한다.
+const zerologBinary = true
+
+// decodeZerologLine : zerolog 가 기록한 CBOR 로그 하나를 필드로 변환하는 함수
+func decodeZerologLine(line []byte) (map[string]interface{}, error) {
+	return decodeCBORLine(time.RFC3339Nano)(line)
+}
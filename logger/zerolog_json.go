@@ -0,0 +1,11 @@
+//go:build !binary_log
+
+package logger
+
+// zerologBinary : zerolog 가 binary_log 빌드 태그로 빌드되어 CBOR 로 기록하는지 여부
+const zerologBinary = false
+
+// decodeZerologLine : zerolog 가 기록한 JSON 로그 한 줄을 필드로 변환하는 함수
+func decodeZerologLine(line []byte) (map[string]interface{}, error) {
+	return decodeLogLine(line)
+}